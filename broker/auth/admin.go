@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+)
+
+// RequireToken protects a handler with a static bearer token, e.g. for the admin
+// API. The token is compared in constant time.
+func RequireToken(token string, next http.Handler) http.Handler {
+	expected := sha256.Sum256([]byte(token))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented := sha256.Sum256([]byte(Credential(r)))
+		if subtle.ConstantTimeCompare(expected[:], presented[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="wasimoff admin"`)
+			http.Error(w, ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
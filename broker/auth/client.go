@@ -0,0 +1,330 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"wasi.team/broker/storage"
+)
+
+var (
+	ErrRateLimited    = errors.New("rate limit exceeded")
+	ErrTooManyTasks   = errors.New("too many concurrent tasks")
+	ErrQuotaExceeded  = errors.New("storage quota exceeded")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrMissingAPIKey  = errors.New("api key required")
+	errKeyStoreFailed = errors.New("key lookup failed")
+)
+
+// ClientAuth authenticates Clients with API keys and enforces their limits. With
+// a nil KeyStore, all requests are allowed anonymously. It also derives the storage
+// namespaces of each request.
+type ClientAuth struct {
+	keys   KeyStore
+	shared []string // namespaces readable by everyone
+
+	// usage is tracked per identity, so multiple keys can share limits
+	mutex  sync.Mutex
+	usage  map[string]*Client
+	quotas map[string]Quota // overrides per identity
+}
+
+// Quota overrides the limits of all API keys of an identity. Zero values keep the
+// limit of the key.
+type Quota struct {
+	Rate          float64
+	Burst         int
+	MaxConcurrent int
+	StorageQuota  int64
+}
+
+// SetQuotas replaces the limit overrides. They apply to subsequent requests.
+func (a *ClientAuth) SetQuotas(quotas map[string]Quota) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.quotas = quotas
+}
+
+// Client is an authenticated API key with its current usage.
+type Client struct {
+	APIKey
+
+	mutex   sync.Mutex
+	tokens  float64   // token bucket for rate limiting
+	refill  time.Time // last time the bucket was refilled
+	running int       // currently running tasks
+	stored  int64     // bytes of files in storage, which were uploaded first by this identity
+}
+
+func NewClientAuth(keys KeyStore, shared []string) *ClientAuth {
+	return &ClientAuth{keys: keys, shared: shared, usage: make(map[string]*Client)}
+}
+
+// Enabled returns true if API keys are required.
+func (a *ClientAuth) Enabled() bool {
+	return a != nil && a.keys != nil
+}
+
+// Authenticate looks up the API key presented on a request.
+func (a *ClientAuth) Authenticate(r *http.Request) (*Client, error) {
+	key := Credential(r)
+	if key == "" {
+		key = r.Header.Get("X-Api-Key")
+	}
+	if key == "" {
+		return nil, ErrMissingAPIKey
+	}
+	apikey, err := a.keys.Lookup(key)
+	if err != nil {
+		log.Printf("ERR: client key lookup: %s", err)
+		return nil, errKeyStoreFailed
+	}
+	if apikey == nil || apikey.Identity == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// use shared usage state but always apply the current limits
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	client, ok := a.usage[apikey.Identity]
+	if !ok {
+		client = &Client{}
+		a.usage[apikey.Identity] = client
+	}
+	client.mutex.Lock()
+	if client.refill.IsZero() {
+		// first request of this identity, start with a full bucket
+		client.tokens, client.refill = float64(max(apikey.Burst, 1)), time.Now()
+	}
+	client.APIKey = *apikey
+	if quota, ok := a.quotas[apikey.Identity]; ok {
+		client.Rate = cmp.Or(quota.Rate, client.Rate)
+		client.Burst = cmp.Or(quota.Burst, client.Burst)
+		client.MaxConcurrent = cmp.Or(quota.MaxConcurrent, client.MaxConcurrent)
+		client.StorageQuota = cmp.Or(quota.StorageQuota, client.StorageQuota)
+	}
+	client.mutex.Unlock()
+	return client, nil
+}
+
+// Middleware rejects requests without a valid API key with 401 Unauthorized and
+// stores the authenticated Client and its namespaces in the request context.
+func (a *ClientAuth) Middleware(next http.Handler) http.Handler {
+	return a.handler(next, true)
+}
+
+// Optional is like Middleware but allows anonymous requests, even when API keys
+// are required elsewhere. Presented keys must still be valid.
+func (a *ClientAuth) Optional(next http.Handler) http.Handler {
+	return a.handler(next, false)
+}
+
+func (a *ClientAuth) handler(next http.Handler, required bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// authenticate the api key, if any
+		var client *Client
+		if a.Enabled() && (required || Credential(r) != "" || r.Header.Get("X-Api-Key") != "") {
+			var err error
+			if client, err = a.Authenticate(r); err != nil {
+				status := http.StatusUnauthorized
+				if errors.Is(err, errKeyStoreFailed) {
+					status = http.StatusInternalServerError
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer realm="wasimoff"`)
+				http.Error(w, err.Error(), status)
+				return
+			}
+			ctx = WithClient(ctx, client)
+			ctx = storage.WithUploader(ctx, client.Identity())
+		}
+
+		// derive the storage namespaces
+		ns, err := a.Namespaces(r, client)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrNamespaceForbidden) {
+				status = http.StatusForbidden
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		ctx = storage.WithNamespaces(ctx, ns)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ------------- namespaces -------------
+
+// NamespaceHeader selects a namespace explicitly. Browsers can use a `?namespace=`
+// query parameter on WebSocket connections instead.
+const NamespaceHeader = "X-Wasimoff-Namespace"
+
+var ErrNamespaceForbidden = errors.New("namespace not allowed for this api key")
+
+// Namespaces derives the storage namespaces of a request. Authenticated Clients
+// use their identity as their namespace and may select any namespace listed in
+// their key explicitly. Anonymous Clients are restricted to the default namespace.
+// The shared namespaces are readable by everyone.
+func (a *ClientAuth) Namespaces(r *http.Request, client *Client) (storage.Namespaces, error) {
+	own := client.Identity()
+
+	// explicitly selected namespace
+	write := own
+	selected := r.Header.Get(NamespaceHeader)
+	if selected == "" {
+		selected = r.URL.Query().Get("namespace")
+	}
+	if selected != "" {
+		if !storage.ValidNamespace(selected) {
+			return storage.Namespaces{}, fmt.Errorf("invalid namespace %q", selected)
+		}
+		if selected != own && (client == nil || !client.mayUse(selected)) {
+			return storage.Namespaces{}, ErrNamespaceForbidden
+		}
+		write = selected
+	}
+
+	// resolve names in the selected, own and shared namespaces
+	read := []string{write}
+	for _, ns := range append([]string{own}, a.shared...) {
+		if !slices.Contains(read, ns) {
+			read = append(read, ns)
+		}
+	}
+	return storage.Namespaces{Write: write, Read: read}, nil
+}
+
+// ------------- request context -------------
+
+type clientContextKey struct{}
+
+// WithClient returns a context carrying the authenticated Client.
+func WithClient(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// ClientFromContext returns the authenticated Client or nil if anonymous.
+func ClientFromContext(ctx context.Context) *Client {
+	client, _ := ctx.Value(clientContextKey{}).(*Client)
+	return client
+}
+
+// ------------- limits -------------
+
+// Identity returns the verified identity or an empty string for anonymous clients.
+func (c *Client) Identity() string {
+	if c == nil {
+		return ""
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.APIKey.Identity
+}
+
+// mayUse checks if a namespace is listed in the API key.
+func (c *Client) mayUse(namespace string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return slices.Contains(c.Namespaces, namespace)
+}
+
+// Admit checks the rate limit and concurrency for a new task. The returned
+// function must be called when the task is finished. Anonymous clients are
+// always admitted.
+func (c *Client) Admit() (release func(), err error) {
+	if c == nil {
+		return func() {}, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// refill the token bucket
+	if c.Rate > 0 {
+		burst := float64(max(c.Burst, 1))
+		now := time.Now()
+		c.tokens = math.Min(burst, c.tokens+now.Sub(c.refill).Seconds()*c.Rate)
+		c.refill = now
+		if c.tokens < 1 {
+			return nil, ErrRateLimited
+		}
+	}
+	if c.MaxConcurrent > 0 && c.running >= c.MaxConcurrent {
+		return nil, ErrTooManyTasks
+	}
+	if c.Rate > 0 {
+		c.tokens -= 1
+	}
+	c.running += 1
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mutex.Lock()
+			c.running -= 1
+			c.mutex.Unlock()
+		})
+	}, nil
+}
+
+// Reserve accounts for size bytes of new files in storage, if they fit into the
+// storage quota. Anonymous clients have no quota.
+func (c *Client) Reserve(size int64) error {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.StorageQuota > 0 && c.stored+size > c.StorageQuota {
+		return ErrQuotaExceeded
+	}
+	c.stored += size
+	return nil
+}
+
+// ReleaseStorage returns size bytes of deleted files to the storage quota of the
+// identity, which uploaded them, e.g. for use as a storage release hook.
+func (a *ClientAuth) ReleaseStorage(identity string, size int64) {
+	a.mutex.Lock()
+	client, ok := a.usage[identity]
+	a.mutex.Unlock()
+	if !ok {
+		return // nothing stored by this identity
+	}
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stored = max(client.stored-size, 0)
+}
+
+// SeedStorage sets the bytes in storage per identity, e.g. from the files which
+// are still stored when the Broker starts, so that quotas persist across restarts.
+func (a *ClientAuth) SeedStorage(usage map[string]int64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for identity, size := range usage {
+		client, ok := a.usage[identity]
+		if !ok {
+			client = &Client{}
+			a.usage[identity] = client
+		}
+		client.mutex.Lock()
+		client.stored = size
+		client.mutex.Unlock()
+	}
+}
+
+// ReserveStorage is a convenience function to reserve storage quota for the
+// Client in the given context, e.g. for use as a storage quota hook.
+func ReserveStorage(ctx context.Context, size int64) error {
+	return ClientFromContext(ctx).Reserve(size)
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Only a minimal subset of JSON Web Tokens is supported: compact serialization,
+// signed with HMAC-SHA256 using a shared secret and the registered claims below.
+
+// Claims are the registered JWT claims used to identify a Provider.
+type Claims struct {
+	Subject   string `json:"sub"`           // verified Provider identity
+	ID        string `json:"jti,omitempty"` // token identifier for revocation
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+var (
+	ErrTokenMalformed = errors.New("malformed token")
+	ErrTokenSignature = errors.New("invalid token signature")
+	ErrTokenExpired   = errors.New("token expired or not valid yet")
+)
+
+// the only accepted header, with a fixed algorithm
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// looksLikeJWT tells JWTs apart from opaque enrollment tokens.
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// SignJWT creates a signed token for the given claims.
+func SignJWT(secret []byte, claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed marshalling claims: %w", err)
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + jwtSignature(secret, unsigned), nil
+}
+
+// VerifyJWT checks the signature and validity period of a token and returns its claims.
+func VerifyJWT(secret []byte, token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	// check the header for the expected algorithm
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrTokenMalformed, err)
+	}
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrTokenMalformed, err)
+	}
+	if h.Alg != "HS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrTokenMalformed, h.Alg)
+	}
+
+	// verify signature before looking at the claims
+	expected := jwtSignature(secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrTokenSignature
+	}
+
+	// decode claims and check validity period
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload: %w", ErrTokenMalformed, err)
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("%w: payload: %w", ErrTokenMalformed, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrTokenMalformed)
+	}
+	if (claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt) ||
+		(claims.NotBefore != 0 && now.Unix() < claims.NotBefore) {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func jwtSignature(secret []byte, unsigned string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
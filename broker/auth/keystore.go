@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// APIKey holds the identity and limits of a Client API key. Zero values for any
+// of the limits mean that this limit is not enforced.
+type APIKey struct {
+	Identity      string  `json:"identity"`   // used as the requester of tasks
+	Rate          float64 `json:"rate"`       // sustained task submissions per second
+	Burst         int     `json:"burst"`      // maximum burst of task submissions
+	MaxConcurrent int     `json:"concurrent"` // maximum tasks running concurrently
+	StorageQuota  int64   `json:"quota"`      // maximum bytes uploaded to storage
+
+	// additional namespaces this key may use besides its identity
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// KeyStore holds API keys. The keys themselves are never logged.
+type KeyStore interface {
+	Lookup(key string) (*APIKey, error) // returns nil if the key is unknown
+	Add(key string, apikey *APIKey) error
+}
+
+// OpenKeyStore opens a JSON file or a BoltDB, when the path is prefixed with boltdb://
+func OpenKeyStore(path string) (KeyStore, error) {
+	if dbpath, ok := strings.CutPrefix(path, "boltdb://"); ok {
+		return NewBoltKeyStore(dbpath)
+	}
+	return NewFileKeyStore(path)
+}
+
+// NewAPIKey generates a new random key string.
+func NewAPIKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read randomness: %w", err)
+	}
+	return "wsk_" + hex.EncodeToString(buf), nil
+}
+
+// ------------- plain json file -------------
+
+// FileKeyStore keeps API keys in a JSON object, which maps keys to their settings.
+// The file is reloaded on SIGHUP, so keys can be added and revoked without restart.
+type FileKeyStore struct {
+	sync.RWMutex
+	path string
+	keys map[string]*APIKey
+}
+
+func NewFileKeyStore(path string) (*FileKeyStore, error) {
+	ks := &FileKeyStore{path: path}
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			log.Printf("Received SIGHUP, reloading client keys from %q", ks.path)
+			if err := ks.reload(); err != nil {
+				log.Printf("ERR: failed key reload, keeping old keys: %v", err)
+			}
+		}
+	}()
+	return ks, nil
+}
+
+func (ks *FileKeyStore) reload() error {
+	keys := make(map[string]*APIKey)
+	buf, err := os.ReadFile(ks.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed reading keys file: %w", err)
+	}
+	if len(buf) > 0 {
+		if err := json.Unmarshal(buf, &keys); err != nil {
+			return fmt.Errorf("failed parsing keys file: %w", err)
+		}
+	}
+	ks.Lock()
+	defer ks.Unlock()
+	ks.keys = keys
+	return nil
+}
+
+func (ks *FileKeyStore) Lookup(key string) (*APIKey, error) {
+	ks.RLock()
+	defer ks.RUnlock()
+	return ks.keys[key], nil
+}
+
+func (ks *FileKeyStore) Add(key string, apikey *APIKey) error {
+	ks.Lock()
+	defer ks.Unlock()
+	keys := make(map[string]*APIKey, len(ks.keys)+1)
+	for k, v := range ks.keys {
+		keys[k] = v
+	}
+	keys[key] = apikey
+	buf, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshalling keys: %w", err)
+	}
+	if err := os.WriteFile(ks.path, buf, 0o600); err != nil {
+		return fmt.Errorf("failed writing keys file: %w", err)
+	}
+	ks.keys = keys
+	return nil
+}
+
+// ------------- boltdb -------------
+
+// BoltKeyStore keeps API keys in a BoltDB bucket, encoded as JSON values. Changes
+// made to the database by other processes are visible immediately.
+type BoltKeyStore struct {
+	db *bolt.DB
+}
+
+var keyBucket = []byte("apikeys")
+
+func NewBoltKeyStore(path string) (*BoltKeyStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open keys db: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(keyBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create keys bucket: %w", err)
+	}
+	return &BoltKeyStore{db}, nil
+}
+
+func (ks *BoltKeyStore) Lookup(key string) (apikey *APIKey, err error) {
+	err = ks.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(keyBucket).Get([]byte(key))
+		if buf == nil {
+			return nil
+		}
+		apikey = &APIKey{}
+		return json.Unmarshal(buf, apikey)
+	})
+	return
+}
+
+func (ks *BoltKeyStore) Add(key string, apikey *APIKey) error {
+	buf, err := json.Marshal(apikey)
+	if err != nil {
+		return fmt.Errorf("failed marshalling key: %w", err)
+	}
+	return ks.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(keyBucket).Put([]byte(key), buf)
+	})
+}
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Policy decides whether Providers must present a credential to connect.
+type Policy string
+
+const (
+	PolicyOpen  Policy = "open"  // anyone can connect, credentials are optional
+	PolicyToken Policy = "token" // a valid enrollment token or JWT is required
+)
+
+var (
+	ErrUnauthenticated = errors.New("credential required")
+	ErrInvalidToken    = errors.New("unknown enrollment token")
+	ErrRevoked         = errors.New("credential revoked")
+)
+
+// ProviderAuth verifies the credentials that Providers present when connecting.
+// Enrollment tokens are kept in a plain text file with one `<token> <identity>`
+// pair per line; lines of the form `revoke <identity or jti>` revoke matching
+// credentials, including JWTs. The file is reloaded on SIGHUP, so tokens can be
+// added and revoked without restarting the Broker; connected Providers are checked
+// again on every SIGHUP, even without a file, e.g. to drop expired JWTs.
+type ProviderAuth struct {
+	sync.RWMutex
+	policy  Policy
+	path    string              // path to enrollment tokens file
+	secret  []byte              // HMAC secret for JWTs
+	tokens  map[[32]byte]string // sha256(token) -> identity
+	revoked map[string]struct{} // revoked identities or JWT IDs
+
+	// functions to call after a successful reload
+	reloaded []func()
+}
+
+// NewProviderAuth loads the enrollment tokens file and starts the reload handler.
+func NewProviderAuth(policy, path, secret string) (*ProviderAuth, error) {
+	a := &ProviderAuth{
+		policy: Policy(policy),
+		path:   path,
+		secret: []byte(secret),
+	}
+	if a.policy != PolicyOpen && a.policy != PolicyToken {
+		return nil, fmt.Errorf("unknown provider auth policy %q", policy)
+	}
+	if a.policy == PolicyToken && a.path == "" && len(a.secret) == 0 {
+		return nil, fmt.Errorf("policy %q requires a tokens file or a JWT secret", policy)
+	}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+
+	// reload from filesystem and revalidate connections on SIGHUP
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			if a.path != "" {
+				log.Printf("Received SIGHUP, reloading provider tokens from %q", a.path)
+			}
+			if err := a.Reload(); err != nil {
+				log.Printf("ERR: failed token reload, keeping old tokens: %v", err)
+			}
+		}
+	}()
+	return a, nil
+}
+
+// SetSecret replaces the HMAC secret for JWTs, e.g. after the configuration file
+// was reloaded, and notifies all listeners if it changed.
+func (a *ProviderAuth) SetSecret(secret string) error {
+	a.Lock()
+	if string(a.secret) == secret {
+		a.Unlock()
+		return nil
+	}
+	if a.policy == PolicyToken && a.path == "" && secret == "" {
+		a.Unlock()
+		return fmt.Errorf("policy %q requires a tokens file or a JWT secret", a.policy)
+	}
+	a.secret = []byte(secret)
+	listeners := a.reloaded
+	a.Unlock()
+	for _, f := range listeners {
+		f()
+	}
+	return nil
+}
+
+// OnReload registers a function to be called after tokens were reloaded, e.g. to
+// disconnect Providers whose credentials were revoked.
+func (a *ProviderAuth) OnReload(f func()) {
+	a.Lock()
+	defer a.Unlock()
+	a.reloaded = append(a.reloaded, f)
+}
+
+// Reload the tokens file and notify all listeners.
+func (a *ProviderAuth) Reload() error {
+	if err := a.reload(); err != nil {
+		return err
+	}
+	a.RLock()
+	listeners := a.reloaded
+	a.RUnlock()
+	for _, f := range listeners {
+		f()
+	}
+	return nil
+}
+
+func (a *ProviderAuth) reload() error {
+	tokens := make(map[[32]byte]string)
+	revoked := make(map[string]struct{})
+
+	if a.path != "" {
+		file, err := os.Open(a.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed opening tokens file: %w", err)
+		}
+		if file != nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for n := 1; scanner.Scan(); n++ {
+				fields := strings.Fields(scanner.Text())
+				if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+					continue // empty line or comment
+				}
+				if len(fields) != 2 {
+					return fmt.Errorf("tokens file line %d: expected two fields", n)
+				}
+				if fields[0] == "revoke" {
+					revoked[fields[1]] = struct{}{}
+				} else {
+					tokens[sha256.Sum256([]byte(fields[0]))] = fields[1]
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("failed reading tokens file: %w", err)
+			}
+		}
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	a.tokens = tokens
+	a.revoked = revoked
+	return nil
+}
+
+// Credential extracts the presented credential from a request. Browsers can not
+// set headers on WebSocket connections, so a `?token=` query parameter is accepted
+// as well as a bearer token in the Authorization header.
+func Credential(r *http.Request) string {
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(bearer)
+	}
+	return r.URL.Query().Get("token")
+}
+
+// Authenticate checks the credential presented by a connecting Provider and
+// returns its verified identity. An empty identity without error means that an
+// anonymous connection is allowed by the policy.
+func (a *ProviderAuth) Authenticate(r *http.Request) (identity string, err error) {
+	credential := Credential(r)
+	if credential == "" {
+		if a.policy == PolicyOpen {
+			return "", nil
+		}
+		return "", ErrUnauthenticated
+	}
+	return a.Verify(credential)
+}
+
+// Verify checks a single credential and returns its identity. It can be called
+// again later to check whether a credential was revoked in the meantime.
+func (a *ProviderAuth) Verify(credential string) (identity string, err error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	// signed tokens carry their identity
+	if looksLikeJWT(credential) && len(a.secret) > 0 {
+		claims, err := VerifyJWT(a.secret, credential, time.Now())
+		if err != nil {
+			return "", err
+		}
+		if a.isRevoked(claims.Subject) || (claims.ID != "" && a.isRevoked(claims.ID)) {
+			return "", ErrRevoked
+		}
+		return claims.Subject, nil
+	}
+
+	// otherwise lookup the enrollment token by its hash
+	hash := sha256.Sum256([]byte(credential))
+	for known, identity := range a.tokens {
+		if subtle.ConstantTimeCompare(known[:], hash[:]) == 1 {
+			if a.isRevoked(identity) {
+				return "", ErrRevoked
+			}
+			return identity, nil
+		}
+	}
+	return "", ErrInvalidToken
+}
+
+func (a *ProviderAuth) isRevoked(key string) bool {
+	_, ok := a.revoked[key]
+	return ok
+}
+
+// Enroll issues a new random enrollment token for the identity and appends it to
+// the tokens file. Running Brokers pick it up after a SIGHUP.
+func Enroll(path, identity string) (token string, err error) {
+	if path == "" {
+		return "", fmt.Errorf("no tokens file configured")
+	}
+	if identity == "" || strings.ContainsAny(identity, " \t\n#") {
+		return "", fmt.Errorf("invalid identity %q", identity)
+	}
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read randomness: %w", err)
+	}
+	token = hex.EncodeToString(buf)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed opening tokens file: %w", err)
+	}
+	defer file.Close()
+	if _, err := fmt.Fprintf(file, "%s %s\n", token, identity); err != nil {
+		return "", fmt.Errorf("failed writing tokens file: %w", err)
+	}
+	return token, nil
+}
@@ -1,45 +1,162 @@
 package config
 
+import "time"
+
 // Prefix for envionment variable names, so HTTP_LISTEN becomes WASIMOFF_HTTP_LISTEN.
 const envprefix = "WASIMOFF"
 
-// TODO: this is getting complex .. it should really be a TOML
-
-// Configuration via environment variables with github.com/kelseyhightower/envconfig.
+// Configuration via environment variables with github.com/kelseyhightower/envconfig
+// and an optional TOML file, see file.go. Settings marked as reloadable are updated
+// on SIGHUP.
 type Configuration struct {
 
+	// CONFIG is the path to a TOML configuration file. Defaults to wasimoff.toml in
+	// the working directory, if it exists.
+	Config string `desc:"Path to a TOML configuration file" toml:"-"`
+
 	// HTTP_LISTEN is the listening address for the HTTP server.
-	HttpListen string `split_words:"true" default:"localhost:4080" desc:"Listening Addr for HTTP server"`
+	HttpListen string `split_words:"true" default:"localhost:4080" desc:"Listening Addr for HTTP server" toml:"http_listen"`
 
 	// HTTP_CERT and HTTP_KEY are paths to a TLS keypair to optionally use for the HTTP server.
 	// If none are given, a plaintext server is started. Reload keys with SIGHUP.
-	HttpCert string `split_words:"true" desc:"Path to TLS certificate to use"`
-	HttpKey  string `split_words:"true" desc:"Path to TLS key to use"`
+	HttpCert string `split_words:"true" desc:"Path to TLS certificate to use" toml:"http_cert"`
+	HttpKey  string `split_words:"true" desc:"Path to TLS key to use" toml:"http_key"`
+
+	// SHUTDOWN_TIMEOUT is the time to wait for queued and in-flight tasks when the broker
+	// receives a SIGTERM. Remaining tasks are cancelled afterwards.
+	ShutdownTimeout time.Duration `split_words:"true" default:"30s" desc:"Time to wait for queued and in-flight tasks on SIGTERM" toml:"shutdown_timeout"`
+
+	// SESSION_GRACE is the time that disconnected Providers are kept to resume their
+	// session on a new connection. Running tasks are not retried during this period.
+	SessionGrace time.Duration `split_words:"true" default:"30s" desc:"Time to keep disconnected Providers for session resumption" toml:"session_grace"`
+
+	// PROVIDER_AUTH is the authentication policy for Providers: "open" allows anonymous
+	// connections, "token" requires an enrollment token or a signed JWT. Tokens are read
+	// from PROVIDER_TOKENS, which is reloaded on SIGHUP. JWTs must be signed with HS256
+	// using the PROVIDER_JWT_SECRET, which is reloadable. Issue new enrollment tokens with
+	// `-enroll <name>`.
+	ProviderAuth      string `split_words:"true" default:"open" desc:"Provider authentication policy: open or token" toml:"provider_auth"`
+	ProviderTokens    string `split_words:"true" desc:"Path to file with Provider enrollment tokens" toml:"provider_tokens"`
+	ProviderJwtSecret string `split_words:"true" desc:"Secret to verify Provider JWTs" toml:"provider_jwt_secret"`
+
+	// CLIENT_KEYS is the path to a JSON file with API keys for Clients, or a BoltDB
+	// database when prefixed with boltdb://. Each key carries an identity and optional
+	// limits. When empty, the Client endpoints remain open for anonymous use. Issue new
+	// keys with `-apikey <identity>`.
+	ClientKeys string `split_words:"true" desc:"Path to Client API keys (JSON file or boltdb://)" toml:"client_keys"`
+
+	// SHARED_NAMESPACES are storage namespaces that every Client can resolve names
+	// from, e.g. for common binaries. Uploading into them requires a key which lists
+	// the namespace explicitly.
+	SharedNamespaces []string `split_words:"true" default:"public" desc:"Storage namespaces readable by all Clients" toml:"shared_namespaces"`
+
+	// CONTRIBUTIONS is the path to a JSON file, which persists the cumulative work done
+	// by each Provider identity across restarts. When empty, totals are kept in memory.
+	Contributions string `desc:"Path to a JSON file to persist Provider contributions" toml:"contributions"`
+
+	// CLIENT_EVENT_INTERVAL is the default and minimum interval of cluster events for
+	// Clients, which subscribed on their WebSocket or the SSE endpoint.
+	ClientEventInterval time.Duration `split_words:"true" default:"1s" desc:"Minimum interval of cluster events for Clients" toml:"client_event_interval"`
+
+	// OTLP_ENDPOINT is the base URL of an OpenTelemetry collector, which receives the
+	// traces of completed tasks as spans over OTLP/HTTP with JSON encoding. Only tasks
+	// submitted with a trace are exported. OTLP_HEADERS are added to each request.
+	OtlpEndpoint string            `split_words:"true" desc:"OTLP/HTTP collector URL for task trace spans" toml:"otlp_endpoint"`
+	OtlpHeaders  map[string]string `split_words:"true" desc:"Headers for OTLP requests (key:value,...)" toml:"otlp_headers"`
+
+	// TRACE_SAMPLING is the fraction of tasks between 0 and 1, for which the Broker
+	// starts a trace itself, if the Client did not request one. Reloadable.
+	TraceSampling float64 `split_words:"true" default:"0" desc:"Fraction of tasks traced by the Broker" toml:"trace_sampling"`
+
+	// TRACE_HISTORY is the number of recently traced tasks, which are kept in memory to
+	// export their timelines in the Chrome trace-event format. Zero disables it.
+	TraceHistory int `split_words:"true" default:"1000" desc:"Number of recent task traces kept for timeline export" toml:"trace_history"`
+
+	// ADMIN_TOKEN is a bearer token to access the admin API on /api/admin. When empty,
+	// the admin API is disabled.
+	AdminToken string `split_words:"true" desc:"Bearer token for the admin API, disabled if empty" toml:"admin_token"`
 
 	// ALLOWED_ORIGINS is a list of allowed Origin headers for transport connections.
-	AllowedOrigins []string `split_words:"true" desc:"List of allowed Origins for WebSocket"`
+	// The file can set them per endpoint in an [origins] table. Reloadable.
+	AllowedOrigins []string `split_words:"true" desc:"List of allowed Origins for WebSocket" toml:"allowed_origins"`
 
 	// STATIC_FILES is a path with static files to serve; usually the webprovider frontend dist.
-	StaticFiles string `split_words:"true" default:"../webprovider/dist/" desc:"Serve static files on \"/\" from here"`
+	StaticFiles string `split_words:"true" default:"../webprovider/dist/" desc:"Serve static files on \"/\" from here" toml:"static_files"`
 
 	// FILESTORAGE is a path to use for a persistent file storage.
 	// An empty string will use an ephemeral in-memory map[string]*File.
 	// A path prefixed with boltdb:// will use a single BoltDB file with blobs.
+	// A URL s3://bucket/prefix will use an S3-compatible object storage, see S3.
 	// Any other path (or when prefixed with dirfs://) will use bare files in a directory.
-	FileStorage string `desc:"Use directory for persistent file storage" default:":memory:"`
+	FileStorage string `desc:"Use directory for persistent file storage" default:":memory:" toml:"filestorage"`
+
+	// STORAGE_TTL removes files without any names, which were not accessed for this
+	// duration, and STORAGE_MAX_SIZE evicts the least recently used files when the
+	// total size of the storage exceeds it. Zero disables either. Reloadable.
+	StorageTTL     time.Duration `split_words:"true" desc:"Remove unnamed files not accessed for this duration" default:"0" toml:"storage_ttl"`
+	StorageMaxSize int64         `split_words:"true" desc:"Evict least recently used files above this total size" default:"0" toml:"storage_max_size"`
+
+	// ARTIFACT_TTL removes stored task artifacts and large stdin inputs, which were not
+	// accessed for this duration, unless STORAGE_TTL is shorter. Zero disables it. Reloadable.
+	ArtifactTTL time.Duration `split_words:"true" desc:"Remove stored task artifacts not accessed for this duration" default:"24h" toml:"artifact_ttl"`
+
+	// STORAGE_MAX_FILE_SIZE limits the size of a single uploaded file and
+	// STORAGE_MAX_UNPACKED the total uncompressed size of an archive.
+	StorageMaxFileSize int64 `split_words:"true" desc:"Maximum size of a single file in storage, zero is unlimited" default:"1073741824" toml:"storage_max_file_size"`
+	StorageMaxUnpacked int64 `split_words:"true" desc:"Maximum uncompressed size of an archive, zero is unlimited" default:"4294967296" toml:"storage_max_unpacked"`
+
+	// REMOTE_HOSTS allows tasks to reference files by http(s) URLs on these hosts,
+	// which the Broker fetches once and caches in the storage. Wildcards like
+	// `*.example.com` match all subdomains. REMOTE_MAX_SIZE limits fetched files.
+	RemoteHosts   []string `split_words:"true" desc:"Hosts to fetch URL refs in tasks from" toml:"remote_hosts"`
+	RemoteMaxSize int64    `split_words:"true" desc:"Maximum size of a fetched file, zero is unlimited" default:"268435456" toml:"remote_max_size"`
+
+	// S3 configures the object storage for a FILESTORAGE of s3://bucket/prefix.
+	S3 S3 `toml:"s3"`
+
+	// MAX_MESSAGE_SIZE limits the size of a single message on sockets and RPC requests.
+	// Larger files must be transferred in chunks, which are much smaller by default.
+	MaxMessageSize int64 `split_words:"true" desc:"Maximum size of a single message in bytes" default:"33554432" toml:"max_message_size"`
+
+	// PROVIDER_MAX_MESSAGE_SIZE limits the size of a single message from a Provider
+	// instead, since task results carry their output and artifacts inline.
+	ProviderMaxMessageSize int64 `split_words:"true" desc:"Maximum size of a single message from Providers in bytes" default:"1073741824" toml:"provider_max_message_size"`
 
 	// CLOUD_CREDENTIALS and CLOUD_FUNCTION are used to enable offloading functions to
 	// the Google Cloud Run Function, using the given service account credentials JSON.
-	CloudCredentials string `desc:"Path to GCP service account credentials JSON" default:"" split_words:"true"`
-	CloudFunction    string `desc:"URL of the function to invoke for cloud offloading" default:"" split_words:"true"`
-	CloudConcurrency int    `desc:"Number of maximum simultaneous cloud invocations" default:"32" split_words:"true"`
+	CloudCredentials string `desc:"Path to GCP service account credentials JSON" default:"" split_words:"true" toml:"cloud_credentials"`
+	CloudFunction    string `desc:"URL of the function to invoke for cloud offloading" default:"" split_words:"true" toml:"cloud_function"`
+	CloudConcurrency int    `desc:"Number of maximum simultaneous cloud invocations" default:"32" split_words:"true" toml:"cloud_concurrency"`
+
+	// SCHEDULER selects the strategy to pick Providers and limits the dispatcher.
+	Scheduler Scheduler `toml:"scheduler"`
+
+	// Structured settings, which can only be given in the configuration file.
+	Cloud   []CloudTarget    `ignored:"true" toml:"cloud"`  // additional cloud offloading targets
+	Quotas  map[string]Quota `ignored:"true" toml:"quotas"` // limits per Client identity, reloadable
+	Origins Origins          `ignored:"true" toml:"origins"`
 
 	// BENCHMODE activates a mode where the Broker produces infinite workload by itself
-	Benchmode int `desc:"Benchmarking mode with n concurrent tasks" default:"0"`
+	Benchmode int `desc:"Benchmarking mode with n concurrent tasks" default:"0" toml:"benchmode"`
 
 	// METRICS will expose metrics for Prometheus via /metrics
-	Metrics bool `desc:"Enable Prometheus exporter on /metrics" default:"false"`
+	Metrics bool `desc:"Enable Prometheus exporter on /metrics" default:"false" toml:"metrics"`
 
 	// DEBUG will enable the pprof handlers under /debug/pprof
-	Debug bool `desc:"Enable profiling handlers on /debug/pprof" default:"false"`
+	Debug bool `desc:"Enable profiling handlers on /debug/pprof" default:"false" toml:"debug"`
+}
+
+// Scheduler parameters, e.g. SCHEDULER_SELECTOR or a [scheduler] table.
+type Scheduler struct {
+	Selector    string `default:"simplematch" desc:"Provider selection: simplematch, roundrobin or anyfree" toml:"selector"`
+	Concurrency int    `default:"32" desc:"Number of tasks being scheduled simultaneously" toml:"concurrency"`
+	Retries     int    `default:"10" desc:"Number of scheduling attempts per task" toml:"retries"`
+}
+
+// S3 connection parameters, e.g. S3_ENDPOINT or an [s3] table. Credentials are read
+// from the usual AWS_* or MINIO_* environment variables.
+type S3 struct {
+	Endpoint string        `default:"s3.amazonaws.com" desc:"Host or URL of the S3 API, http:// disables TLS" toml:"endpoint"`
+	Region   string        `desc:"Region of the bucket, detected if empty" toml:"region"`
+	Presign  time.Duration `default:"0" desc:"Redirect downloads to presigned URLs valid for this duration" toml:"presign"`
 }
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The configuration can also be read from a TOML file, which is given in the
+// CONFIG environment variable or found as wasimoff.toml in the working directory.
+// Keys are the lowercase environment variable names without prefix, e.g.
+// `http_listen`, and tables group nested settings like `[scheduler]`. Environment
+// variables still take precedence over the file. Structured settings, which can
+// not be expressed as environment variables, are only read from the file:
+//
+//	[[cloud]]                 # multiple cloud offloading targets
+//	name = "gcp"
+//	function = "https://..."
+//	credentials = "sa.json"
+//	concurrency = 32
+//
+//	[quotas.alice]            # per-identity limits, override the API keys
+//	rate = 10.0
+//	concurrent = 4
+//
+//	[origins]                 # allowed Origins per WebSocket endpoint
+//	provider = ["https://wasi.team"]
+//	client = ["*"]
+
+// defaultConfigFile is read if it exists and no CONFIG is given.
+const defaultConfigFile = "wasimoff.toml"
+
+// CloudTarget is a function to offload tasks to. Tasks are distributed over all
+// targets, each with its own concurrency limit.
+type CloudTarget struct {
+	Name        string `toml:"name"`
+	Function    string `toml:"function"`    // URL of the function to invoke
+	Credentials string `toml:"credentials"` // path to GCP service account credentials JSON
+	Concurrency int    `toml:"concurrency"` // maximum simultaneous invocations
+}
+
+// Quota overrides the limits of all API keys of an identity. Zero values keep the
+// limit of the key.
+type Quota struct {
+	Rate          float64 `toml:"rate"`       // sustained task submissions per second
+	Burst         int     `toml:"burst"`      // maximum burst of task submissions
+	MaxConcurrent int     `toml:"concurrent"` // maximum tasks running concurrently
+	StorageQuota  int64   `toml:"storage"`    // maximum bytes uploaded to storage
+}
+
+// Origins are the allowed Origin patterns of each WebSocket endpoint. A nil list
+// uses the default of the endpoint.
+type Origins struct {
+	Provider []string `toml:"provider"`
+	Client   []string `toml:"client"`
+}
+
+// ProviderOrigins returns the allowed Origins of the Provider endpoint, which
+// default to ALLOWED_ORIGINS.
+func (c *Configuration) ProviderOrigins() []string {
+	if c.Origins.Provider != nil {
+		return c.Origins.Provider
+	}
+	return c.AllowedOrigins
+}
+
+// ClientOrigins returns the allowed Origins of the Client endpoint, which default
+// to any Origin because Clients can be anywhere.
+func (c *Configuration) ClientOrigins() []string {
+	if c.Origins.Client != nil {
+		return c.Origins.Client
+	}
+	return []string{"*"}
+}
+
+// CloudTargets returns all configured cloud offloading targets, including the
+// single target given with CLOUD_FUNCTION. Unnamed targets are numbered.
+func (c *Configuration) CloudTargets() []CloudTarget {
+	var targets []CloudTarget
+	if c.CloudFunction != "" {
+		targets = append(targets, CloudTarget{
+			Name:        "cloud",
+			Function:    c.CloudFunction,
+			Credentials: c.CloudCredentials,
+			Concurrency: c.CloudConcurrency,
+		})
+	}
+	for i, target := range c.Cloud {
+		if target.Name == "" {
+			target.Name = fmt.Sprintf("cloud-%d", i+1)
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// configFile returns the path of the configuration file or an empty string.
+func configFile(path string) string {
+	if path != "" {
+		return path
+	}
+	if _, err := os.Stat(defaultConfigFile); err == nil {
+		return defaultConfigFile
+	}
+	return ""
+}
+
+// decodeFile reads the TOML file into conf. Only keys present in the file are
+// applied and only if their environment variable is not set.
+func decodeFile(path string, conf *Configuration) error {
+	var file Configuration
+	meta, err := toml.DecodeFile(path, &file)
+	if err != nil {
+		return fmt.Errorf("failed parsing %q: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return fmt.Errorf("unknown keys in %q: %v", path, undecoded)
+	}
+	overlay(reflect.ValueOf(conf).Elem(), reflect.ValueOf(file), meta, nil, envprefix)
+	return nil
+}
+
+// overlay copies the fields from file to conf recursively, which were defined in
+// the TOML file and not overridden by an environment variable.
+func overlay(conf, file reflect.Value, meta toml.MetaData, path []string, prefix string) {
+	for i := range conf.NumField() {
+		field := conf.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := append(path[:len(path):len(path)], name)
+		env := prefix + "_" + envName(field)
+		ignored := field.Tag.Get("ignored") == "true"
+
+		// recurse into tables, which are also processed by envconfig
+		if field.Type.Kind() == reflect.Struct && !ignored {
+			overlay(conf.Field(i), file.Field(i), meta, key, env)
+			continue
+		}
+		if !meta.IsDefined(key...) {
+			continue
+		}
+		if _, set := os.LookupEnv(env); set && !ignored {
+			continue
+		}
+		conf.Field(i).Set(file.Field(i))
+	}
+}
+
+// same as in github.com/kelseyhightower/envconfig
+var (
+	gatherRegexp  = regexp.MustCompile("([^A-Z]+|[A-Z]+[^A-Z]+|[A-Z]+)")
+	acronymRegexp = regexp.MustCompile("([A-Z]+)([A-Z][^A-Z]+)")
+)
+
+// envName derives the environment variable name of a field without the prefix
+// like envconfig does, e.g. HTTP_LISTEN for HttpListen with split_words.
+func envName(field reflect.StructField) string {
+	if alt := field.Tag.Get("envconfig"); alt != "" {
+		return strings.ToUpper(alt)
+	}
+	if field.Tag.Get("split_words") != "true" {
+		return strings.ToUpper(field.Name)
+	}
+	var words []string
+	for _, match := range gatherRegexp.FindAllString(field.Name, -1) {
+		if m := acronymRegexp.FindStringSubmatch(match); len(m) == 3 {
+			words = append(words, m[1], m[2])
+		} else {
+			words = append(words, match)
+		}
+	}
+	return strings.ToUpper(strings.Join(words, "_"))
+}
@@ -2,9 +2,13 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"slices"
+	"sync"
+	"syscall"
 	"text/tabwriter"
 
 	"github.com/joho/godotenv"
@@ -12,10 +16,16 @@ import (
 )
 
 // Keep a global lazy-loaded instance of the configuration
-var configuration *Configuration
+var (
+	mutex         sync.Mutex
+	configuration *Configuration
+	reloaded      []func(*Configuration)
+)
 
 // GetConfiguration returns a lazily-loaded configuration parsed from environment.
 func GetConfiguration() (conf Configuration) {
+	mutex.Lock()
+	defer mutex.Unlock()
 	if configuration == nil {
 		configuration = loadConfiguration()
 	}
@@ -23,8 +33,7 @@ func GetConfiguration() (conf Configuration) {
 }
 
 // loadConfiguration checks if user requested help (-h/--help) and prints usage information
-// or returns the configuration parsed from environment variables.
-// TODO: replace with spf13/viper if file handling is needed
+// or returns the configuration parsed from environment variables and the config file.
 func loadConfiguration() (conf *Configuration) {
 	conf = &Configuration{}
 
@@ -46,15 +55,86 @@ func loadConfiguration() (conf *Configuration) {
 		}
 	}
 
-	// parse configuration from environment variables
-	if err := envconfig.Process(envprefix, conf); err != nil {
+	// parse configuration from environment variables and file
+	conf, err := parse()
+	if err != nil {
 		log.Fatalf("failed parsing config: %s", err)
 	}
+
+	// reload the file on SIGHUP
+	if conf.Config != "" {
+		log.Printf("Loaded configuration file %q", conf.Config)
+		go func() {
+			hup := make(chan os.Signal, 1)
+			signal.Notify(hup, syscall.SIGHUP)
+			for range hup {
+				log.Printf("Received SIGHUP, reloading configuration from %q", conf.Config)
+				if err := reload(); err != nil {
+					log.Printf("ERR: failed configuration reload, keeping old settings: %v", err)
+				}
+			}
+		}()
+	}
 	return
 }
 
+// parse the environment variables first, so their defaults can be overridden by
+// the file and the file can be given in the environment
+func parse() (*Configuration, error) {
+	conf := &Configuration{}
+	if err := envconfig.Process(envprefix, conf); err != nil {
+		return nil, err
+	}
+	if conf.Config = configFile(conf.Config); conf.Config != "" {
+		if err := decodeFile(conf.Config, conf); err != nil {
+			return nil, err
+		}
+	}
+	return conf, nil
+}
+
+// OnReload registers a function to be called with the new configuration after the
+// file was reloaded. It should only apply the settings marked as reloadable.
+func OnReload(f func(conf *Configuration)) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	reloaded = append(reloaded, f)
+}
+
+// reload parses the configuration again and notifies all listeners.
+func reload() error {
+	conf, err := parse()
+	if err != nil {
+		return err
+	}
+	mutex.Lock()
+	configuration = conf
+	listeners := reloaded
+	mutex.Unlock()
+	for _, f := range listeners {
+		f(conf)
+	}
+	return nil
+}
+
 // see https://github.com/kelseyhightower/envconfig/blob/v1.4.0/usage.go#L31
 const usageHelpFormat = `This application is configured with the following environment variables:
 KEY	DESCRIPTION	DEFAULT
 {{range .}}{{usage_key .}}	{{usage_description .}}	{{usage_default .}}
 {{end}}`
+
+// GoString masks secrets when the configuration is logged with %#v.
+func (c *Configuration) GoString() string {
+	type plain Configuration
+	masked := plain(*c)
+	if masked.ProviderJwtSecret != "" {
+		masked.ProviderJwtSecret = "<redacted>"
+	}
+	if len(masked.OtlpHeaders) > 0 {
+		masked.OtlpHeaders = map[string]string{"<redacted>": ""}
+	}
+	if masked.AdminToken != "" {
+		masked.AdminToken = "<redacted>"
+	}
+	return fmt.Sprintf("%#v", &masked)
+}
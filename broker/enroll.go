@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"wasi.team/broker/auth"
+	"wasi.team/broker/config"
+	"wasi.team/broker/net/transport"
+	"wasi.team/broker/storage"
+)
+
+// enroll prints a new credential for a Provider with the given identity. Enrollment
+// tokens are appended to the configured tokens file; if there is none, a JWT is
+// signed with the configured secret instead.
+func enroll(conf *config.Configuration, identity string) {
+	if conf.ProviderTokens != "" {
+		token, err := auth.Enroll(conf.ProviderTokens, identity)
+		if err != nil {
+			log.Fatalf("enrollment failed: %s", err)
+		}
+		log.Printf("Added enrollment token to %q, send SIGHUP to running brokers", conf.ProviderTokens)
+		fmt.Println(token)
+		return
+	}
+	if conf.ProviderJwtSecret != "" {
+		jti := transport.Identifier()
+		token, err := auth.SignJWT([]byte(conf.ProviderJwtSecret), auth.Claims{
+			Subject:  identity,
+			ID:       hex.EncodeToString(jti[:]),
+			IssuedAt: time.Now().Unix(),
+		})
+		if err != nil {
+			log.Fatalf("enrollment failed: %s", err)
+		}
+		fmt.Println(token)
+		return
+	}
+	log.Fatal("enrollment failed: neither WASIMOFF_PROVIDER_TOKENS nor WASIMOFF_PROVIDER_JWT_SECRET configured")
+}
+
+// issueAPIKey adds a new Client API key with the given identity and prints it.
+// Limits are given as additional `rate=`, `burst=`, `concurrent=` and `quota=`
+// arguments; omitted limits are not enforced. Additional storage namespaces are
+// given as a comma-separated `namespaces=` list.
+func issueAPIKey(conf *config.Configuration, identity string, limits []string) {
+	if conf.ClientKeys == "" {
+		log.Fatal("issuing api key failed: WASIMOFF_CLIENT_KEYS not configured")
+	}
+	if !storage.ValidNamespace(identity) {
+		log.Fatalf("issuing api key failed: identity %q is not a valid namespace", identity)
+	}
+	apikey := &auth.APIKey{Identity: identity}
+	for _, arg := range limits {
+		var err error
+		key, value, _ := strings.Cut(arg, "=")
+		switch key {
+		case "rate":
+			apikey.Rate, err = strconv.ParseFloat(value, 64)
+		case "burst":
+			apikey.Burst, err = strconv.Atoi(value)
+		case "concurrent":
+			apikey.MaxConcurrent, err = strconv.Atoi(value)
+		case "quota":
+			apikey.StorageQuota, err = strconv.ParseInt(value, 10, 64)
+		case "namespaces":
+			apikey.Namespaces = strings.Split(value, ",")
+			for _, ns := range apikey.Namespaces {
+				if !storage.ValidNamespace(ns) {
+					err = fmt.Errorf("invalid namespace %q", ns)
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown limit")
+		}
+		if err != nil {
+			log.Fatalf("issuing api key failed: %q: %s", arg, err)
+		}
+	}
+	keys, err := auth.OpenKeyStore(conf.ClientKeys)
+	if err != nil {
+		log.Fatalf("issuing api key failed: %s", err)
+	}
+	key, err := auth.NewAPIKey()
+	if err == nil {
+		err = keys.Add(key, apikey)
+	}
+	if err != nil {
+		log.Fatalf("issuing api key failed: %s", err)
+	}
+	log.Printf("Added api key for %q to %q", identity, conf.ClientKeys)
+	fmt.Println(key)
+}
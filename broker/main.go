@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 
+	"connectrpc.com/connect"
+	"wasi.team/broker/auth"
 	"wasi.team/broker/config"
 	"wasi.team/broker/net/server"
+	"wasi.team/broker/net/transport"
 	"wasi.team/broker/provider"
 	"wasi.team/broker/scheduler"
 	"wasi.team/broker/scheduler/client"
+	"wasi.team/broker/storage"
+	"wasi.team/broker/tracing"
 	"wasi.team/proto/v1/wasimoffv1connect"
 )
 
@@ -21,6 +28,22 @@ func main() {
 	conf := config.GetConfiguration()
 	log.Printf("%#v", &conf)
 
+	// issue an enrollment token for a Provider, if requested on commandline
+	if len(os.Args) == 3 && os.Args[1] == "-enroll" {
+		enroll(&conf, os.Args[2])
+		return
+	}
+
+	// issue an API key for a Client, if requested on commandline
+	if len(os.Args) >= 3 && os.Args[1] == "-apikey" {
+		issueAPIKey(&conf, os.Args[2], os.Args[3:])
+		return
+	}
+
+	// limit the size of incoming messages on all sockets
+	transport.MaxMessageSize = conf.MaxMessageSize
+	transport.MaxProviderMessageSize = conf.ProviderMaxMessageSize
+
 	// create a new http server for the broker
 	mux := http.NewServeMux()
 	broker, err := server.NewServer(mux, conf.HttpListen, conf.HttpCert, conf.HttpKey)
@@ -33,38 +56,127 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to create provider store: %s", err)
 	}
-	selector := scheduler.NewSimpleMatchSelector(store)
-	// selector := scheduler.NewRoundRobinSelector(store)
-	// selector := scheduler.NewAnyFreeSelector(store)
+	selector, err := scheduler.NewSelector(conf.Scheduler.Selector, store)
+	if err != nil {
+		log.Fatalf("failed to create scheduler: %s", err)
+	}
 
 	// provider endpoint
-	mux.HandleFunc("GET /api/provider/ws", provider.WebSocketHandler(store, conf.AllowedOrigins))
+	providerOrigins := transport.NewOrigins(conf.ProviderOrigins())
+	mux.HandleFunc("GET /api/provider/ws", provider.WebSocketHandler(store, providerOrigins))
 	log.Printf("Provider socket: %s/api/provider/ws", broker.Addr())
 
 	// create a queue for the tasks and start the dispatcher
-	go scheduler.Dispatcher(store, selector, 32)
+	go scheduler.Dispatcher(store, selector, max(conf.Scheduler.Concurrency, 1), max(conf.Scheduler.Retries, 1))
+
+	// on SIGTERM, finish queued and in-flight tasks before closing all providers
+	broker.ShutdownTimeout = conf.ShutdownTimeout
+	broker.OnShutdown(func(ctx context.Context) {
+		scheduler.Shutdown(ctx)
+		store.CloseAll(scheduler.ErrShuttingDown)
+		if err := store.Ledger.Save(); err != nil {
+			log.Printf("ERR: %s", err)
+		}
+	})
 
 	// maybe start the "benchmode" load generation
 	go client.BenchmodeTspFlood(store, conf.Benchmode)
 
+	// client authentication with api keys, if configured
+	var keys auth.KeyStore
+	if conf.ClientKeys != "" {
+		var err error
+		if keys, err = auth.OpenKeyStore(conf.ClientKeys); err != nil {
+			log.Fatalf("failed to open client keys: %s", err)
+		}
+		store.Storage.Quota = auth.ReserveStorage
+		log.Printf("Client API keys required, loaded from %q", conf.ClientKeys)
+	}
+	for _, ns := range conf.SharedNamespaces {
+		if !storage.ValidNamespace(ns) {
+			log.Fatalf("invalid shared namespace %q", ns)
+		}
+	}
+	clientAuth := auth.NewClientAuth(keys, conf.SharedNamespaces)
+	clientAuth.SetQuotas(quotas(conf.Quotas))
+	if keys != nil {
+		store.Storage.Release = clientAuth.ReleaseStorage
+		clientAuth.SeedStorage(store.Storage.Usage())
+	}
+
+	// export task traces to an opentelemetry collector, if configured
+	var traces *tracing.Exporter
+	if conf.OtlpEndpoint != "" {
+		traces = tracing.NewExporter(conf.OtlpEndpoint, conf.OtlpHeaders)
+		broker.OnShutdown(traces.Shutdown)
+		log.Printf("Exporting task traces to %s", conf.OtlpEndpoint)
+	}
+
 	// client endpoints
-	rpc := &client.ConnectRpcServer{Store: store}
+	rpc := &client.ConnectRpcServer{
+		Store:         store,
+		EventInterval: conf.ClientEventInterval,
+		Traces:        traces,
+		History:       tracing.NewHistory(conf.TraceHistory),
+	}
+	if err := validTraceSampling(conf.TraceSampling); err != nil {
+		log.Fatal(err)
+	}
+	rpc.SetTraceSampling(conf.TraceSampling)
 	// -- websocket
-	mux.HandleFunc("GET /api/client/ws", client.ClientSocketHandler(rpc))
+	clientOrigins := transport.NewOrigins(conf.ClientOrigins())
+	mux.Handle("GET /api/client/ws", clientAuth.Middleware(client.ClientSocketHandler(rpc, clientOrigins)))
 	log.Printf("Client socket: %s/api/client/ws", broker.Addr())
 	// -- connectrpc
-	path, handler := wasimoffv1connect.NewTasksHandler(rpc)
-	mux.Handle("/api/client"+path, http.StripPrefix("/api/client", handler))
+	path, handler := wasimoffv1connect.NewTasksHandler(rpc, connect.WithReadMaxBytes(int(conf.MaxMessageSize)))
+	mux.Handle("/api/client"+path, clientAuth.Middleware(http.StripPrefix("/api/client", handler)))
 	log.Printf("Client RPC: %s%s", broker.Addr(), "/api/client"+path)
 	// -- plain http
-	mux.Handle("/api/client/run/{wasm}", client.HttpExecWasip1Handler(rpc))
+	mux.Handle("/api/client/run/{wasm}", clientAuth.Middleware(client.HttpExecWasip1Handler(rpc)))
 	log.Printf("Client HTTP: %s%s", broker.Addr(), "/api/client/run/{wasm}")
+	// -- cluster events
+	mux.Handle("GET /api/client/events", clientAuth.Middleware(client.ClusterEventsHandler(rpc)))
+	log.Printf("Client events: %s%s", broker.Addr(), "/api/client/events")
+	// -- list own tasks
+	mux.Handle("GET /api/client/tasks", clientAuth.Middleware(client.TaskListHandler()))
+	// -- timelines of recently traced tasks
+	if rpc.History != nil {
+		mux.Handle("GET /api/client/traces/chrome", clientAuth.Middleware(client.ChromeTraceHandler(rpc.History)))
+	}
 
 	// storage: serve files from and upload into store storage
-	mux.Handle("GET /api/storage/{filename}", store.Storage)
-	mux.HandleFunc("POST /api/storage/upload", store.Storage.Upload())
+	mux.Handle("GET /api/storage", clientAuth.Optional(store.Storage.ListHandler()))
+	mux.Handle("GET /api/storage/{filename...}", clientAuth.Optional(store.Storage))
+	mux.Handle("POST /api/storage/upload", clientAuth.Middleware(store.Storage.Upload()))
+	mux.Handle("DELETE /api/storage/{filename...}", clientAuth.Middleware(store.Storage.DeleteHandler()))
 	log.Printf("Upload at %s/api/storage/upload", broker.Addr())
 
+	// public leaderboard of provider contributions
+	mux.HandleFunc("GET /api/leaderboard", store.Ledger.LeaderboardHandler())
+
+	// admin api to manage providers, if a token is configured
+	if conf.AdminToken != "" {
+		mux.Handle("/api/admin/", auth.RequireToken(conf.AdminToken, provider.AdminHandler(store)))
+		log.Printf("Admin API: %s/api/admin/providers", broker.Addr())
+	}
+
+	// apply the reloadable settings on SIGHUP
+	config.OnReload(func(conf *config.Configuration) {
+		providerOrigins.Set(conf.ProviderOrigins())
+		clientOrigins.Set(conf.ClientOrigins())
+		clientAuth.SetQuotas(quotas(conf.Quotas))
+		if err := validTraceSampling(conf.TraceSampling); err != nil {
+			log.Printf("ERR: %s", err)
+		} else {
+			rpc.SetTraceSampling(conf.TraceSampling)
+		}
+		store.Storage.SetRetention(conf.StorageTTL, conf.ArtifactTTL, conf.StorageMaxSize)
+		if err := store.Auth.SetSecret(conf.ProviderJwtSecret); err != nil {
+			log.Printf("ERR: keeping old provider JWT secret: %s", err)
+		}
+		log.Printf("Reloaded origins, %d client quotas, trace sampling, storage retention and provider JWT secret", len(conf.Quotas))
+	})
+
 	// health and version message
 	mux.HandleFunc("GET /healthz", server.Healthz())
 	mux.HandleFunc("GET /api/version", server.Version())
@@ -95,3 +207,19 @@ func main() {
 
 //
 // ---
+
+// quotas converts the configured limits per Client identity.
+func quotas(configured map[string]config.Quota) map[string]auth.Quota {
+	quotas := make(map[string]auth.Quota, len(configured))
+	for identity, quota := range configured {
+		quotas[identity] = auth.Quota(quota)
+	}
+	return quotas
+}
+
+func validTraceSampling(fraction float64) error {
+	if fraction < 0 || fraction > 1 {
+		return fmt.Errorf("trace sampling must be between 0 and 1, got %v", fraction)
+	}
+	return nil
+}
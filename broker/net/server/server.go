@@ -1,12 +1,16 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"wasi.team/broker/config"
@@ -17,6 +21,17 @@ import (
 type Server struct {
 	Http *http.Server
 	cr   *cert.CertReloader
+
+	// time to wait for shutdown hooks to finish on SIGTERM
+	ShutdownTimeout time.Duration
+	shutdownHooks   []func(context.Context)
+}
+
+// OnShutdown registers a function that is called during a graceful shutdown, before
+// the HTTP server itself is stopped. Hooks are called in order of registration and
+// share a context, which expires after the ShutdownTimeout.
+func (s *Server) OnShutdown(hook func(context.Context)) {
+	s.shutdownHooks = append(s.shutdownHooks, hook)
 }
 
 // Create a new Server with optional TLS using the CertReloader.
@@ -57,6 +72,10 @@ func (s *Server) ListenAndServe() error {
 	sigint := make(chan os.Signal, 1)
 	signal.Notify(sigint, os.Interrupt)
 
+	// signal handler for a graceful shutdown
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+
 	// start the HTTP server in background, with a channel for errors
 	httpErr := make(chan error)
 	go func() {
@@ -74,12 +93,34 @@ func (s *Server) ListenAndServe() error {
 		s.Http.Close()
 		return fmt.Errorf("SIGINT received")
 
+	case <-sigterm: // orderly shutdown requested
+		s.shutdown()
+		return fmt.Errorf("SIGTERM received")
+
 	case err := <-httpErr: // http.Server failed
 		return fmt.Errorf("http.Server failed: %w", err)
 	}
 
 }
 
+// shutdown runs all registered hooks and then stops the HTTP server gracefully.
+func (s *Server) shutdown() {
+	log.Printf("Shutting down, waiting up to %s for queued and in-flight tasks ...", s.ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	for _, hook := range s.shutdownHooks {
+		hook(ctx)
+	}
+
+	// hooks should have finished all work, so only allow a short grace period
+	grace, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Http.Shutdown(grace); err != nil {
+		log.Printf("ERR: graceful http shutdown failed: %s", err)
+		s.Http.Close()
+	}
+}
+
 // Healthz returns a simple HandlerFunc simply replying with "OK"
 func Healthz() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -235,11 +235,6 @@ func (m *Messenger) receiver() {
 		m.transport.Close(err)
 		receiveErr = err
 	}
-	// terminate any pending calls
-	for _, call := range m.pending {
-		call.Error = receiveErr
-		call.done()
-	}
 	// set errors for future requests
 	// TODO: reuse m.close() but it would currently deadlock because it also wants pendingMutex
 	if m.Err() == nil {
@@ -248,6 +243,11 @@ func (m *Messenger) receiver() {
 		<-m.Closing()
 		close(m.events)
 	}
+	// terminate any pending calls, after closure is visible to their callers
+	for _, call := range m.pending {
+		call.Error = receiveErr
+		call.done()
+	}
 	m.pendingMutex.Unlock()
 	m.sendMutex.Unlock()
 }
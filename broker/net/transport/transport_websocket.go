@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	wasimoff "wasi.team/proto/v1"
 
@@ -18,6 +19,34 @@ var (
 	provider_v1_json     = wasimoff.Subprotocol_wasimoff_provider_v1_json.String()
 )
 
+// MaxMessageSize is the read limit for a single message on a WebSocket transport.
+// Larger files should be transferred with chunked Filesystem messages instead.
+var MaxMessageSize int64 = 32 << 20 // 32 MiB
+
+// MaxProviderMessageSize is the read limit on Provider sockets, which return task
+// results with their output and artifacts inline.
+var MaxProviderMessageSize int64 = 1 << 30 // 1 GiB
+
+// Origins holds the allowed Origin patterns of an endpoint, which can be replaced
+// at runtime, e.g. when the configuration is reloaded.
+type Origins struct {
+	patterns atomic.Pointer[[]string]
+}
+
+func NewOrigins(patterns []string) *Origins {
+	o := &Origins{}
+	o.Set(patterns)
+	return o
+}
+
+func (o *Origins) Set(patterns []string) {
+	o.patterns.Store(&patterns)
+}
+
+func (o *Origins) Get() []string {
+	return *o.patterns.Load()
+}
+
 // WebSocketTransport implements broker/net/transport.Transport for Messaging
 type WebSocketTransport struct {
 	conn *websocket.Conn // upgraded WebSocket connection
@@ -48,13 +77,18 @@ func UpgradeToWebSocketTransport(w http.ResponseWriter, req *http.Request, origi
 		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("supported protocols: %v", protocols))
 		return nil, fmt.Errorf("%w: no supported subprotocol", ErrCodec)
 	}
-	// disable the read limit
-	conn.SetReadLimit(-1)
+	// limit the size of incoming messages
+	conn.SetReadLimit(MaxMessageSize)
 
 	// return the Transport
 	return &WebSocketTransport{conn, req}, nil
 }
 
+// SetReadLimit replaces the read limit for single incoming messages.
+func (t *WebSocketTransport) SetReadLimit(limit int64) {
+	t.conn.SetReadLimit(limit)
+}
+
 // DialWebSocketTransport can be used to dial and create a WebSocket transport from
 // the Client side to the Broker.
 func DialWebSocketTransport(ctx context.Context, url string) (t *WebSocketTransport, err error) {
@@ -77,8 +111,8 @@ func DialWebSocketTransport(ctx context.Context, url string) (t *WebSocketTransp
 		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("supported protocols: %v", protocols))
 		return nil, fmt.Errorf("%w: no supported subprotocol", ErrCodec)
 	}
-	// disable the read limit
-	conn.SetReadLimit(-1)
+	// limit the size of incoming messages
+	conn.SetReadLimit(MaxMessageSize)
 
 	// return the Transport
 	return &WebSocketTransport{conn, res.Request}, nil
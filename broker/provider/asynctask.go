@@ -7,6 +7,8 @@ import (
 	"time"
 
 	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // AsyncTask is an individual parametrized task from an offloading job that
@@ -21,6 +23,9 @@ type AsyncTask struct {
 	TimeStart      time.Time
 	TimeScheduled  time.Time
 
+	// number of Providers considered in the last scheduling attempt
+	Candidates int
+
 	Error error           // errors encountered internally during scheduling or RPC
 	done  chan *AsyncTask // received itself when complete
 }
@@ -59,6 +64,21 @@ func (t *AsyncTask) Done() *AsyncTask {
 	return t
 }
 
+// Assign sets the name of the Provider that received this task in its metadata
+// and completes the details of the last scheduling event in the trace. It must
+// only be called by the receiver, which owns the task at that point.
+func (t *AsyncTask) Assign(provider string) {
+	info := t.Request.GetInfo()
+	info.Provider = proto.String(provider)
+	if event := info.GetTrace().Last(wasimoff.Task_TraceEvent_BrokerScheduleTask); event != nil {
+		details := fmt.Sprintf("candidates=%d provider=%q", t.Candidates, provider)
+		if prev := event.GetDetails(); prev != "" {
+			details = prev + " " + details
+		}
+		event.Details = proto.String(details)
+	}
+}
+
 // Check some prerequisites before attempting to schedule a task
 func (t *AsyncTask) Check() (err error) {
 	// done channel must never be nil
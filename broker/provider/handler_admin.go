@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrDisconnected is the closure cause of Providers, which were disconnected by
+// an administrator.
+var ErrDisconnected = errors.New("disconnected by administrator")
+
+// ProviderStatus is the public information about a connected Provider.
+type ProviderStatus struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	UserAgent string   `json:"useragent"`
+	Identity  string   `json:"identity,omitempty"`
+	Limit     int      `json:"limit"`     // concurrency limit
+	Tasks     int      `json:"tasks"`     // currently running tasks
+	Latency   float64  `json:"latency"`   // exponential moving average in seconds
+	Uptime    float64  `json:"uptime"`    // seconds since initial connection
+	Files     []string `json:"files"`     // refs of known files
+	Paused    bool     `json:"paused"`    // scheduling paused by an administrator
+	Draining  bool     `json:"draining"`  // provider is leaving
+	Suspended bool     `json:"suspended"` // connection lost, waiting for resumption
+}
+
+// Status collects the current information about a Provider.
+func (p *Provider) Status() ProviderStatus {
+	files := p.Files()
+	slices.Sort(files)
+	return ProviderStatus{
+		ID:        p.Get(ID),
+		Name:      p.Get(Name),
+		Address:   p.Get(Address),
+		UserAgent: p.Get(UserAgent),
+		Identity:  p.Get(Identity),
+		Limit:     p.CurrentLimit(),
+		Tasks:     p.RunningTasks(),
+		Latency:   p.Latency().Seconds(),
+		Uptime:    p.Uptime().Seconds(),
+		Files:     files,
+		Paused:    p.Paused(),
+		Draining:  p.Draining(),
+		Suspended: p.Suspended(),
+	}
+}
+
+// AdminHandler returns the admin API to inspect and manage connected Providers.
+// It must be mounted on /api/admin/ and protected by some form of authentication.
+//
+//	GET  /api/admin/providers                 list all Providers
+//	GET  /api/admin/providers/{id}            details of a single Provider
+//	POST /api/admin/providers/{id}/disconnect close the connection
+//	POST /api/admin/providers/{id}/pause      stop scheduling tasks to it
+//	POST /api/admin/providers/{id}/resume     continue scheduling tasks to it
+//	POST /api/admin/providers/{id}/message    send the body as a GenericMessage
+func AdminHandler(store *ProviderStore) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/admin/providers", func(w http.ResponseWriter, r *http.Request) {
+		providers := []ProviderStatus{}
+		store.Range(func(_ string, p *Provider) bool {
+			providers = append(providers, p.Status())
+			return true
+		})
+		slices.SortFunc(providers, func(a, b ProviderStatus) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		writeJSON(w, providers)
+	})
+
+	mux.HandleFunc("GET /api/admin/providers/{id}", withProvider(store, func(w http.ResponseWriter, r *http.Request, p *Provider) {
+		writeJSON(w, p.Status())
+	}))
+
+	mux.HandleFunc("POST /api/admin/providers/{id}/disconnect", withProvider(store, func(w http.ResponseWriter, r *http.Request, p *Provider) {
+		log.Printf("[%s] Admin: disconnecting Provider %s", p.Get(Address), p.Get(Name))
+		p.Close(ErrDisconnected)
+		store.Remove(p)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	mux.HandleFunc("POST /api/admin/providers/{id}/pause", withProvider(store, func(w http.ResponseWriter, r *http.Request, p *Provider) {
+		log.Printf("[%s] Admin: pausing Provider %s", p.Get(Address), p.Get(Name))
+		p.Pause()
+		writeJSON(w, p.Status())
+	}))
+
+	mux.HandleFunc("POST /api/admin/providers/{id}/resume", withProvider(store, func(w http.ResponseWriter, r *http.Request, p *Provider) {
+		log.Printf("[%s] Admin: resuming Provider %s", p.Get(Address), p.Get(Name))
+		p.Unpause()
+		writeJSON(w, p.Status())
+	}))
+
+	mux.HandleFunc("POST /api/admin/providers/{id}/message", withProvider(store, func(w http.ResponseWriter, r *http.Request, p *Provider) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 64*1024))
+		if err != nil {
+			http.Error(w, "failed reading message", http.StatusBadRequest)
+			return
+		}
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			http.Error(w, "message cannot be empty", http.StatusBadRequest)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+		event := &wasimoff.Event_GenericMessage{Message: proto.String(message)}
+		if err := p.conn().SendEvent(ctx, event); err != nil {
+			http.Error(w, "sending message failed: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	return mux
+}
+
+// withProvider looks up the Provider from the path or responds with 404.
+func withProvider(store *ProviderStore, handler func(http.ResponseWriter, *http.Request, *Provider)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p := store.Load(r.PathValue("id"))
+		if p == nil {
+			http.Error(w, "provider not found", http.StatusNotFound)
+			return
+		}
+		handler(w, r, p)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -6,17 +6,21 @@ import (
 	"net/http"
 	"slices"
 
+	"wasi.team/broker/auth"
 	"wasi.team/broker/net/transport"
+	"wasi.team/broker/storage"
 	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // WebSocketHandler returns a http.HandlerFunc to be used on a route that shall serve
 // as an endpoint for Providers to connect to. This particular handler uses WebSocket
 // transport with either Protobuf or JSON encoding, negotiated using subprotocol strings.
-func WebSocketHandler(store *ProviderStore, origins []string) http.HandlerFunc {
+func WebSocketHandler(store *ProviderStore, origins *transport.Origins) http.HandlerFunc {
 
 	// warn about wildcard origin pattern
-	if slices.Contains(origins, "*") {
+	if slices.Contains(origins.Get(), "*") {
 		log.Println("WARNING: you're using the wildcard pattern in AllowedOrigins!")
 	}
 
@@ -30,43 +34,69 @@ func WebSocketHandler(store *ProviderStore, origins []string) http.HandlerFunc {
 			return
 		}
 
-		// check for an id query parameter
+		// verify credentials before upgrading
+		identity, err := store.Auth.Authenticate(r)
+		if err != nil {
+			log.Printf("[%s] New Provider: authentication failed: %s", addr, err)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// check for an id query parameter, unless the identity is verified
 		id := "anonymous"
-		if pid := r.URL.Query().Get("id"); pid != "" {
+		if identity != "" {
+			id = identity
+		} else if pid := r.URL.Query().Get("id"); pid != "" {
 			id = pid
 		}
 
 		// upgrade the transport
-		wst, err := transport.UpgradeToWebSocketTransport(w, r, origins)
+		wst, err := transport.UpgradeToWebSocketTransport(w, r, origins.Get())
 		if err != nil {
 			log.Printf("[%s] New Provider: upgrade failed: %s", addr, err)
 			return
 		}
+		wst.SetReadLimit(transport.MaxProviderMessageSize)
 		msg := transport.NewMessengerInterface(wst)
 
-		// setup the provider instance
-		provider := NewProvider(msg)
-		defer provider.Close(nil)
-
-		// set name and useragent from request
-		provider.info[Name] = id
-		provider.info[UserAgent] = useragent
+		// resume a previous session or setup a new provider instance
+		provider := store.Resume(r.URL.Query().Get("session"), identity, msg)
+		resumed := provider != nil
+		if !resumed {
+			provider = NewProvider(msg, store.sessionGrace)
+			// set name and useragent from request
+			provider.info[Name] = id
+			provider.info[UserAgent] = useragent
+			provider.info[Identity] = identity
+			provider.credential = auth.Credential(r)
+		}
 
 		// handle incoming event messages
-		go provider.eventTransmitter()
+		go provider.eventTransmitter(store, msg)
+
+		// announce the session parameters for a later resumption
+		if err = msg.SendEvent(r.Context(), provider.sessionEvent()); err != nil {
+			log.Printf("[%s] New Provider: sending session failed: %s", addr, err)
+		}
 
 		// get the list of available files on provider
 		if err = provider.ListFiles(); err != nil {
 			log.Printf("[%s] New Provider: %s", addr, err)
-			return
+			if !resumed {
+				provider.Close(err)
+				return
+			}
+			msg.Close(err)
+		} else if resumed {
+			log.Printf("[%s] Provider (%s) resumed session %s", addr, provider.Get(Name), provider.Get(ID))
+			go provider.resumeTasks(msg)
+		} else {
+			// add provider to the store
+			log.Printf("[%s] New Provider (%s) connected using WebSocket", addr, id)
+			log.Printf("[%s] User-Agent: %s", addr, useragent)
+			store.Add(provider)
 		}
 
-		// add provider to the store
-		log.Printf("[%s] New Provider (%s) connected using WebSocket", addr, id)
-		log.Printf("[%s] User-Agent: %s", addr, useragent)
-		store.Add(provider)
-		defer store.Remove(provider)
-
 		// wait until the session ends to defer cleanup
 		select {
 		case <-r.Context().Done():
@@ -75,24 +105,60 @@ func WebSocketHandler(store *ProviderStore, origins []string) http.HandlerFunc {
 		}
 		log.Printf("[%s] Provider Session closed", addr)
 
+		// keep the provider around for a while, if it might resume its session
+		if provider.Suspend(msg) {
+			if !provider.Suspended() {
+				return // another connection took over already
+			}
+			log.Printf("[%s] Provider %s suspended, waiting %s for resumption", addr, provider.Get(ID), store.sessionGrace)
+			return
+		}
+		provider.Close(msg.Err())
+
 	}
 }
 
 // eventTransmitter loops to receive incoming messages or send updates to the provider
-func (p *Provider) eventTransmitter() {
+func (p *Provider) eventTransmitter(store *ProviderStore, msg *transport.Messenger) {
 	for {
 		select {
 
-		// reject incoming requests
-		case request, ok := <-p.messenger.Requests():
+		// handle incoming requests
+		case request, ok := <-msg.Requests():
 			if !ok {
 				return // channel is closing, quit
 			}
-			// log.Printf("[%s] Request %d: %s", p.messenger.Addr(), request.Seq, prototext.Format(request.Request))
-			request.Respond(p.lifetime.Context, nil, fmt.Errorf("requests not supported on provider socket"))
+			// log.Printf("[%s] Request %d: %s", msg.Addr(), request.Seq, prototext.Format(request.Request))
+			switch rq := request.Request.(type) {
+
+			case *wasimoff.Filesystem_Chunk_Download_Request:
+				// provider fetches a large file from storage in chunks
+				go func() {
+					var msg proto.Message
+					response, err := store.Storage.DownloadChunk(storage.DefaultNamespaces, rq)
+					if err == nil {
+						msg = response
+					}
+					request.Respond(p.lifetime.Context, msg, err)
+				}()
+
+			case *wasimoff.Task_Deliver_Request:
+				// provider returns a result from before its session was resumed
+				var ack proto.Message
+				err := p.deliver(rq)
+				if err == nil {
+					ack = &wasimoff.Task_Deliver_Response{}
+				}
+				request.Respond(p.lifetime.Context, ack, err)
+
+			default:
+				// reject anything else
+				request.Respond(p.lifetime.Context, nil, fmt.Errorf("requests not supported on provider socket"))
+
+			}
 
 		// handle incoming events
-		case event, ok := <-p.messenger.Events():
+		case event, ok := <-msg.Events():
 			if !ok {
 				return // channel is closing, quit
 			}
@@ -112,6 +178,12 @@ func (p *Provider) eventTransmitter() {
 					p.limiter.SetLimit(int(*ev.Concurrency))
 				}
 
+			case *wasimoff.Event_Drain:
+				// provider wants to leave, stop scheduling and let tasks finish
+				log.Printf("[%s] Provider wants to drain: %s", p.Get(Address), ev.GetReason())
+				store.Remove(p)
+				p.Drain()
+
 			case *wasimoff.Event_FileSystemUpdate:
 				// update about stored files on provider
 				for _, file := range ev.GetAdded() {
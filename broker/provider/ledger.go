@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Contribution is the cumulative work done by a Provider identity across all of
+// its connections. Anonymous Providers are accounted by their name instead.
+type Contribution struct {
+	Name      string    `json:"name"`
+	Tasks     uint64    `json:"tasks"`   // successfully completed tasks
+	Seconds   float64   `json:"seconds"` // time spent computing these tasks
+	Bytes     uint64    `json:"bytes"`   // size of task requests and results
+	FirstSeen time.Time `json:"first"`
+	LastSeen  time.Time `json:"last"`
+}
+
+// Ledger accounts the Contributions of all Providers. If a path is given, the
+// totals are loaded from a JSON file on startup and saved periodically.
+type Ledger struct {
+	mutex  sync.Mutex
+	path   string
+	totals map[string]*Contribution
+	rates  map[string]*RateCounter // current throughput per Provider
+	dirty  bool                    // changed since last save
+}
+
+// NewLedger loads previous totals from path, if it exists, and saves changes every
+// interval. An empty path keeps the totals in memory only.
+func NewLedger(path string, interval time.Duration) (*Ledger, error) {
+	l := &Ledger{
+		path:   path,
+		totals: make(map[string]*Contribution),
+		rates:  make(map[string]*RateCounter),
+	}
+	if path == "" {
+		return l, nil
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed reading contributions: %w", err)
+	}
+	if len(buf) > 0 {
+		var totals []*Contribution
+		if err := json.Unmarshal(buf, &totals); err != nil {
+			return nil, fmt.Errorf("failed parsing contributions: %w", err)
+		}
+		for _, c := range totals {
+			l.totals[c.Name] = c
+		}
+	}
+	go func() {
+		for range time.Tick(interval) {
+			if err := l.Save(); err != nil {
+				log.Printf("ERR: %s", err)
+			}
+		}
+	}()
+	return l, nil
+}
+
+// Record a successfully completed task of a Provider.
+func (l *Ledger) Record(name string, duration time.Duration, bytes int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	c, ok := l.totals[name]
+	if !ok {
+		c = &Contribution{Name: name, FirstSeen: now}
+		l.totals[name] = c
+	}
+	c.Tasks += 1
+	c.Seconds += duration.Seconds()
+	c.Bytes += uint64(bytes)
+	c.LastSeen = now
+	l.dirty = true
+
+	rate, ok := l.rates[name]
+	if !ok {
+		rate = NewRateCounter(5 * time.Second)
+		l.rates[name] = rate
+	}
+	rate.Observe()
+}
+
+// Rate returns the current throughput of a Provider in tasks per second.
+func (l *Ledger) Rate(name string) float64 {
+	l.mutex.Lock()
+	rate, ok := l.rates[name]
+	l.mutex.Unlock()
+	if !ok {
+		return 0
+	}
+	return rate.GetRate()
+}
+
+// Save writes the totals to the file atomically, if anything changed.
+func (l *Ledger) Save() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.path == "" || !l.dirty {
+		return nil
+	}
+	totals := make([]*Contribution, 0, len(l.totals))
+	for _, c := range l.totals {
+		totals = append(totals, c)
+	}
+	buf, err := json.MarshalIndent(totals, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshalling contributions: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), ".contributions-*")
+	if err != nil {
+		return fmt.Errorf("failed saving contributions: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed saving contributions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed saving contributions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.path); err != nil {
+		return fmt.Errorf("failed saving contributions: %w", err)
+	}
+	l.dirty = false
+	return nil
+}
+
+// LeaderboardEntry is a Contribution with the current throughput.
+type LeaderboardEntry struct {
+	Contribution
+	Rate float64 `json:"rate"` // current tasks per second
+}
+
+// Leaderboard returns the top Contributions, ordered by "tasks", "seconds" or "bytes".
+func (l *Ledger) Leaderboard(by string, limit int) ([]LeaderboardEntry, error) {
+	var key func(c *Contribution) float64
+	switch by {
+	case "", "tasks":
+		key = func(c *Contribution) float64 { return float64(c.Tasks) }
+	case "seconds":
+		key = func(c *Contribution) float64 { return c.Seconds }
+	case "bytes":
+		key = func(c *Contribution) float64 { return float64(c.Bytes) }
+	default:
+		return nil, fmt.Errorf("cannot order by %q", by)
+	}
+
+	l.mutex.Lock()
+	entries := make([]LeaderboardEntry, 0, len(l.totals))
+	for _, c := range l.totals {
+		entries = append(entries, LeaderboardEntry{Contribution: *c})
+	}
+	l.mutex.Unlock()
+
+	slices.SortFunc(entries, func(a, b LeaderboardEntry) int {
+		return cmp.Or(cmp.Compare(key(&b.Contribution), key(&a.Contribution)), cmp.Compare(a.Name, b.Name))
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i := range entries {
+		entries[i].Rate = l.Rate(entries[i].Name)
+	}
+	return entries, nil
+}
+
+// LeaderboardHandler serves the Leaderboard as JSON. The order and the number of
+// entries can be given with `?by=` and `?limit=` parameters.
+func (l *Ledger) LeaderboardHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 100
+		if param := r.URL.Query().Get("limit"); param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil || n < 0 {
+				http.Error(w, "malformed limit", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		entries, err := l.Leaderboard(r.URL.Query().Get("by"), limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, entries)
+	}
+}
@@ -18,6 +18,9 @@ type ProviderStoreMetrics struct {
 	// count specific events like retries
 	TaskRetries prometheus.CounterVec
 
+	// count completed tasks per tenant namespace
+	TasksNamespace prometheus.CounterVec
+
 	// track available resources
 	ConnectedProviders        prometheus.GaugeFunc // currently connected providers
 	AvailableWorkers          prometheus.GaugeVec  // available workers, partitioned by providers and cloud
@@ -67,6 +70,12 @@ func (store *ProviderStore) initializePrometheusMetrics() {
 		Help: "number of retries across all scheduled tasks",
 	}, []string{"attempt"})
 
+	// completed tasks per namespace, the default namespace is empty
+	m.TasksNamespace = *promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wasimoff_tasks_namespace_count",
+		Help: "number of completed tasks; partitioned by tenant namespace and status",
+	}, []string{"namespace", "status"})
+
 	// currently waiting (queued) and dispatching (scheduling) tasks
 	m.CurrentlyQueuedTasks = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "wasimoff_tasks_queued",
@@ -111,6 +120,7 @@ func (s *ProviderStore) ObserveRetry(attempt int) {
 
 // Set the current task queue length gauge
 func (s *ProviderStore) ObserveTaskQueue(queuelen int, scheduling int) {
+	s.queued.Store(int64(queuelen))
 	s.metrics.CurrentlyQueuedTasks.Set(float64(queuelen))        // tasks in the queue
 	s.metrics.CurrentlyDispatchingTasks.Set(float64(scheduling)) // tasks trying to dispatch
 }
@@ -148,6 +158,9 @@ func (s *ProviderStore) ObserveCompleted(task *AsyncTask) {
 	labels := prometheus.Labels{"status": status, "target": target}
 	s.metrics.TasksCompleted.With(labels).Observe(durComplete)
 	s.metrics.TasksExecution.With(labels).Observe(durExecution)
+	s.metrics.TasksNamespace.With(prometheus.Labels{
+		"namespace": task.Request.GetInfo().GetNamespace(), "status": status,
+	}).Inc()
 	// log.Printf("TASK id=%s status=%s on=%s time=%f (%f)", task.Request.GetInfo().GetId(), status, target, durComplete, durExecution)
 
 }
@@ -17,12 +17,21 @@ import (
 
 // Provider is a single connection initiated by a computing provider
 type Provider struct {
-	messenger *transport.Messenger // messenger connection to provider
+	messenger *transport.Messenger // messenger connection to provider, see conn()
+	mutex     sync.RWMutex         // guards messenger, info, session, pause and latency
+
+	// session state to resume after a reconnect
+	session session
 
 	// cancellable lifetime context to signal closure upwards
 	lifetime  transport.Lifetime
 	closeOnce sync.Once
 
+	// child lifetime which is cancelled when the provider announces draining,
+	// and a waitgroup to let running tasks finish before closing
+	drain    transport.Lifetime
+	inflight sync.WaitGroup
+
 	// unbuffered channel to submit tasks; can be `nil` if nobody's listening
 	Submit chan *AsyncTask
 
@@ -30,39 +39,64 @@ type Provider struct {
 	limiter semaphore.Semaphore
 	waiting bool
 
+	// an administrator can pause scheduling to this Provider, see Pause()
+	paused   bool
+	unpaused chan struct{} // closed while not paused
+	pausing  chan struct{} // closed while paused
+
 	// information about the provider, to be accessed with Get()
 	info map[ProviderInfoKey]string
 
+	// credential presented on connection, to check for revocation later
+	credential string
+
 	// hashmap of files known on this provider
 	files sync.Map // map[string]struct{}
 
 	// keep an exponential average latency measurement
 	latency float64 // in seconds
+
+	// time of the initial connection
+	connected time.Time
+
+	// accounts the work done by this Provider, set when added to the store
+	ledger *Ledger
 }
 
 type ProviderInfoKey string
 
 const (
-	Name      ProviderInfoKey = "name"      // a unique name for identification
+	ID        ProviderInfoKey = "id"        // stable identifier across reconnects
+	Name      ProviderInfoKey = "name"      // a human-readable name for identification
 	Address   ProviderInfoKey = "address"   // remote address of transport conn
 	UserAgent ProviderInfoKey = "useragent" // software and architecture info
+	Identity  ProviderInfoKey = "identity"  // verified identity, empty if anonymous
 )
 
-// Setup a new Provider instance from a given Messenger
-func NewProvider(messenger *transport.Messenger) *Provider {
+// Setup a new Provider instance from a given Messenger. Its session can be resumed
+// within the grace period after a connection loss.
+func NewProvider(messenger *transport.Messenger, grace time.Duration) *Provider {
 	lifetime := transport.NewLifetime(context.TODO())
 
 	// construct the provider
+	unpaused := make(chan struct{})
+	close(unpaused)
 	provider := &Provider{
 		messenger: messenger,
+		session:   newSession(grace),
 		lifetime:  lifetime,
+		drain:     transport.NewLifetime(lifetime.Context),
 		Submit:    nil, // must be setup by acceptTasks
 		limiter:   semaphore.New(0),
 		info:      make(map[ProviderInfoKey]string),
 		files:     sync.Map{},
+		unpaused:  unpaused,
+		pausing:   make(chan struct{}),
+		connected: time.Now(),
 	}
 
 	// set known information
+	provider.info[ID] = newProviderID()
 	provider.info[Name] = messenger.Addr()
 	provider.info[Address] = messenger.Addr()
 	provider.info[UserAgent] = "unknown"
@@ -76,13 +110,24 @@ func NewProvider(messenger *transport.Messenger) *Provider {
 }
 
 func (p *Provider) Get(key ProviderInfoKey) string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.info[key]
 }
 
 func (p *Provider) Waiting() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.waiting
 }
 
+// setWaiting marks whether the accept loop holds a semaphore for the next task.
+func (p *Provider) setWaiting(waiting bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.waiting = waiting
+}
+
 // -------------------- closure -------------------- >>
 
 // Returns the cause of the closure or nil if Provider isn't closed yet.
@@ -101,11 +146,71 @@ func (p *Provider) Close(reason error) {
 		reason = transport.ErrLifetimeEnded
 	}
 	p.closeOnce.Do(func() {
-		p.messenger.Close(fmt.Errorf("closed from Provider: %w", reason))
+		p.conn().Close(fmt.Errorf("closed from Provider: %w", reason))
 		p.lifetime.Cancel(reason)
 	})
 }
 
+// ErrDrained is the closure cause of Providers, which left after draining.
+var ErrDrained = errors.New("provider drained")
+
+// Drain stops accepting new tasks on this Provider. Running tasks can finish and
+// the Provider is closed afterwards.
+func (p *Provider) Drain() {
+	p.drain.Cancel(ErrDrained)
+}
+
+// Draining returns true if the Provider does not accept new tasks anymore.
+func (p *Provider) Draining() bool {
+	return errors.Is(p.drain.Err(), ErrDrained)
+}
+
+// -------------------- pausing -------------------- >>
+
+// Pause stops scheduling new tasks to this Provider but keeps it connected.
+// Running tasks are not affected.
+func (p *Provider) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.paused {
+		p.paused = true
+		p.unpaused = make(chan struct{})
+		close(p.pausing)
+	}
+}
+
+// Unpause continues scheduling tasks to a paused Provider.
+func (p *Provider) Unpause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.paused {
+		p.paused = false
+		p.pausing = make(chan struct{})
+		close(p.unpaused)
+	}
+}
+
+// Paused returns true if scheduling to this Provider was paused.
+func (p *Provider) Paused() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.paused
+}
+
+// waitUnpaused returns a channel, which is closed while the Provider is not paused.
+func (p *Provider) waitUnpaused() <-chan struct{} {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.unpaused
+}
+
+// waitPaused returns a channel, which is closed while the Provider is paused.
+func (p *Provider) waitPaused() <-chan struct{} {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.pausing
+}
+
 // -------------------- limiter -------------------- >>
 
 // Get the currently running tasks according to the semaphore
@@ -113,6 +218,16 @@ func (p *Provider) CurrentTasks() int {
 	return p.limiter.GetCount()
 }
 
+// Get the currently running tasks, without the semaphore that is held while
+// waiting for the next task
+func (p *Provider) RunningTasks() int {
+	n := p.CurrentTasks()
+	if p.Waiting() {
+		n -= 1
+	}
+	return max(n, 0)
+}
+
 // Get the currently configured Limit in the task semaphore
 func (p *Provider) CurrentLimit() int {
 	return p.limiter.GetLimit()
@@ -132,27 +247,49 @@ func (p *Provider) acceptTasks() (err error) {
 	}
 
 	// close Provider if the loop ever exits
-	defer p.Close(err)
+	defer func() { p.Close(err) }()
 
 	for {
 
 		// acquire a semaphore before accepting a task
 		//? off-by-one because we acquire and hold a semaphore before we even get a task
-		if err = p.limiter.Acquire(p.lifetime.Context, 1); err != nil {
+		if err = p.limiter.Acquire(p.drain.Context, 1); err != nil {
 			// nobody to notify and nothing to free, just quit
-			return err
+			return p.drained()
 		}
-		p.waiting = true
 
+		// wait while the session is suspended or scheduling is paused
+		select {
+		case <-p.online():
+		case <-p.drain.Closing():
+			p.limiter.Release(1)
+			return p.drained()
+		}
 		select {
+		case <-p.waitUnpaused():
+		case <-p.drain.Closing():
+			p.limiter.Release(1)
+			return p.drained()
+		}
+		p.setWaiting(true)
 
-		// Provider is closing, quit the loop
-		case <-p.lifetime.Closing():
-			return p.Err()
+		select {
+
+		// paused while waiting, give back the semaphore and wait again
+		case <-p.waitPaused():
+			p.setWaiting(false)
+			p.limiter.Release(1)
+			continue
+
+		// Provider is closing or draining, quit the loop
+		case <-p.drain.Closing():
+			p.setWaiting(false)
+			p.limiter.Release(1)
+			return p.drained()
 
 		// receive task details from channel
 		case task := <-p.Submit:
-			p.waiting = false
+			p.setWaiting(false)
 
 			// prerequisite checks
 			if err := task.Check(); err != nil {
@@ -164,14 +301,20 @@ func (p *Provider) acceptTasks() (err error) {
 
 			// run the Request in a goroutine asynchronously
 			// TODO: avoid gofunc by using a second listener on a `chan *PendingCall`
+			p.inflight.Add(1)
 			go func() {
-				task.Request.GetInfo().Provider = proto.String(p.Get(Name))
+				defer p.inflight.Done()
+				task.Assign(p.Get(Name))
 				task.Request.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerTransmitProviderTask)
+				start := time.Now()
 				task.Error = p.run(task.Context, task.Request, task.Response)
+				if task.Error == nil {
+					p.contributed(time.Since(start), proto.Size(task.Request)+proto.Size(task.Response))
+				}
 				// send cancellation event if error is due to context
 				if errors.Is(task.Error, context.Canceled) {
 					// don't really care for result or error here, just that it completed somehow
-					_ = p.messenger.RequestSync(p.lifetime.Context, &wasimoff.Task_Cancel{
+					_ = p.conn().RequestSync(p.lifetime.Context, &wasimoff.Task_Cancel{
 						Id:     task.Request.GetInfo().Id,
 						Reason: proto.String(context.Canceled.Error()),
 					}, &wasimoff.Task_Cancel{})
@@ -185,6 +328,17 @@ func (p *Provider) acceptTasks() (err error) {
 	}
 }
 
+// drained waits for running tasks to finish, if the Provider is draining, and
+// returns the cause to close the Provider with.
+func (p *Provider) drained() error {
+	if err := p.Err(); err != nil {
+		return err // closed without draining
+	}
+	log.Printf("[%s] Provider draining, waiting for %d running tasks", p.Get(Address), p.CurrentTasks())
+	p.inflight.Wait()
+	return ErrDrained
+}
+
 // -------------------- ping at interval -------------------- >>
 
 func (p *Provider) pinger(period time.Duration) {
@@ -196,8 +350,12 @@ func (p *Provider) pinger(period time.Duration) {
 		select {
 
 		case <-timer.C:
+			if p.Suspended() {
+				timer.Reset(period)
+				continue
+			}
 			start = time.Now()
-			if err = p.messenger.RequestSync(p.lifetime.Context, ping, ping); err != nil {
+			if err = p.conn().RequestSync(p.lifetime.Context, ping, ping); err != nil {
 				log.Printf("[%s] Error in pinger(): %s", p.Get(Address), err)
 			} else {
 				p.observeLatency(time.Since(start))
@@ -211,8 +369,47 @@ func (p *Provider) pinger(period time.Duration) {
 	}
 }
 
+// anonymousContributor prefixes the self-chosen names of anonymous Providers, so
+// they can't be credited to a verified identity.
+const anonymousContributor = "anonymous/"
+
+// Contributor is the name under which the work of this Provider is accounted:
+// its verified identity or its prefixed name if anonymous.
+func (p *Provider) Contributor() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if identity := p.info[Identity]; identity != "" {
+		return identity
+	}
+	return anonymousContributor + p.info[Name]
+}
+
+// contributed records a completed task in the ledger.
+func (p *Provider) contributed(duration time.Duration, bytes int) {
+	p.mutex.RLock()
+	ledger := p.ledger
+	p.mutex.RUnlock()
+	if ledger != nil {
+		ledger.Record(p.Contributor(), duration, bytes)
+	}
+}
+
+// Latency returns the exponential moving average of the ping latency.
+func (p *Provider) Latency() time.Duration {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return time.Duration(p.latency * float64(time.Second))
+}
+
+// Uptime returns the time since the Provider connected initially.
+func (p *Provider) Uptime() time.Duration {
+	return time.Since(p.connected)
+}
+
 // add measurement with an exponential moving average
 func (p *Provider) observeLatency(ping time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 
 	// initialize with first measurement
 	if p.latency == 0 {
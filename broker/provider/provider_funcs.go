@@ -14,9 +14,18 @@ import (
 // run is the internal detail, which executes a task on the Provider without semaphore guards
 func (p *Provider) run(ctx context.Context, args wasimoff.Task_Request, result wasimoff.Task_Response) (err error) {
 	// addr := p.Get(Address)
-	// task := args.GetInfo().GetId()
+	task := args.GetInfo().GetId()
 	// log.Printf(">>> schedule %s >> %s", task, addr)
-	if err := p.messenger.RequestSync(ctx, args, result); err != nil {
+	msg, pending := p.track(task)
+	if pending != nil {
+		defer p.untrack(task, pending)
+	}
+	err = msg.RequestSync(ctx, args, result)
+	if err != nil && pending != nil && ctx.Err() == nil && msg.Err() != nil && p.session.grace > 0 {
+		// connection was lost while the task was running, wait for a resumed session
+		err = p.awaitDelivery(ctx, pending, result)
+	}
+	if err != nil {
 		// log.Printf("ERROR! <<<<< %s << %s", task, addr)
 		return fmt.Errorf("provider.run failed: %w", err)
 	}
@@ -48,7 +57,7 @@ func (p *Provider) ListFiles() error {
 	// receive listing into a new struct
 	args := wasimoff.Filesystem_Listing_Request{}
 	response := wasimoff.Filesystem_Listing_Response{}
-	if err := p.messenger.RequestSync(context.TODO(), &args, &response); err != nil {
+	if err := p.conn().RequestSync(context.TODO(), &args, &response); err != nil {
 		return fmt.Errorf("provider.ListFiles failed: %w", err)
 	}
 
@@ -66,7 +75,7 @@ func (p *Provider) ProbeFile(addr string) (has bool, err error) {
 	// receive response bool into a new struct
 	args := wasimoff.Filesystem_Probe_Request{File: &addr}
 	response := wasimoff.Filesystem_Probe_Response{}
-	if err := p.messenger.RequestSync(context.TODO(), &args, &response); err != nil {
+	if err := p.conn().RequestSync(context.TODO(), &args, &response); err != nil {
 		return false, fmt.Errorf("provider.ProbeFile failed: %w", err)
 	}
 
@@ -99,7 +108,7 @@ func (p *Provider) Upload(file *storage.File) (err error) {
 		Blob:  file.Bytes,
 	}}
 	response := wasimoff.Filesystem_Upload_Response{}
-	if err := p.messenger.RequestSync(context.TODO(), &args, &response); err != nil {
+	if err := p.conn().RequestSync(context.TODO(), &args, &response); err != nil {
 		return fmt.Errorf("provider.Upload %q failed: %w", ref, err)
 	}
 	if response.GetRef() != ref {
@@ -108,6 +117,16 @@ func (p *Provider) Upload(file *storage.File) (err error) {
 	return
 }
 
+// Files returns the refs of all files this Provider *is known* to have.
+func (p *Provider) Files() []string {
+	files := []string{}
+	p.files.Range(func(key, _ any) bool {
+		files = append(files, key.(string))
+		return true
+	})
+	return files
+}
+
 // Has returns if this Provider *is known* to have a certain file, without re-probing
 func (p *Provider) Has(file string) bool {
 	_, ok := p.files.Load(file)
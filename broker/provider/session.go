@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"wasi.team/broker/net/transport"
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A Provider can lose its connection briefly, e.g. during a Wi-Fi blip. Instead of
+// failing all running tasks immediately, the Provider is kept in a suspended state
+// for a grace period. It can reconnect with its session token and results of tasks
+// that completed in the meantime are delivered on the new connection.
+
+// ErrSessionExpired is the closure cause of Providers, which did not resume their
+// session within the grace period.
+var ErrSessionExpired = errors.New("session not resumed")
+
+// errSessionResumed closes a previous connection, when a Provider resumed its
+// session before the Broker noticed that the old connection was lost.
+var errSessionResumed = errors.New("session resumed on another connection")
+
+// session holds the state needed to resume a Provider after a reconnect.
+type session struct {
+	token     string        // secret to present when reconnecting
+	grace     time.Duration // how long to wait for a reconnect
+	suspended bool          // true while no connection is attached
+	online    chan struct{} // closed while a connection is attached
+
+	// tasks which were sent to the Provider and still await a result
+	pending map[string]*pendingTask
+}
+
+// pendingTask is a task sent to the Provider, whose result might be delivered on
+// a resumed session if the original connection is lost.
+type pendingTask struct {
+	conn      *transport.Messenger // connection the request was sent on
+	delivered chan delivery        // receives the result on a resumed session
+}
+
+// delivery is either a result delivered on a resumed session or an error.
+type delivery struct {
+	result wasimoff.Task_Response
+	err    error
+}
+
+func newSession(grace time.Duration) session {
+	id := transport.Identifier()
+	online := make(chan struct{})
+	close(online)
+	return session{
+		token:   hex.EncodeToString(id[:]),
+		grace:   grace,
+		online:  online,
+		pending: make(map[string]*pendingTask),
+	}
+}
+
+// newProviderID generates a random, stable identifier for a new Provider.
+func newProviderID() string {
+	id := transport.Identifier()
+	return hex.EncodeToString(id[:8])
+}
+
+// sessionEvent returns the Event to announce session parameters to the Provider.
+func (p *Provider) sessionEvent() *wasimoff.Event_Session {
+	return &wasimoff.Event_Session{
+		Id:    proto.String(p.Get(ID)),
+		Token: proto.String(p.session.token),
+		Grace: proto.Uint32(uint32(p.session.grace.Seconds())),
+	}
+}
+
+// conn returns the currently attached Messenger.
+func (p *Provider) conn() *transport.Messenger {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.messenger
+}
+
+// online returns a channel, which is closed while a connection is attached.
+func (p *Provider) online() <-chan struct{} {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.session.online
+}
+
+// Suspended returns true if the Provider lost its connection and waits for a
+// session resumption.
+func (p *Provider) Suspended() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.session.suspended
+}
+
+// Suspend is called when the connection of a Messenger ended. The Provider is kept
+// for the grace period to allow resuming the session. Returns false if the session
+// can not be resumed and the Provider should be closed instead.
+func (p *Provider) Suspend(msg *transport.Messenger) bool {
+	if p.session.grace <= 0 || p.Err() != nil || p.Draining() {
+		return false
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// another connection has already taken over, nothing to do
+	if p.messenger != msg || p.session.suspended {
+		return true
+	}
+
+	// stop accepting tasks until resumed
+	p.session.suspended = true
+	p.session.online = make(chan struct{})
+	go p.expire(p.session.online, p.session.grace)
+	return true
+}
+
+// expire closes the Provider if the session was not resumed within the grace period.
+func (p *Provider) expire(online <-chan struct{}, grace time.Duration) {
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-online: // resumed in time
+	case <-p.Closing():
+	case <-timer.C:
+		p.Close(fmt.Errorf("%w within %s", ErrSessionExpired, grace))
+	}
+}
+
+// resume attaches a new Messenger to this Provider and closes the previous one.
+func (p *Provider) resume(msg *transport.Messenger) error {
+	p.mutex.Lock()
+	if err := p.Err(); err != nil {
+		p.mutex.Unlock()
+		return err
+	}
+	previous := p.messenger
+	p.messenger = msg
+	p.info[Address] = msg.Addr()
+	if p.session.suspended {
+		p.session.suspended = false
+		close(p.session.online)
+	}
+	p.mutex.Unlock()
+
+	// the previous connection might not have noticed the loss yet
+	if previous != msg {
+		previous.Close(errSessionResumed)
+	}
+	return nil
+}
+
+// resumeTasks tells the Provider which results are still expected after a session
+// was resumed on a new connection. Tasks unknown to the Provider fail immediately.
+func (p *Provider) resumeTasks(msg *transport.Messenger) {
+
+	// collect tasks which were sent on previous connections
+	args := wasimoff.Task_Resume_Request{}
+	p.mutex.RLock()
+	for id, task := range p.session.pending {
+		if task.conn != msg {
+			args.Pending = append(args.Pending, id)
+		}
+	}
+	p.mutex.RUnlock()
+	if len(args.Pending) == 0 {
+		return
+	}
+
+	response := wasimoff.Task_Resume_Response{}
+	if err := msg.RequestSync(p.lifetime.Context, &args, &response); err != nil {
+		log.Printf("[%s] Resuming %d tasks failed: %s", p.Get(Address), len(args.Pending), err)
+		return
+	}
+	log.Printf("[%s] Resumed session with %d pending tasks, %d unknown", p.Get(Address), len(args.Pending), len(response.GetUnknown()))
+	for _, id := range response.GetUnknown() {
+		p.notify(id, delivery{err: fmt.Errorf("task unknown to Provider after resuming session")})
+	}
+}
+
+// track registers a task before sending it and returns the current connection.
+func (p *Provider) track(id string) (*transport.Messenger, *pendingTask) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, exists := p.session.pending[id]; exists || id == "" {
+		return p.messenger, nil // can't be told apart, don't track
+	}
+	task := &pendingTask{conn: p.messenger, delivered: make(chan delivery, 1)}
+	p.session.pending[id] = task
+	return p.messenger, task
+}
+
+// untrack removes a task after its result was received.
+func (p *Provider) untrack(id string, task *pendingTask) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.session.pending[id] == task {
+		delete(p.session.pending, id)
+	}
+}
+
+// notify hands a delivery to a pending task without blocking.
+func (p *Provider) notify(id string, d delivery) error {
+	p.mutex.RLock()
+	task, ok := p.session.pending[id]
+	p.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("no task %q awaiting a result", id)
+	}
+	select {
+	case task.delivered <- d:
+		return nil
+	default:
+		return fmt.Errorf("result for task %q was already delivered", id)
+	}
+}
+
+// deliver receives a result on a resumed session.
+func (p *Provider) deliver(rq *wasimoff.Task_Deliver_Request) error {
+	var result wasimoff.Task_Response
+	switch r := rq.GetResult().(type) {
+	case *wasimoff.Task_Deliver_Request_Wasip1:
+		result = r.Wasip1
+	case *wasimoff.Task_Deliver_Request_Pyodide:
+		result = r.Pyodide
+	}
+	if result == nil || result.GetInfo() == nil {
+		return fmt.Errorf("delivery without a result")
+	}
+	return p.notify(result.GetInfo().GetId(), delivery{result: result})
+}
+
+// awaitDelivery waits for the result of a task, whose connection was lost, to be
+// delivered on a resumed session.
+func (p *Provider) awaitDelivery(ctx context.Context, task *pendingTask, result wasimoff.Task_Response) error {
+	select {
+
+	case d := <-task.delivered:
+		if d.err != nil {
+			return d.err
+		}
+		if d.result.ProtoReflect().Descriptor() != result.ProtoReflect().Descriptor() {
+			return fmt.Errorf("delivered result has wrong type: %s", d.result.ProtoReflect().Descriptor().FullName())
+		}
+		proto.Reset(result)
+		proto.Merge(result, d.result)
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-p.Closing():
+		return fmt.Errorf("connection lost: %w", p.Err())
+
+	}
+}
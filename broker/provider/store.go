@@ -3,14 +3,18 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"wasi.team/broker/auth"
 	"wasi.team/broker/config"
+	"wasi.team/broker/net/transport"
 	"wasi.team/broker/storage"
 	wasimoff "wasi.team/proto/v1"
 
@@ -30,29 +34,40 @@ type ProviderStore struct {
 	// Providers are held in a sync.Map safe for concurrent access
 	providers *xsync.MapOf[string, *Provider]
 
-	// hold an authenticated client for cloud offloading
+	// shared queue of all cloud offloading targets
 	// check with CanCloudOffload()
-	CloudSubmit   chan *AsyncTask
-	cloudClient   *http.Client
-	cloudFunction string
+	CloudSubmit chan *AsyncTask
 
 	// Storage holds the uploaded files in memory
 	Storage *storage.FileStorage
 
+	// Auth verifies credentials of connecting Providers
+	Auth *auth.ProviderAuth
+
 	// Broadcast is a channel to submit events for all Providers
 	Broadcast chan proto.Message
 
 	// ratecounter is used to keep track of throughput [tasks/s]
 	ratecounter *RateCounter
+
+	// Ledger accounts the contributions of individual Providers
+	Ledger *Ledger
+
+	// last observed length of the task queue
+	queued atomic.Int64
+
+	// how long to keep disconnected Providers for session resumption
+	sessionGrace time.Duration
 }
 
 // NewProviderStore properly initializes the fields in the store
 func NewProviderStore(storagepath string, conf *config.Configuration) (*ProviderStore, error) {
 
 	store := ProviderStore{
-		providers:   xsync.NewMapOf[*Provider](),
-		Broadcast:   make(chan proto.Message, 10),
-		ratecounter: NewRateCounter(5 * time.Second),
+		providers:    xsync.NewMapOf[*Provider](),
+		Broadcast:    make(chan proto.Message, 10),
+		ratecounter:  NewRateCounter(5 * time.Second),
+		sessionGrace: conf.SessionGrace,
 	}
 
 	// initialize metrics gauges
@@ -63,30 +78,56 @@ func NewProviderStore(storagepath string, conf *config.Configuration) (*Provider
 		store.Storage = storage.NewMemoryFileStorage()
 	} else if strings.HasPrefix(storagepath, "boltdb://") {
 		store.Storage = storage.NewBoltFileStorage(storagepath[9:])
+	} else if strings.HasPrefix(storagepath, "s3://") {
+		store.Storage = storage.NewS3FileStorage(storagepath, storage.S3Options(conf.S3))
 	} else if strings.HasPrefix(storagepath, "dirfs://") {
 		store.Storage = storage.NewDirectoryFileStorage(storagepath[8:])
 	} else {
 		store.Storage = storage.NewDirectoryFileStorage(storagepath)
 	}
+	store.Storage.MaxFileSize = conf.StorageMaxFileSize
+	store.Storage.MaxUnpackedSize = conf.StorageMaxUnpacked
+	store.Storage.RemoteHosts = conf.RemoteHosts
+	store.Storage.RemoteMaxSize = conf.RemoteMaxSize
+	store.Storage.SetRetention(conf.StorageTTL, conf.ArtifactTTL, conf.StorageMaxSize)
+	store.Storage.OnRemove = store.evictFiles
+
+	// initialize contribution accounting
+	ledger, err := NewLedger(conf.Contributions, 30*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ledger: %w", err)
+	}
+	store.Ledger = ledger
 
-	// maybe initialize cloud client
-	if conf.CloudFunction != "" && conf.CloudConcurrency > 0 {
+	// initialize provider authentication
+	providerAuth, err := auth.NewProviderAuth(conf.ProviderAuth, conf.ProviderTokens, conf.ProviderJwtSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize provider auth: %w", err)
+	}
+	store.Auth = providerAuth
+	store.Auth.OnReload(store.revalidate)
+
+	// maybe initialize cloud clients
+	cloudWorkers := 0
+	for _, target := range conf.CloudTargets() {
+		if target.Function == "" || target.Concurrency <= 0 {
+			continue
+		}
+		if store.CloudSubmit == nil {
+			store.CloudSubmit = make(chan *AsyncTask) // unbuffered on purpose
+		}
 		// cloudfunction without credentials is probably a local docker container
-		if conf.CloudCredentials != "" {
-			client, err := idtoken.NewClient(context.Background(), conf.CloudFunction, idtoken.WithCredentialsFile(conf.CloudCredentials))
+		client := http.DefaultClient
+		if target.Credentials != "" {
+			client, err = idtoken.NewClient(context.Background(), target.Function, idtoken.WithCredentialsFile(target.Credentials))
 			if err != nil {
-				return nil, fmt.Errorf("failed to initialize GCP cloudclient: %w", err)
+				return nil, fmt.Errorf("failed to initialize GCP cloudclient %q: %w", target.Name, err)
 			}
-			store.cloudClient = client
-		} else {
-			store.cloudClient = http.DefaultClient
 		}
-		store.cloudFunction = conf.CloudFunction
-		go store.cloudLoop(conf.CloudConcurrency)
-		store.metrics.AvailableWorkers.WithLabelValues("cloud").Set(float64(conf.CloudConcurrency))
-	} else {
-		store.metrics.AvailableWorkers.WithLabelValues("cloud").Set(0)
+		go store.cloudLoop(cloudTarget{target.Name, target.Function, client}, target.Concurrency)
+		cloudWorkers += target.Concurrency
 	}
+	store.metrics.AvailableWorkers.WithLabelValues("cloud").Set(float64(cloudWorkers))
 
 	// start broadcast transmitter
 	go store.transmitter()
@@ -105,13 +146,49 @@ func (s *ProviderStore) transmitter() {
 	// broadcast events from channel
 	for event := range s.Broadcast {
 		s.Range(func(_ string, p *Provider) bool {
-			p.messenger.SendEvent(context.Background(), event)
+			p.conn().SendEvent(context.Background(), event)
 			return true
 		})
 	}
 
 }
 
+// evictFiles forgets deleted files on all Providers and tells them to evict
+// their cached copies.
+func (s *ProviderStore) evictFiles(refs []string) {
+	s.Range(func(_ string, p *Provider) bool {
+		for _, ref := range refs {
+			p.files.Delete(ref)
+		}
+		return true
+	})
+	s.Broadcast <- &wasimoff.Event_FileSystemUpdate{Removed: refs}
+}
+
+// ClusterInfo returns the current size and capacity of the cluster.
+func (s *ProviderStore) ClusterInfo() *wasimoff.Event_ClusterInfo {
+	var providers, workers, busy int
+	s.Range(func(_ string, p *Provider) bool {
+		providers += 1
+		workers += p.CurrentLimit()
+		busy += p.RunningTasks()
+		return true
+	})
+	return &wasimoff.Event_ClusterInfo{
+		Providers: proto.Uint32(uint32(providers)),
+		Workers:   proto.Uint32(uint32(workers)),
+		Busy:      proto.Uint32(uint32(busy)),
+		Queued:    proto.Uint32(uint32(s.queued.Load())),
+	}
+}
+
+// Throughput returns the current overall throughput of the cluster.
+func (s *ProviderStore) Throughput() *wasimoff.Event_Throughput {
+	return &wasimoff.Event_Throughput{
+		Overall: proto.Float32(float32(s.ratecounter.GetRate())),
+	}
+}
+
 // ------------- cloud offloading runner client -------------
 
 // check if cloudclient is available and the task can be offloaded
@@ -120,26 +197,33 @@ func (s *ProviderStore) CanCloudOffload(task *AsyncTask) bool {
 	if s.CloudSubmit == nil {
 		return false
 	}
-	// only Wasip1 tasks supported for now
-	if _, ok := task.Request.(*wasimoff.Task_Wasip1_Request); ok {
-		return true
+	// only Wasip1 tasks supported for now, without additional filesystem layers or stdin files
+	if r, ok := task.Request.(*wasimoff.Task_Wasip1_Request); ok {
+		return len(r.GetParams().GetLayers()) == 0 && r.GetParams().GetStdinFile() == nil
 	}
 	// fallback to previous behaviour by default
 	return false
 }
 
+// cloudTarget is a function to offload tasks to with an authenticated client
+type cloudTarget struct {
+	name     string
+	function string
+	client   *http.Client
+}
+
 // cloudRun sends the task to the cloud function using the configured client
-func (s *ProviderStore) cloudRun(ctx context.Context, request wasimoff.Task_Request, response wasimoff.Task_Response) error {
+func (s *ProviderStore) cloudRun(ctx context.Context, target cloudTarget, request wasimoff.Task_Request, response wasimoff.Task_Response) error {
 	body, err := proto.Marshal(request)
 	if err != nil {
 		return fmt.Errorf("failed marshalling request: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cloudFunction, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.function, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create POST request: %w", err)
 	}
 	req.Header.Set("content-type", "application/proto")
-	resp, err := s.cloudClient.Do(req)
+	resp, err := target.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("cloud offloading request failed: %w", err)
 	}
@@ -155,11 +239,11 @@ func (s *ProviderStore) cloudRun(ctx context.Context, request wasimoff.Task_Requ
 	return nil
 }
 
-// throughput-limited listener loop for incoming cloud offloading requests
-func (s *ProviderStore) cloudLoop(concurrency int) {
+// throughput-limited listener loop for incoming cloud offloading requests,
+// multiple targets receive from the same queue
+func (s *ProviderStore) cloudLoop(target cloudTarget, concurrency int) {
 
 	limiter := semaphore.New(concurrency) // limit simultaneous cloud invocations
-	s.CloudSubmit = make(chan *AsyncTask) // unbuffered on purpose
 
 	for {
 
@@ -182,9 +266,9 @@ func (s *ProviderStore) cloudLoop(concurrency int) {
 
 		// run the request asynchronously
 		go func(limiter semaphore.Semaphore, task *AsyncTask) {
-			task.Request.GetInfo().Provider = proto.String("cloud")
+			task.Assign(target.name)
 			task.Request.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerTransmitProviderTask)
-			err := s.cloudRun(task.Context, task.Request, task.Response)
+			err := s.cloudRun(task.Context, target, task.Request, task.Response)
 			if err != nil {
 				task.Error = fmt.Errorf("cloud offload failed: %w", err)
 			}
@@ -198,37 +282,90 @@ func (s *ProviderStore) cloudLoop(concurrency int) {
 
 // -------------- ratecounter in tasks/second --------------
 
-// throughput expects
+// throughput sends the overall throughput and their own contribution to each Provider
 func (s *ProviderStore) throughput(tick time.Duration) {
 	for range time.Tick(tick) {
-		tps := s.ratecounter.GetRate()
-
-		select {
-		case s.Broadcast <- &wasimoff.Event_Throughput{
-			Overall: proto.Float32(float32(tps)),
-			// TODO: add individual contribution
-		}:
-			// ok
-		default: // never block
-		}
-
+		overall := s.Throughput().Overall
+		s.Range(func(_ string, p *Provider) bool {
+			// never block on a slow Provider
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), tick)
+				defer cancel()
+				p.conn().SendEvent(ctx, &wasimoff.Event_Throughput{
+					Overall: overall,
+					Yours:   proto.Float32(float32(s.Ledger.Rate(p.Contributor()))),
+				})
+			}()
+			return true
+		})
 	}
 }
 
 // --------------- stub methods for sync.Map ---------------
 
-// Add a Provider to the Map.
+// Add a Provider to the Map. It is removed automatically when closed.
 func (s *ProviderStore) Add(provider *Provider) {
-	s.providers.Store(provider.Get(Address), provider)
+	provider.mutex.Lock()
+	provider.ledger = s.Ledger
+	provider.mutex.Unlock()
+	s.providers.Store(provider.Get(ID), provider)
 	log.Printf("ProviderStore: %d connected", s.Size())
-	s.Broadcast <- &wasimoff.Event_ClusterInfo{Providers: proto.Uint32(uint32(s.Size()))}
+	s.Broadcast <- s.ClusterInfo()
+	go func() {
+		<-provider.Closing()
+		s.Remove(provider)
+	}()
+}
+
+// Resume looks up a Provider by its session token and attaches the new Messenger
+// to it. The verified identity must match the one used to start the session.
+// Returns nil if there is no such session to resume.
+func (s *ProviderStore) Resume(token, identity string, msg *transport.Messenger) *Provider {
+	if token == "" {
+		return nil
+	}
+	var provider *Provider
+	s.Range(func(_ string, p *Provider) bool {
+		if subtle.ConstantTimeCompare([]byte(p.session.token), []byte(token)) == 1 {
+			provider = p
+			return false
+		}
+		return true
+	})
+	if provider == nil || provider.Get(Identity) != identity || provider.resume(msg) != nil {
+		return nil
+	}
+	return provider
 }
 
-// Remove a Provider from the Map.
+// Remove a Provider from the Map. Removing it again is a no-op.
 func (s *ProviderStore) Remove(provider *Provider) {
-	s.providers.Delete(provider.Get(Address))
+	if _, ok := s.providers.LoadAndDelete(provider.Get(ID)); !ok {
+		return
+	}
 	log.Printf("ProviderStore: %d connected", s.Size())
-	s.Broadcast <- &wasimoff.Event_ClusterInfo{Providers: proto.Uint32(uint32(s.Size()))}
+	s.Broadcast <- s.ClusterInfo()
+}
+
+// revalidate closes all Providers whose credentials are not valid anymore.
+func (s *ProviderStore) revalidate() {
+	for _, p := range s.Values() {
+		if p.Get(Identity) == "" {
+			continue // anonymous connection
+		}
+		if _, err := s.Auth.Verify(p.credential); err != nil {
+			log.Printf("[%s] Closing Provider %s: %s", p.Get(Address), p.Get(Identity), err)
+			p.Close(err)
+		}
+	}
+}
+
+// CloseAll removes and closes all connected Providers, e.g. during shutdown.
+func (s *ProviderStore) CloseAll(reason error) {
+	for _, p := range s.Values() {
+		s.Remove(p)
+		p.Close(reason)
+	}
 }
 
 // Size is the current size of the Map.
@@ -236,9 +373,9 @@ func (s *ProviderStore) Size() int {
 	return s.providers.Size()
 }
 
-// Load a Provider from the Map by its address.
-func (s *ProviderStore) Load(addr string) *Provider {
-	p, ok := s.providers.Load(addr)
+// Load a Provider from the Map by its ID.
+func (s *ProviderStore) Load(id string) *Provider {
+	p, ok := s.providers.Load(id)
 	if !ok {
 		return nil
 	}
@@ -248,15 +385,15 @@ func (s *ProviderStore) Load(addr string) *Provider {
 // Range will iterate over all Providers in the Map and call the given function.
 // If the function returns `false`, the iteration will stop. See xsync.Map.Range()
 // for more usage notes and (lack of) guarantees.
-func (s *ProviderStore) Range(f func(addr string, provider *Provider) bool) {
+func (s *ProviderStore) Range(f func(id string, provider *Provider) bool) {
 	s.providers.Range(f)
 }
 
-// Keys will simply return the current keys (Provider addresses) of the Map.
+// Keys will simply return the current keys (Provider IDs) of the Map.
 func (s *ProviderStore) Keys() []string {
 	keys := make([]string, 0, s.Size())
-	s.Range(func(addr string, _ *Provider) bool {
-		keys = append(keys, addr)
+	s.Range(func(id string, _ *Provider) bool {
+		keys = append(keys, id)
 		return true
 	})
 	return keys
@@ -8,6 +8,7 @@ import (
 
 	"wasi.team/broker/provider"
 	"wasi.team/broker/scheduler"
+	"wasi.team/broker/storage"
 	wasimoff "wasi.team/proto/v1"
 
 	"google.golang.org/protobuf/encoding/prototext"
@@ -30,7 +31,7 @@ func BenchmodeTspFlood(store *provider.ProviderStore, parallel int) {
 		if store.Storage.Get(bin) != nil {
 			// file uploaded
 			log.Printf("BENCHMODE: required binary uploaded, let's go ...")
-			err := store.Storage.ResolvePbFile(&binary) // ! <-- this one is important
+			err := store.Storage.ResolvePbFile(context.Background(), storage.DefaultNamespaces, &binary) // ! <-- this one is important
 			if err != nil {
 				panic(err)
 			}
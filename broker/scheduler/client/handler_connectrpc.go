@@ -2,17 +2,25 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
 	"strconv"
 	"sync/atomic"
+	"time"
 
+	"wasi.team/broker/auth"
 	"wasi.team/broker/provider"
 	"wasi.team/broker/scheduler"
 	"wasi.team/broker/storage"
+	"wasi.team/broker/tracing"
 	wasimoff "wasi.team/proto/v1"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // This ConnectRPC server implements the Wasimoff service from messages.proto, to
@@ -24,6 +32,30 @@ import (
 type ConnectRpcServer struct {
 	Store   *provider.ProviderStore
 	taskSeq atomic.Uint64
+
+	// minimum interval of cluster events for subscribed Clients
+	EventInterval time.Duration
+
+	// exports traces of completed tasks, may be nil
+	Traces *tracing.Exporter
+
+	// keeps recent traces for timeline export, may be nil
+	History *tracing.History
+
+	// fraction of untraced tasks for which a trace is started, as float64 bits
+	sampling atomic.Uint64
+}
+
+// SetTraceSampling sets the fraction of untraced tasks, for which the Broker starts
+// a trace itself.
+func (s *ConnectRpcServer) SetTraceSampling(fraction float64) {
+	s.sampling.Store(math.Float64bits(fraction))
+}
+
+// traced hands the trace of a completed task to the exporter and the history.
+func (s *ConnectRpcServer) traced(info *wasimoff.Task_Metadata, err error) {
+	s.Traces.Export(info, err)
+	s.History.Record(info)
 }
 
 func (s *ConnectRpcServer) Upload(
@@ -47,9 +79,15 @@ func (s *ConnectRpcServer) Upload(
 	// can have a friendly lookup-name as query parameter
 	name := u.GetRef()
 
-	// insert file in storage
-	file, err := s.Store.Storage.Insert(name, ft, u.Blob)
-	if err != nil {
+	// insert file in storage, with the name in the client's namespace
+	file, err := s.Store.Storage.InsertNamed(ctx, name, ft, u.Blob)
+	if errors.Is(err, storage.ErrInvalidName) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if errors.Is(err, storage.ErrTooLarge) || errors.Is(err, auth.ErrQuotaExceeded) {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	} else if errors.Is(err, storage.ErrInvalidFile) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
 		return nil, fmt.Errorf("inserting in storage failed: %w", err)
 	}
 
@@ -59,6 +97,112 @@ func (s *ConnectRpcServer) Upload(
 	}), nil
 }
 
+func (s *ConnectRpcServer) UploadChunk(
+	ctx context.Context,
+	req *connect.Request[wasimoff.Filesystem_Chunk_Upload_Request],
+) (
+	*connect.Response[wasimoff.Filesystem_Chunk_Upload_Response],
+	error,
+) {
+	// append the chunk to a partial upload in storage
+	response, err := s.Store.Storage.UploadChunk(ctx, req.Msg)
+	if errors.Is(err, storage.ErrTooLarge) || errors.Is(err, auth.ErrQuotaExceeded) {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	} else if errors.Is(err, storage.ErrInvalidFile) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("chunked upload failed: %w", err)
+	}
+	return connect.NewResponse(response), nil
+}
+
+func (s *ConnectRpcServer) DownloadChunk(
+	ctx context.Context,
+	req *connect.Request[wasimoff.Filesystem_Chunk_Download_Request],
+) (
+	*connect.Response[wasimoff.Filesystem_Chunk_Download_Response],
+	error,
+) {
+	// read the requested range from storage
+	response, err := s.Store.Storage.DownloadChunk(storage.NamespacesFrom(ctx), req.Msg)
+	if err != nil {
+		return nil, fmt.Errorf("chunked download failed: %w", err)
+	}
+	return connect.NewResponse(response), nil
+}
+
+func (s *ConnectRpcServer) ListFiles(
+	ctx context.Context,
+	req *connect.Request[wasimoff.Filesystem_List_Request],
+) (
+	*connect.Response[wasimoff.Filesystem_List_Response],
+	error,
+) {
+	// list a page of named files in the client's namespaces
+	r := req.Msg
+	entries, next, err := s.Store.Storage.List(storage.NamespacesFrom(ctx), r.GetPrefix(), r.GetCursor(), int(r.GetLimit()))
+	if err != nil {
+		return nil, fmt.Errorf("listing files failed: %w", err)
+	}
+	response := &wasimoff.Filesystem_List_Response{
+		Files: make([]*wasimoff.Filesystem_List_Entry, 0, len(entries)),
+		Next:  proto.String(next),
+	}
+	for _, e := range entries {
+		response.Files = append(response.Files, &wasimoff.Filesystem_List_Entry{
+			Name:     proto.String(e.Name),
+			Ref:      proto.String(e.Ref),
+			Media:    proto.String(e.Media),
+			Size:     proto.Uint64(uint64(e.Size)),
+			Uploaded: timestamp(e.Uploaded),
+			Accessed: timestamp(e.Accessed),
+			Uploader: proto.String(e.Uploader),
+			Wasm:     wasmInfo(e.Wasm),
+			Zip:      archiveInfo(e.Zip),
+			Tar:      archiveInfo(e.Tar),
+		})
+	}
+	return connect.NewResponse(response), nil
+}
+
+// wasmInfo converts the inspection results of a WebAssembly binary to protobuf.
+func wasmInfo(w *storage.WasmInfo) *wasimoff.Filesystem_WasmInfo {
+	if w == nil {
+		return nil
+	}
+	info := &wasimoff.Filesystem_WasmInfo{
+		Component: proto.Bool(w.Component),
+		Version:   proto.Uint32(uint32(w.Version)),
+		Wasi:      proto.String(w.Wasi),
+		Imports:   w.Imports,
+		Exports:   w.Exports,
+	}
+	if w.Memory != nil {
+		info.MemoryMin = proto.Uint64(w.Memory.Min)
+		info.MemoryMax = w.Memory.Max
+	}
+	return info
+}
+
+// archiveInfo converts the inspection results of an archive to protobuf.
+func archiveInfo(a *storage.ArchiveInfo) *wasimoff.Filesystem_ArchiveInfo {
+	if a == nil {
+		return nil
+	}
+	return &wasimoff.Filesystem_ArchiveInfo{
+		Files:    proto.Uint32(uint32(a.Files)),
+		Unpacked: proto.Uint64(a.Unpacked),
+	}
+}
+
+// timestamp converts a time to protobuf, leaving unknown times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 func (s *ConnectRpcServer) RunWasip1(
 	ctx context.Context,
 	req *connect.Request[wasimoff.Task_Wasip1_Request],
@@ -66,14 +210,25 @@ func (s *ConnectRpcServer) RunWasip1(
 	*connect.Response[wasimoff.Task_Wasip1_Response],
 	error,
 ) {
+	// check the client's limits
+	release, err := s.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// assemble task info for internal dispatcher queue
 	r := req.Msg
-	r.Info = s.prepareTaskInfo(r.GetInfo(), req.Peer())
+	r.Info = s.prepareTaskInfo(ctx, r.GetInfo(), req.Peer())
 
-	// resolve any filenames to storage hashes
-	if err := s.Store.Storage.ResolveTaskFiles(r); err != nil {
+	// resolve any filenames to storage hashes, keeping the files while queued
+	unpin, err := s.Store.Storage.ResolveTaskFiles(ctx, storage.NamespacesFrom(ctx), r)
+	if errors.Is(err, auth.ErrQuotaExceeded) {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	} else if err != nil {
 		return nil, err
 	}
+	defer unpin()
 
 	// dispatch
 	response := &wasimoff.Task_Wasip1_Response{}
@@ -84,9 +239,17 @@ func (s *ConnectRpcServer) RunWasip1(
 	s.copyTaskInfo(r.Info, &response.Info)
 
 	if call.Error != nil {
+		s.traced(response.GetInfo(), call.Error)
 		return nil, call.Error
 	} else {
+		if r.GetParams().GetStoreArtifacts() {
+			if err := s.storeArtifacts(ctx, response.GetInfo(), response.GetOk().GetArtifacts()); err != nil {
+				s.traced(response.GetInfo(), err)
+				return nil, err
+			}
+		}
 		response.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerTransmitClientResponse)
+		s.traced(response.GetInfo(), nil)
 		return connect.NewResponse(response), nil
 	}
 
@@ -99,9 +262,25 @@ func (s *ConnectRpcServer) RunPyodide(
 	*connect.Response[wasimoff.Task_Pyodide_Response],
 	error,
 ) {
+	// check the client's limits
+	release, err := s.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// assemble task info for internal dispatcher queue
 	r := req.Msg
-	r.Info = s.prepareTaskInfo(r.GetInfo(), req.Peer())
+	r.Info = s.prepareTaskInfo(ctx, r.GetInfo(), req.Peer())
+
+	// resolve the rootfs to a storage hash, keeping the file while queued
+	unpin, err := s.Store.Storage.ResolveTaskFiles(ctx, storage.NamespacesFrom(ctx), r)
+	if errors.Is(err, auth.ErrQuotaExceeded) {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	} else if err != nil {
+		return nil, err
+	}
+	defer unpin()
 
 	// dispatch
 	response := &wasimoff.Task_Pyodide_Response{}
@@ -112,16 +291,42 @@ func (s *ConnectRpcServer) RunPyodide(
 	s.copyTaskInfo(r.Info, &response.Info)
 
 	if call.Error != nil {
+		s.traced(response.GetInfo(), call.Error)
 		return nil, call.Error
 	} else {
+		if r.GetParams().GetStoreArtifacts() {
+			if err := s.storeArtifacts(ctx, response.GetInfo(), response.GetOk().GetArtifacts()); err != nil {
+				s.traced(response.GetInfo(), err)
+				return nil, err
+			}
+		}
 		response.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerTransmitClientResponse)
+		s.traced(response.GetInfo(), nil)
 		return connect.NewResponse(response), nil
 	}
 
 }
 
+// insert the returned artifacts in storage; they were requested as a ref, so fail
+// instead of returning them inline
+func (s *ConnectRpcServer) storeArtifacts(ctx context.Context, info *wasimoff.Task_Metadata, artifacts *wasimoff.File) error {
+	err := s.Store.Storage.StoreArtifacts(ctx, artifacts)
+	if errors.Is(err, auth.ErrQuotaExceeded) || errors.Is(err, storage.ErrTooLarge) {
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("storing artifacts: %w", err))
+	} else if err != nil {
+		log.Printf("ERR: storing artifacts of task %s failed: %s", info.GetId(), err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("storing artifacts failed"))
+	}
+	return nil
+}
+
 // try to submit a task to the queue or return an error immediately
 func SubmitToQueue(queue chan *provider.AsyncTask, task *provider.AsyncTask) {
+	if scheduler.ShuttingDown() {
+		task.Error = fmt.Errorf("503: %w", scheduler.ErrShuttingDown)
+		task.Done()
+		return
+	}
 	select {
 	case queue <- task:
 		return // ok
@@ -132,19 +337,38 @@ func SubmitToQueue(queue chan *provider.AsyncTask, task *provider.AsyncTask) {
 	}
 }
 
+// admit checks the rate limit and concurrency of the authenticated client
+func (s *ConnectRpcServer) admit(ctx context.Context) (release func(), err error) {
+	release, err = auth.ClientFromContext(ctx).Admit()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return release, nil
+}
+
 // -------------------- handlers for task metadata --------------------
 
-func (s *ConnectRpcServer) prepareTaskInfo(info *wasimoff.Task_Metadata, peer connect.Peer) *wasimoff.Task_Metadata {
-	if info != nil {
-		info.TraceEvent(wasimoff.Task_TraceEvent_BrokerReceivedClientRequest)
+func (s *ConnectRpcServer) prepareTaskInfo(ctx context.Context, info *wasimoff.Task_Metadata, peer connect.Peer) *wasimoff.Task_Metadata {
+	// start a trace for a sample of the tasks without one
+	trace := info.GetTrace()
+	if sampling := math.Float64frombits(s.sampling.Load()); trace == nil && sampling > 0 && rand.Float64() < sampling {
+		trace = &wasimoff.Task_Trace{Created: proto.Int64(time.Now().UnixNano())}
+	}
+	// prefer the verified identity over the remote address
+	requester := peer.Addr
+	if identity := auth.ClientFromContext(ctx).Identity(); identity != "" {
+		requester = identity
 	}
-	return &wasimoff.Task_Metadata{
+	prepared := &wasimoff.Task_Metadata{
 		Id:        proto.String(strconv.FormatUint(s.taskSeq.Add(1), 10)),
-		Requester: proto.String(peer.Addr),
+		Requester: proto.String(requester),
+		Namespace: proto.String(storage.NamespacesFrom(ctx).Write),
 		Reference: proto.String(info.GetReference()),
-		Trace:     info.GetTrace(),
+		Trace:     trace,
 		Provider:  nil,
 	}
+	prepared.TraceEvent(wasimoff.Task_TraceEvent_BrokerReceivedClientRequest)
+	return prepared
 }
 
 func (s *ConnectRpcServer) copyTaskInfo(info *wasimoff.Task_Metadata, res **wasimoff.Task_Metadata) {
@@ -156,6 +380,7 @@ func (s *ConnectRpcServer) copyTaskInfo(info *wasimoff.Task_Metadata, res **wasi
 		r.Id = info.Id
 		r.Reference = info.Reference
 		r.Requester = info.Requester
+		r.Namespace = info.Namespace
 	}
 	(*res).TraceEvent(wasimoff.Task_TraceEvent_BrokerReceivedProviderResult)
 }
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wasi.team/broker/net/transport"
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Clients can subscribe to the same ClusterInfo and Throughput events that are
+// broadcast to Providers, e.g. for dashboards or autoscalers. Events are sent at
+// a regular interval, which can not be shorter than the configured EventInterval.
+
+// eventInterval clamps a requested interval to the configured minimum.
+func (s *ConnectRpcServer) eventInterval(requested time.Duration) time.Duration {
+	minimum := s.EventInterval
+	if minimum <= 0 {
+		minimum = time.Second
+	}
+	return max(requested, minimum)
+}
+
+// clusterEvents sends the current cluster state with send at every interval until
+// the context is cancelled or sending fails.
+func (s *ConnectRpcServer) clusterEvents(ctx context.Context, interval time.Duration, send func(proto.Message) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, event := range []proto.Message{s.Store.ClusterInfo(), s.Store.Throughput()} {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribe handles an Event_Subscribe_Request on a Client WebSocket. Any previous
+// subscription on the same socket is replaced.
+func (s *ConnectRpcServer) subscribe(ctx context.Context, messenger *transport.Messenger, previous context.CancelFunc, request *wasimoff.Event_Subscribe_Request) (context.CancelFunc, *wasimoff.Event_Subscribe_Response) {
+	if previous != nil {
+		previous()
+	}
+	if request.GetInterval() == 0 {
+		return nil, &wasimoff.Event_Subscribe_Response{Interval: proto.Uint32(0)}
+	}
+	interval := s.eventInterval(time.Duration(request.GetInterval()) * time.Millisecond)
+	ctx, cancel := context.WithCancel(ctx)
+	go s.clusterEvents(ctx, interval, func(event proto.Message) error {
+		return messenger.SendEvent(ctx, event)
+	})
+	return cancel, &wasimoff.Event_Subscribe_Response{Interval: proto.Uint32(uint32(interval.Milliseconds()))}
+}
+
+// ClusterEventsHandler streams cluster events as Server-Sent Events. The interval
+// can be given as a duration or in milliseconds with an `?interval=` parameter.
+func ClusterEventsHandler(rpc *ConnectRpcServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr := transport.ProxiedAddr(r)
+
+		// parse the requested interval
+		var requested time.Duration
+		if param := r.URL.Query().Get("interval"); param != "" {
+			var err error
+			if requested, err = time.ParseDuration(param); err != nil {
+				ms, err := strconv.ParseUint(param, 10, 32)
+				if err != nil {
+					http.Error(w, "malformed interval", http.StatusBadRequest)
+					return
+				}
+				requested = time.Duration(ms) * time.Millisecond
+			}
+		}
+		interval := rpc.eventInterval(requested)
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		log.Printf("[%s] New Client event stream, every %s", addr, interval)
+		err := rpc.clusterEvents(r.Context(), interval, func(event proto.Message) error {
+			data, err := protojson.Marshal(event)
+			if err != nil {
+				return err
+			}
+			name := event.ProtoReflect().Descriptor().Name()
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+		log.Printf("[%s] Client event stream closed: %v", addr, err)
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,7 +12,10 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/shlex"
+	"google.golang.org/protobuf/proto"
+	"wasi.team/broker/auth"
 	"wasi.team/broker/net/transport"
+	"wasi.team/broker/storage"
 	wasimoff "wasi.team/proto/v1"
 )
 
@@ -71,11 +75,33 @@ func HttpExecWasip1Handler(rpc *ConnectRpcServer) http.HandlerFunc {
 				}
 			}
 
-			// add artifact paths from header(s)
+			// add filesystem layers from header(s) as `ref=/mount:ro`
+			if key == "X-Layer" {
+				for _, spec := range values {
+					layer, err := wasimoff.ParseLayer(spec)
+					if err != nil {
+						http.Error(w, fmt.Sprintf("malformatted X-Layer: %s", err), http.StatusBadRequest)
+						return
+					}
+					task.Layers = append(task.Layers, layer)
+				}
+			}
+
+			// add artifact paths from header(s), which are returned inline
 			if key == "X-Artifact" {
 				task.Artifacts = values
 			}
 
+			// optionally store the artifacts and return them by ref instead
+			if key == "X-Store-Artifacts" {
+				store, err := strconv.ParseBool(values[0])
+				if err != nil {
+					http.Error(w, "malformatted X-Store-Artifacts", http.StatusBadRequest)
+					return
+				}
+				task.StoreArtifacts = proto.Bool(store)
+			}
+
 		}
 
 		// pass through content-length and content-type specifically and always override
@@ -86,16 +112,31 @@ func HttpExecWasip1Handler(rpc *ConnectRpcServer) http.HandlerFunc {
 			task.Envs = append(task.Envs, fmt.Sprintf("%s=%s", "CONTENT_TYPE", contentType))
 		}
 
-		// read stdin from request body
+		// read stdin from request body, large inputs are staged in storage and
+		// transferred to the Provider in chunks instead of inline
 		if r.Body != nil {
-			var stdin bytes.Buffer
 			defer r.Body.Close()
-			_, err := io.Copy(&stdin, r.Body)
+			head, err := io.ReadAll(io.LimitReader(r.Body, storage.ChunkSize+1))
 			if err != nil {
 				http.Error(w, "error reading request body", http.StatusBadRequest)
 				return
 			}
-			task.Stdin = stdin.Bytes()
+			if len(head) <= storage.ChunkSize {
+				task.Stdin = head
+			} else {
+				ref, err := rpc.Store.Storage.StoreStdin(r.Context(), io.MultiReader(bytes.NewReader(head), r.Body), r.ContentLength)
+				if errors.Is(err, storage.ErrTooLarge) {
+					http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+					return
+				} else if errors.Is(err, auth.ErrQuotaExceeded) {
+					http.Error(w, err.Error(), http.StatusTooManyRequests)
+					return
+				} else if err != nil {
+					http.Error(w, "error storing request body", http.StatusInternalServerError)
+					return
+				}
+				task.StdinFile = &wasimoff.File{Ref: &ref}
+			}
 		}
 
 		// log.Printf("{client %s} %s", addr, prototext.Format(task))
@@ -105,7 +146,11 @@ func HttpExecWasip1Handler(rpc *ConnectRpcServer) http.HandlerFunc {
 		})
 		response, err := rpc.RunWasip1(r.Context(), request)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			status := http.StatusInternalServerError
+			if connect.CodeOf(err) == connect.CodeResourceExhausted {
+				status = http.StatusTooManyRequests
+			}
+			http.Error(w, err.Error(), status)
 			return
 		}
 		if response.Msg == nil {
@@ -135,7 +180,12 @@ func HttpExecWasip1Handler(rpc *ConnectRpcServer) http.HandlerFunc {
 				w.Header().Set("X-Wasimoff-Status", strconv.Itoa(int(*ok.Status)))
 			}
 
-			// base64-encode the artifact, let's hope nobody ever uses this for large files
+			// return the ref of the stored artifacts, which can be downloaded from storage
+			if ref := ok.GetArtifacts().GetRef(); ref != "" {
+				w.Header().Set("X-Wasimoff-Artifacts-Ref", ref)
+			}
+
+			// base64-encode the artifacts, unless they were stored
 			if ok.Artifacts != nil && ok.Artifacts.Blob != nil {
 				blob := base64.StdEncoding.EncodeToString(ok.Artifacts.Blob)
 				w.Header().Set("X-Wasimoff-Artifacts", blob)
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"wasi.team/broker/auth"
+	"wasi.team/broker/scheduler"
+	"wasi.team/broker/storage"
+)
+
+// TaskSummary is the public information about an in-flight task.
+type TaskSummary struct {
+	ID        string    `json:"id"`
+	Reference string    `json:"reference,omitempty"`
+	Requester string    `json:"requester,omitempty"`
+	Namespace string    `json:"namespace"`
+	Started   time.Time `json:"started"`
+}
+
+// TaskListHandler lists the currently scheduled or running tasks in the namespace
+// of the requesting Client. Tasks of other tenants are never shown and the
+// requester is only shown on the Client's own tasks.
+func TaskListHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := storage.NamespacesFrom(r.Context()).Write
+
+		tasks := []TaskSummary{}
+		for _, task := range scheduler.InFlight() {
+			info := task.Request.GetInfo()
+			if info.GetNamespace() != namespace {
+				continue
+			}
+			tasks = append(tasks, TaskSummary{
+				ID:        info.GetId(),
+				Reference: info.GetReference(),
+				Requester: ownRequester(r.Context(), info.GetRequester()),
+				Namespace: info.GetNamespace(),
+				Started:   task.TimeStart,
+			})
+		}
+		slices.SortFunc(tasks, func(a, b TaskSummary) int {
+			return a.Started.Compare(b.Started)
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tasks)
+	}
+}
+
+// ownRequester returns the requester of a task only if it is the authenticated
+// Client itself, since namespaces can be shared with others.
+func ownRequester(ctx context.Context, requester string) string {
+	if identity := auth.ClientFromContext(ctx).Identity(); identity != "" && identity == requester {
+		return requester
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+
+	"wasi.team/broker/storage"
+	"wasi.team/broker/tracing"
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ChromeTraceHandler exports the timelines of recently traced tasks in the namespace
+// of the requesting Client as Chrome trace-event JSON, e.g. to load into Perfetto.
+// The requester is only included on the Client's own tasks. The number of most
+// recent tasks can be limited with a `?limit=` parameter.
+func ChromeTraceHandler(history *tracing.History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := storage.NamespacesFrom(r.Context()).Write
+
+		limit := 0
+		if param := r.URL.Query().Get("limit"); param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil || n < 0 {
+				http.Error(w, "malformed limit", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		tasks := history.Tasks(func(info *wasimoff.Task_Metadata) bool {
+			return info.GetNamespace() == namespace
+		})
+		if limit > 0 && len(tasks) > limit {
+			tasks = tasks[len(tasks)-limit:]
+		}
+		for i, info := range tasks {
+			if ownRequester(r.Context(), info.GetRequester()) == "" {
+				tasks[i] = proto.Clone(info).(*wasimoff.Task_Metadata)
+				tasks[i].Requester = nil
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="wasimoff-trace.json"`)
+		tracing.WriteChromeTrace(w, tasks)
+	}
+}
@@ -18,14 +18,13 @@ import (
 // as an endpoint for Clients to connect to. This particular handler uses WebSocket
 // transport with either Protobuf or JSON encoding, negotiated using subprotocol strings.
 // func ClientSocketHandler(rpc *WasimoffRPCServer) http.HandlerFunc {
-func ClientSocketHandler(rpc *ConnectRpcServer) http.HandlerFunc {
+func ClientSocketHandler(rpc *ConnectRpcServer, origins *transport.Origins) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		addr := transport.ProxiedAddr(r)
 
 		// upgrade the transport
-		// using wildcard in Allowed-Origins because Client can be anywhere
-		wst, err := transport.UpgradeToWebSocketTransport(w, r, []string{"*"})
+		wst, err := transport.UpgradeToWebSocketTransport(w, r, origins.Get())
 		if err != nil {
 			log.Printf("[%s] New Client socket: upgrade failed: %s", addr, err)
 			return
@@ -33,6 +32,14 @@ func ClientSocketHandler(rpc *ConnectRpcServer) http.HandlerFunc {
 		messenger := transport.NewMessengerInterface(wst)
 		log.Printf("[%s] New Client socket", addr)
 
+		// cancels the current subscription to cluster events, if any
+		var unsubscribe context.CancelFunc
+		defer func() {
+			if unsubscribe != nil {
+				unsubscribe()
+			}
+		}()
+
 		defer log.Printf("[%s] Client socket closed", addr)
 		for {
 			select {
@@ -93,6 +100,48 @@ func ClientSocketHandler(rpc *ConnectRpcServer) http.HandlerFunc {
 					}(r.Context(), request, taskrequest)
 					continue
 
+				case *wasimoff.Filesystem_Chunk_Upload_Request:
+					go func(ctx context.Context, req transport.IncomingRequest, chunk *wasimoff.Filesystem_Chunk_Upload_Request) {
+						r := connect.NewRequest(chunk)
+						resp, err := rpc.UploadChunk(ctx, r)
+						var msg proto.Message
+						if resp != nil {
+							msg = resp.Msg
+						}
+						req.Respond(ctx, msg, err)
+					}(r.Context(), request, taskrequest)
+					continue
+
+				case *wasimoff.Filesystem_Chunk_Download_Request:
+					go func(ctx context.Context, req transport.IncomingRequest, chunk *wasimoff.Filesystem_Chunk_Download_Request) {
+						r := connect.NewRequest(chunk)
+						resp, err := rpc.DownloadChunk(ctx, r)
+						var msg proto.Message
+						if resp != nil {
+							msg = resp.Msg
+						}
+						req.Respond(ctx, msg, err)
+					}(r.Context(), request, taskrequest)
+					continue
+
+				case *wasimoff.Filesystem_List_Request:
+					go func(ctx context.Context, req transport.IncomingRequest, list *wasimoff.Filesystem_List_Request) {
+						r := connect.NewRequest(list)
+						resp, err := rpc.ListFiles(ctx, r)
+						var msg proto.Message
+						if resp != nil {
+							msg = resp.Msg
+						}
+						req.Respond(ctx, msg, err)
+					}(r.Context(), request, taskrequest)
+					continue
+
+				case *wasimoff.Event_Subscribe_Request:
+					var response *wasimoff.Event_Subscribe_Response
+					unsubscribe, response = rpc.subscribe(r.Context(), messenger, unsubscribe, taskrequest)
+					request.Respond(r.Context(), response, nil)
+					continue
+
 				default: // unexpected message type
 					request.Respond(r.Context(), nil, fmt.Errorf("expecting only Task_Request/Upload messages on this socket"))
 					continue
@@ -3,9 +3,12 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"wasi.team/broker/provider"
@@ -15,6 +18,28 @@ import (
 // reuseable task queue for HTTP handler and websocket
 var TaskQueue = make(chan *provider.AsyncTask, 2048)
 
+// ErrShuttingDown is returned for tasks that are rejected or cancelled during shutdown.
+var ErrShuttingDown = errors.New("broker is shutting down")
+
+// state for a graceful shutdown of the dispatcher
+var (
+	shuttingDown atomic.Bool           // no new tasks are accepted
+	expired      atomic.Bool           // shutdown timeout expired, queued tasks fail
+	inflight     atomic.Int64          // tasks taken from the queue, which aren't finished
+	drained      = make(chan struct{}) // closed when nothing is queued or in flight
+	drainedOnce  sync.Once
+	cancels      sync.Map // map[*provider.AsyncTask]context.CancelCauseFunc
+)
+
+// InFlight returns all tasks which are currently being scheduled or executed.
+func InFlight() (tasks []*provider.AsyncTask) {
+	cancels.Range(func(task, _ any) bool {
+		tasks = append(tasks, task.(*provider.AsyncTask))
+		return true
+	})
+	return tasks
+}
+
 // Scheduler is a generic interface which must be fulfilled by a concrete scheduler,
 // i.e. the type that selects suitable providers given task information and submits the task.
 type Scheduler interface {
@@ -22,11 +47,26 @@ type Scheduler interface {
 	Schedule(ctx context.Context, task *provider.AsyncTask) error
 }
 
+// NewSelector returns a Scheduler by name: "simplematch", "roundrobin" or "anyfree".
+func NewSelector(name string, store *provider.ProviderStore) (Scheduler, error) {
+	switch name {
+	case "", "simplematch":
+		return NewSimpleMatchSelector(store), nil
+	case "roundrobin":
+		return NewRoundRobinSelector(store), nil
+	case "anyfree":
+		return NewAnyFreeSelector(store), nil
+	default:
+		return nil, fmt.Errorf("unknown scheduler %q", name)
+	}
+}
+
 // The Dispatcher takes a task queue and a provider selector strategy and then
 // decides which task to send to which provider for computation. Additionally,
 // limit the number of concurrently scheduling tasks (this does not mean running,
-// in-flight tasks but those that are currently "looking for a slot").
-func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency int) {
+// in-flight tasks but those that are currently "looking for a slot") and the
+// number of attempts per task.
+func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency, retries int) {
 
 	// use ticketing to limit simultaneous schedules
 	tickets := make(chan struct{}, concurrency)
@@ -42,17 +82,54 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 		}
 	}()
 
-	for task := range TaskQueue {
+	// during shutdown, regularly check if the queue was drained; only this loop
+	// takes tasks from the queue, so the check can't miss a task in between
+	check := time.NewTicker(50 * time.Millisecond)
+	defer check.Stop()
+
+	for {
+		var task *provider.AsyncTask
+		select {
+		case task = <-TaskQueue:
+		case <-check.C:
+			if ShuttingDown() && len(TaskQueue) == 0 && inflight.Load() == 0 {
+				drainedOnce.Do(func() { close(drained) })
+			}
+			continue
+		}
+
+		// fail queued tasks immediately once the shutdown timeout expired
+		if expired.Load() {
+			task.Error = ErrShuttingDown
+			store.ObserveCompleted(task)
+			task.Done()
+			continue
+		}
+
 		<-tickets // get a ticket
 
 		// each task is handled in a separate goroutine
+		inflight.Add(1)
 		go func(task *provider.AsyncTask) {
+			defer inflight.Add(-1)
 			interceptingChannel := make(chan *provider.AsyncTask, 1)
 			interceptedChannel := task.Intercept(interceptingChannel)
 
-			retries := 10
+			// wrap the context to be able to cancel the task during shutdown
+			ctx, cancel := context.WithCancelCause(task.Context)
+			task.Context = ctx
+			cancels.Store(task, cancel)
+			if expired.Load() {
+				// shutdown timeout expired while waiting for a ticket
+				cancel(ErrShuttingDown)
+			}
+			defer func() {
+				cancels.Delete(task)
+				cancel(nil)
+			}()
+
 			var err error
-			errs := make([]error, 0, 10)
+			errs := make([]error, 0, retries)
 			for i := 1; i <= retries; i++ {
 
 				// when retrying, we sleep and need to reacquire a ticket
@@ -64,7 +141,11 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 				}
 
 				// schedule the task with a provider and release a ticket
-				task.Request.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerScheduleTask)
+				details := fmt.Sprintf("attempt=%d/%d", i, retries)
+				if len(errs) > 0 {
+					details += fmt.Sprintf(" retry=%q", errs[len(errs)-1].Error())
+				}
+				task.Request.GetInfo().TraceEventDetails(wasimoff.Task_TraceEvent_BrokerScheduleTask, details)
 				err = selector.Schedule(task.Context, task)
 				tickets <- struct{}{}
 
@@ -72,6 +153,7 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 				if err != nil {
 					// don't retry, if the context was cancelled
 					if errors.Is(err, context.Canceled) {
+						errs = append(errs, context.Cause(task.Context))
 						break
 					}
 					log.Printf("RETRY: scheduling %s failed (%d/%d): %s", task.Request.GetInfo().GetId(), i, retries, err)
@@ -85,6 +167,8 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 				if result.Error != nil {
 					// don't retry, if the context was cancelled
 					if errors.Is(result.Error, context.Canceled) {
+						err = result.Error
+						errs = append(errs, context.Cause(task.Context))
 						break
 					}
 					log.Printf("RETRY: task %s failed (%d/%d): %v", task.Request.GetInfo().GetId(), i, retries, result.Error)
@@ -101,7 +185,7 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 			// still erroneous after retries, give up
 			if err != nil {
 				task.Error = errors.Join(errs...)
-				task.Request.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_BrokerError)
+				task.Request.GetInfo().TraceEventDetails(wasimoff.Task_TraceEvent_BrokerError, task.Error.Error())
 			}
 			store.ObserveCompleted(task)
 			interceptedChannel <- task
@@ -110,6 +194,41 @@ func Dispatcher(store *provider.ProviderStore, selector Scheduler, concurrency i
 	}
 }
 
+// ShuttingDown returns true once a shutdown was started and no new tasks are accepted.
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+// Shutdown stops accepting new tasks and waits until all queued tasks were
+// dispatched and all in-flight tasks finished, until the context expires. Any
+// remaining tasks are then cancelled, which also sends a cancellation to their
+// Providers, and fail with ErrShuttingDown, like the tasks still in the queue.
+func Shutdown(ctx context.Context) {
+	shuttingDown.Store(true)
+
+	select {
+	case <-drained:
+		log.Println("Shutdown: all queued and in-flight tasks finished")
+		return
+	case <-ctx.Done():
+	}
+
+	// timeout reached, fail queued tasks and cancel all remaining tasks
+	expired.Store(true)
+	n := 0
+	cancels.Range(func(_, cancel any) bool {
+		cancel.(context.CancelCauseFunc)(ErrShuttingDown)
+		n++
+		return true
+	})
+	log.Printf("Shutdown: cancelled %d remaining tasks", n)
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		log.Println("Shutdown: some cancelled tasks did not return in time")
+	}
+}
+
 // exponentialDelay gives a duration between 10ms and 1s for i=1..9
 func exponentialDelay(i int) time.Duration {
 	// fn(i) = a*e^(i/b) with a,b such that fn(2..10) = 10..1000
@@ -141,6 +260,9 @@ func dynamicSubmit(
 		cases[i].Send = reflect.ValueOf(task)
 	}
 
+	// remember the number of candidates for the trace
+	task.Candidates = len(providers)
+
 	// set scheduling time on return
 	defer func() {
 		task.TimeScheduled = time.Now()
@@ -30,11 +30,11 @@ func (s *AnyFreeSelector) selectCandidates(_ *provider.AsyncTask) (candidates []
 	return s.store.Values(), nil
 }
 
-func (s *AnyFreeSelector) Schedule(ctx context.Context, task *provider.AsyncTask) (call *provider.AsyncTask, err error) {
+func (s *AnyFreeSelector) Schedule(ctx context.Context, task *provider.AsyncTask) (err error) {
 
 	providers, err := s.selectCandidates(task)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	err = dynamicSubmit(ctx, task, providers, nil)
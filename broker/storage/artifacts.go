@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"archive/zip"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Tasks can return their artifacts by ref instead of inline: the Broker inserts
+// the archive in storage like any other unnamed file and clients download it, or
+// single files from it, afterwards. The archive counts towards the storage quota
+// of the requester and is removed after the artifact TTL, which is tracked in
+// memory, so archives stored before a restart fall back to the regular TTL.
+
+// StoreArtifacts inserts the artifacts archive returned by a task into storage
+// and replaces its blob with the ref. The context is the one of the task request.
+func (fs *FileStorage) StoreArtifacts(ctx context.Context, pbf *wasimoff.File) error {
+	if pbf == nil || pbf.Blob == nil {
+		return nil
+	}
+	media := cmp.Or(pbf.GetMedia(), MediaZip)
+	file, created, err := fs.insert("", FileInfo{Media: media, Uploader: UploaderFrom(ctx)}, pbf.Blob)
+	if err != nil {
+		return err
+	}
+	if err := fs.charge(ctx, file.Ref(), int64(len(pbf.Blob)), created); err != nil {
+		return err
+	}
+	if created {
+		fs.index.markArtifact(file.Ref())
+	}
+	pbf.Ref, pbf.Media, pbf.Blob = proto.String(file.Ref()), proto.String(file.Media), nil
+	return nil
+}
+
+// StoreStdin stages a large stdin of a task in storage, so that it is transferred
+// to the Provider in chunks instead of inline, and returns its ref. The announced
+// size is checked against the quota before reading, like an upload.
+func (fs *FileStorage) StoreStdin(ctx context.Context, r io.Reader, announced int64) (string, error) {
+	meta := FileInfo{Media: MediaBlob, Uploader: UploaderFrom(ctx)}
+	reserved := int64(0)
+	if fs.Quota != nil {
+		reserved = max(announced, 1)
+		if err := fs.Quota(ctx, reserved); err != nil {
+			return "", err
+		}
+	}
+	info, created, err := fs.insertReader("", meta, r)
+	fs.release(meta.Uploader, reserved)
+	if err != nil {
+		return "", err
+	}
+	if err := fs.charge(ctx, info.Ref, info.Size, created); err != nil {
+		return "", err
+	}
+	if created {
+		fs.index.markArtifact(info.Ref)
+	}
+	return info.Ref, nil
+}
+
+// serveArchiveFile extracts a single file from a zip archive in storage, e.g. a
+// result from the artifacts of a task.
+func (fs *FileStorage) serveArchiveFile(w http.ResponseWriter, r *http.Request, filename, name string) {
+
+	// open the archive in storage
+	file, err := fs.LookupReader(NamespacesFrom(r.Context()), filename)
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "File not Found in storage", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "opening file in storage failed", http.StatusInternalServerError)
+		log.Printf("ERR: Serve [%s]: %s", r.RemoteAddr, err)
+		return
+	}
+	defer file.Close()
+	if file.Media != MediaZip && file.Media != MediaWheel {
+		http.Error(w, "file is not a zip archive", http.StatusBadRequest)
+		return
+	}
+	archive, err := zip.NewReader(readerAt(file), file.Size)
+	if err != nil {
+		http.Error(w, "reading archive failed", http.StatusInternalServerError)
+		log.Printf("ERR: Serve [%s]: %s", r.RemoteAddr, err)
+		return
+	}
+
+	// find the file by its cleaned path
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	for _, entry := range archive.File {
+		if strings.TrimPrefix(path.Clean("/"+entry.Name), "/") != name || entry.FileInfo().IsDir() {
+			continue
+		}
+		content, err := entry.Open()
+		if err != nil {
+			http.Error(w, "reading archive failed", http.StatusInternalServerError)
+			log.Printf("ERR: Serve [%s]: %s", r.RemoteAddr, err)
+			return
+		}
+		defer content.Close()
+
+		// entries are immutable within an archive, so its ref is used for caching
+		etag := fmt.Sprintf("%q", file.Ref+"/"+name)
+		w.Header().Set("content-type", cmp.Or(mime.TypeByExtension(path.Ext(name)), MediaBlob))
+		w.Header().Add("x-wasimoff-ref", file.Ref)
+		w.Header().Set("etag", etag)
+		cacheControl(w, filename)
+		w.Header().Add("access-control-allow-origin", "*")
+		if r.Header.Get("if-none-match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("content-length", strconv.FormatUint(entry.UncompressedSize64, 10))
+		if r.Method != http.MethodHead {
+			io.Copy(w, content)
+		}
+		return
+	}
+	http.Error(w, "File not Found in archive", http.StatusNotFound)
+
+}
+
+// readerAt adapts a file for random access, unless it supports it already. The
+// adapter seeks before every read and must not be used concurrently.
+func readerAt(r io.ReadSeeker) io.ReaderAt {
+	if ra, ok := r.(io.ReaderAt); ok {
+		return ra
+	}
+	return seekReaderAt{r}
+}
+
+type seekReaderAt struct {
+	io.ReadSeeker
+}
+
+func (s seekReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := s.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(s, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,289 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Chunked transfers split large files into sequential pieces, so that no single
+// message on a Messenger needs to hold an entire file. Partial uploads are staged
+// in temporary files and only inserted into the storage once they are complete
+// and their digest matches the announced content address. The announced size is
+// charged to the Quota of the uploader with the first chunk and returned if the
+// upload is discarded.
+
+const (
+	// ChunkSize is the default length of chunks in transfers.
+	ChunkSize = 1 << 20 // 1 MiB
+	// MaxChunkSize caps the length of a chunk requested in downloads.
+	MaxChunkSize = 8 << 20 // 8 MiB
+	// Partial uploads without activity for this duration are discarded.
+	uploadIdleTimeout = time.Hour
+)
+
+// ChunkDigest returns the sha256 digest of a chunk's data, encoded like a ref.
+func ChunkDigest(data []byte) string {
+	return sha256Ref(data)
+}
+
+// partialUpload is an incomplete chunked upload, staged in a temporary file.
+type partialUpload struct {
+	sync.Mutex
+	file     *os.File  // staging file in temporary directory
+	digest   hash.Hash // running digest over all received bytes
+	name     string
+	meta     FileInfo // media type, uploader and original name
+	size     uint64
+	received uint64
+	touched  time.Time
+	reserved int64 // quota charged for this upload, until it is inserted
+}
+
+// uploadKey identifies a partial upload. Uploads of the same content by different
+// uploaders or under different names are staged separately, so that each keeps its
+// own name and quota.
+type uploadKey struct {
+	ref, uploader, name string
+}
+
+// chunkedUploads holds all partial uploads, keyed by their announced ref and uploader.
+type chunkedUploads struct {
+	mutex   sync.Mutex
+	partial map[uploadKey]*partialUpload
+	release func(uploader string, size int64) // return the quota of discarded uploads
+}
+
+func newChunkedUploads(release func(uploader string, size int64)) *chunkedUploads {
+	u := &chunkedUploads{partial: make(map[uploadKey]*partialUpload), release: release}
+	go u.janitor(time.Minute)
+	return u
+}
+
+// open returns an existing partial upload for this key or starts a new one.
+func (u *chunkedUploads) open(key uploadKey, meta FileInfo, size uint64) (p *partialUpload, created bool, err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	// continue an existing upload, if the parameters match
+	if p, ok := u.partial[key]; ok {
+		if p.size != size || p.meta.Media != meta.Media {
+			return nil, false, fmt.Errorf("upload %s already in progress with different size or media type", key.ref)
+		}
+		return p, false, nil
+	}
+
+	// otherwise create a new staging file
+	file, err := os.CreateTemp("", "wasimoff-upload-*")
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot create staging file: %w", err)
+	}
+	p = &partialUpload{
+		file:    file,
+		digest:  sha256.New(),
+		name:    key.name,
+		meta:    meta,
+		size:    size,
+		touched: time.Now(),
+	}
+	u.partial[key] = p
+	return p, true, nil
+}
+
+// discard removes a partial upload and its staging file.
+func (u *chunkedUploads) discard(key uploadKey, p *partialUpload) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if u.partial[key] == p {
+		delete(u.partial, key)
+	}
+	u.remove(p)
+}
+
+// remove the staging file and return the quota of a partial upload.
+func (u *chunkedUploads) remove(p *partialUpload) {
+	p.file.Close()
+	os.Remove(p.file.Name())
+	if p.reserved > 0 {
+		u.release(p.meta.Uploader, p.reserved)
+		p.reserved = 0
+	}
+}
+
+// janitor regularly discards stale partial uploads.
+func (u *chunkedUploads) janitor(period time.Duration) {
+	for range time.Tick(period) {
+		u.mutex.Lock()
+		for key, p := range u.partial {
+			// skip uploads which are currently receiving a chunk
+			if !p.TryLock() {
+				continue
+			}
+			if time.Since(p.touched) > uploadIdleTimeout {
+				log.Printf("Storage: discarding stale partial upload %s (%d/%d bytes)", key.ref, p.received, p.size)
+				delete(u.partial, key)
+				u.remove(p)
+			}
+			p.Unlock()
+		}
+		u.mutex.Unlock()
+	}
+}
+
+// UploadChunk appends the next chunk of a file to a partial upload. Chunks must be
+// sent in order; duplicate or out-of-order chunks are skipped and the returned offset
+// tells the sender where to continue. Once all bytes are received, the digest is
+// verified and the file is inserted into the storage, with its name in the Write
+// namespace of the request context.
+func (fs *FileStorage) UploadChunk(ctx context.Context, req *wasimoff.Filesystem_Chunk_Upload_Request) (*wasimoff.Filesystem_Chunk_Upload_Response, error) {
+
+	// the announced ref identifies the transfer
+	ref := req.GetRef()
+	if !IsRef(ref) {
+		return nil, fmt.Errorf("chunked upload must be identified by a sha256 ref")
+	}
+	media, err := CheckMediaType(req.GetMedia())
+	if err != nil {
+		return nil, fmt.Errorf("media: %w", err)
+	}
+	if fs.MaxFileSize > 0 && req.GetSize() > uint64(fs.MaxFileSize) {
+		return nil, ErrTooLarge
+	}
+	name := req.GetName()
+	if !validName(name) {
+		return nil, ErrInvalidName
+	}
+	meta := FileInfo{Media: media, Uploader: UploaderFrom(ctx), Name: name}
+	if name != "" {
+		name = qualify(NamespacesFrom(ctx).Write, name)
+	}
+
+	// the file might be known already, then only the name needs to be added
+	if fs.index.has(ref) {
+		if name != "" {
+			if err := fs.link(name, ref); err != nil {
+				return nil, fmt.Errorf("inserting name failed: %w", err)
+			}
+		}
+		return &wasimoff.Filesystem_Chunk_Upload_Response{
+			Offset: proto.Uint64(req.GetSize()),
+			Ref:    proto.String(ref),
+		}, nil
+	}
+
+	key := uploadKey{ref: ref, uploader: meta.Uploader, name: name}
+	upload, created, err := fs.uploads.open(key, meta, req.GetSize())
+	if err != nil {
+		return nil, err
+	}
+	upload.Lock()
+	defer upload.Unlock()
+	upload.touched = time.Now()
+
+	// charge the announced size before anything is staged
+	if created && fs.Quota != nil {
+		if err := fs.Quota(ctx, int64(upload.size)); err != nil {
+			fs.uploads.discard(key, upload)
+			return nil, err
+		}
+		upload.reserved = int64(upload.size)
+	}
+
+	// append the data, if this is the next expected chunk
+	if data := req.GetData(); len(data) > 0 {
+		if req.GetDigest() != ChunkDigest(data) {
+			return nil, fmt.Errorf("chunk at offset %d: digest mismatch", req.GetOffset())
+		}
+		if req.GetOffset() == upload.received {
+			if upload.received+uint64(len(data)) > upload.size {
+				fs.uploads.discard(key, upload)
+				return nil, fmt.Errorf("chunk at offset %d: exceeds announced size %d", req.GetOffset(), upload.size)
+			}
+			if _, err := upload.file.Write(data); err != nil {
+				fs.uploads.discard(key, upload)
+				return nil, fmt.Errorf("writing chunk to staging file failed: %w", err)
+			}
+			upload.digest.Write(data)
+			upload.received += uint64(len(data))
+		}
+	}
+	response := &wasimoff.Filesystem_Chunk_Upload_Response{
+		Offset: proto.Uint64(upload.received),
+	}
+	if upload.received < upload.size {
+		return response, nil
+	}
+
+	// upload is complete, verify the digest before inserting
+	defer fs.uploads.discard(key, upload)
+	if digest := fmt.Sprintf("sha256:%x", upload.digest.Sum(nil)); digest != ref {
+		return nil, fmt.Errorf("upload complete but digest %s does not match ref", digest)
+	}
+	if _, err := upload.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewinding staging file failed: %w", err)
+	}
+	inserted, created, err := fs.insertReader(upload.name, upload.meta, upload.file)
+	if err != nil {
+		return nil, fmt.Errorf("inserting in storage failed: %w", err)
+	}
+	if created {
+		upload.reserved = 0 // keep the charge, it is released when the file is deleted
+	}
+	response.Ref = proto.String(inserted.Ref)
+	return response, nil
+
+}
+
+// DownloadChunk returns a single chunk of a file in storage.
+func (fs *FileStorage) DownloadChunk(ns Namespaces, req *wasimoff.Filesystem_Chunk_Download_Request) (*wasimoff.Filesystem_Chunk_Download_Response, error) {
+
+	file, err := fs.LookupReader(ns, req.GetFile())
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("file not found in storage")
+	} else if err != nil {
+		return nil, fmt.Errorf("opening file failed: %w", err)
+	}
+	defer file.Close()
+
+	// clamp the requested range to the file size
+	size := uint64(file.Size)
+	offset := req.GetOffset()
+	if offset > size {
+		return nil, fmt.Errorf("offset %d is beyond file size %d", offset, size)
+	}
+	length := uint64(req.GetLength())
+	if length == 0 {
+		length = ChunkSize
+	}
+	end := min(offset+min(length, MaxChunkSize), size)
+
+	// read only the requested range
+	data := make([]byte, end-offset)
+	if _, err := file.Seek(int64(offset), io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seeking in file failed: %w", err)
+	}
+	if _, err := io.ReadFull(file, data); err != nil {
+		return nil, fmt.Errorf("reading chunk failed: %w", err)
+	}
+
+	return &wasimoff.Filesystem_Chunk_Download_Response{
+		Ref:    proto.String(file.Ref),
+		Media:  proto.String(file.Media),
+		Size:   proto.Uint64(size),
+		Offset: proto.Uint64(offset),
+		Data:   data,
+		Digest: proto.String(ChunkDigest(data)),
+	}, nil
+
+}
@@ -1,12 +1,19 @@
 package storage
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"mime"
+	"net/http"
+	"os"
+	"path"
 	"regexp"
 	"slices"
+	"time"
 )
 
 // TODO: use a library to redetect media type from bytes
@@ -38,6 +45,64 @@ func (f *File) Ref() string {
 	return f.ref
 }
 
+// FileInfo is the metadata of a File, which is stored when it is uploaded first.
+type FileInfo struct {
+	Ref      string       `json:"ref"`
+	Media    string       `json:"media"`              // content-type
+	Size     int64        `json:"size"`               // total length in bytes
+	Uploaded time.Time    `json:"uploaded"`           // time of the first upload
+	Uploader string       `json:"uploader,omitempty"` // identity of the first uploader
+	Name     string       `json:"name,omitempty"`     // original name given by the first uploader
+	Wasm     *WasmInfo    `json:"wasm,omitempty"`     // inspected WebAssembly binary
+	Zip      *ArchiveInfo `json:"zip,omitempty"`      // inspected zip archive or wheel
+	Tar      *ArchiveInfo `json:"tar,omitempty"`      // inspected tar archive, maybe compressed
+}
+
+// newFileInfo fills in the metadata of a newly uploaded File.
+func newFileInfo(meta FileInfo, ref string, size int64) *FileInfo {
+	meta.Ref, meta.Size, meta.Uploaded = ref, size, time.Now().UTC()
+	return &meta
+}
+
+// FileReader is an open File in the storage, which is read on demand instead of
+// holding its contents in memory. It must be closed after use.
+type FileReader struct {
+	io.ReadSeekCloser
+	*FileInfo
+}
+
+// Open returns a FileReader over the contents of an in-memory File.
+func (f *File) Open(info *FileInfo) *FileReader {
+	if info == nil {
+		info = &FileInfo{Ref: f.Ref(), Media: f.Media, Size: int64(len(f.Bytes))}
+	}
+	return &FileReader{nopCloser{bytes.NewReader(f.Bytes)}, info}
+}
+
+type nopCloser struct{ io.ReadSeeker }
+
+func (nopCloser) Close() error { return nil }
+
+// spool copies a stream into a temporary file while hashing it, so it can be
+// stored under its content address afterwards. The caller must remove the file.
+func spool(r io.Reader) (file *os.File, ref string, size int64, err error) {
+	file, err = os.CreateTemp("", "wasimoff-upload-*")
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("cannot create staging file: %w", err)
+	}
+	digest := sha256.New()
+	size, err = io.Copy(io.MultiWriter(file, digest), r)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, "", 0, err
+	}
+	return file, fmt.Sprintf("sha256:%x", digest.Sum(nil)), size, nil
+}
+
 // sha256Ref takes file's bytes, calculates a SHA256 digest
 // and returns a string encoding with hash prefix (sha256:<hex>).
 func sha256Ref(bytes []byte) string {
@@ -52,9 +117,66 @@ func IsRef(ref string) bool {
 	return reSha256Addr.MatchString(ref)
 }
 
+// Media types of the files, which can be stored.
+const (
+	MediaWasm  = "application/wasm"
+	MediaZip   = "application/zip"
+	MediaTar   = "application/x-tar"
+	MediaGzip  = "application/gzip"        // usually a compressed tar archive
+	MediaWheel = "application/x-wheel+zip" // Python wheel, which is a zip archive
+	MediaBlob  = "application/octet-stream"
+	MediaText  = "text/plain"
+)
+
 var expectedMediaTypes = []string{
-	"application/wasm",
-	"application/zip",
+	MediaWasm,
+	MediaZip,
+	MediaTar,
+	MediaGzip,
+	MediaWheel,
+	MediaBlob,
+	MediaText,
+}
+
+// mediaAliases are alternative names of the expected media types.
+var mediaAliases = map[string]string{
+	"application/x-gzip": MediaGzip,
+	"application/tar":    MediaTar,
+}
+
+// DetectMediaType peeks at the first bytes of a stream to detect its media type
+// and checks if it's one of the expected types. The returned reader must be used
+// in place of r afterwards.
+func DetectMediaType(r io.Reader) (string, io.Reader, error) {
+	buffered := bufio.NewReaderSize(r, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF {
+		return "", buffered, err
+	}
+	mt, err := CheckMediaType(sniff(head))
+	return mt, buffered, err
+}
+
+// sniff detects the media type of the first bytes of a file. Tar archives are not
+// recognized by http.DetectContentType, so their magic is checked first.
+func sniff(head []byte) string {
+	if isTar(head) {
+		return MediaTar
+	}
+	return http.DetectContentType(head)
+}
+
+// isTar checks for the magic of POSIX and GNU tar headers.
+func isTar(head []byte) bool {
+	return len(head) >= 262 && string(head[257:262]) == "ustar"
+}
+
+// wheelMedia tells Python wheels apart from other zip archives by their filename.
+func wheelMedia(media, name string) string {
+	if media == MediaZip && path.Ext(name) == ".whl" {
+		return MediaWheel
+	}
+	return media
 }
 
 // CheckMediaType tries to parse the given media type, ignoring optional
@@ -67,6 +189,9 @@ func CheckMediaType(media string) (string, error) {
 	if err != nil {
 		return mt, fmt.Errorf("failed parsing: %w", err)
 	}
+	if alias, ok := mediaAliases[mt]; ok {
+		mt = alias
+	}
 	if !slices.Contains(expectedMediaTypes, mt) {
 		err = fmt.Errorf("unexpected media type: %s", mt)
 	}
@@ -1,27 +1,234 @@
 package storage
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"log"
+	"maps"
+	"os"
+	"slices"
 
 	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
 type AbstractFileStorage interface {
-	Insert(name, media string, blob []byte) (file *File, err error)
+	Insert(name string, meta FileInfo, blob []byte) (file *File, err error)
+	InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error)
 	Get(nameOrRef string) *File
+	Open(nameOrRef string) (*FileReader, error)
+	Stat(nameOrRef string) (*FileInfo, error)
 	All() iter.Seq2[string, *File]
+	Delete(ref string) error
+	Link(name, ref string) error
+	Unlink(name string) error
+	Names() iter.Seq2[string, string]
+}
+
+// Presigner is implemented by backends, which can hand out temporary URLs to
+// download files directly instead of proxying them through the Broker.
+type Presigner interface {
+	Presign(nameOrRef string) (ref, location string, err error)
 }
 
 type FileStorage struct {
 	AbstractFileStorage
+
+	// partial chunked uploads, which are not inserted yet
+	uploads *chunkedUploads
+
+	// Quota is called for new files and can reject them, e.g. to enforce per-client
+	// storage quotas. The context is the one of the upload request. Release is called
+	// with the uploader and size of deleted files to return their share again.
+	Quota   func(ctx context.Context, size int64) error
+	Release func(uploader string, size int64)
+
+	// MaxFileSize limits the size of single files, zero is unlimited.
+	MaxFileSize int64
+
+	// MaxUnpackedSize limits the total uncompressed size of archives, zero is unlimited.
+	MaxUnpackedSize int64
+
+	// default maximum total size for backends, which must always be bounded
+	budget int64
+
+	// RemoteHosts is the allowlist of hosts to fetch URL refs from, empty disables
+	// fetching. RemoteMaxSize limits the size of fetched files, zero is unlimited.
+	RemoteHosts   []string
+	RemoteMaxSize int64
+
+	// fetched URL refs
+	remote *refCache
+
+	// tar archives converted to zip for use as a rootfs
+	converted *refCache
+
+	// index of sizes, timestamps and references for garbage collection
+	index *fileIndex
+
+	// redirect downloads to presigned URLs, if the backend supports it
+	presigner Presigner
+
+	// OnRemove is called with the refs of deleted files, e.g. to tell Providers
+	// to evict their cached copies.
+	OnRemove func(refs []string)
+}
+
+// charge accounts a newly inserted file to the Quota of the uploader in the context.
+// Files which were in storage already are not counted again. If the file does not
+// fit, it is discarded again and the error of the Quota is returned.
+func (fs *FileStorage) charge(ctx context.Context, ref string, size int64, created bool) error {
+	if !created || fs.Quota == nil {
+		return nil
+	}
+	qerr := fs.Quota(ctx, size)
+	if qerr == nil {
+		return nil
+	}
+	fs.index.mutex.Lock()
+	fs.index.drop(ref)
+	fs.index.mutex.Unlock()
+	if err := fs.AbstractFileStorage.Delete(ref); err != nil && !errors.Is(err, ErrNotFound) {
+		log.Printf("ERR: Storage: discarding %s above quota: %s", ref, err)
+	}
+	return qerr
+}
+
+// release returns the share of a deleted file to the Quota of its uploader.
+func (fs *FileStorage) release(uploader string, size int64) {
+	if fs.Release != nil && uploader != "" && size > 0 {
+		fs.Release(uploader, size)
+	}
+}
+
+// Usage sums the sizes of all stored files per uploader, e.g. to restore the
+// storage quotas after a restart. Files are charged to their first uploader.
+func (fs *FileStorage) Usage() map[string]int64 {
+	fs.index.mutex.Lock()
+	refs := slices.Collect(maps.Keys(fs.index.files))
+	fs.index.mutex.Unlock()
+	usage := make(map[string]int64)
+	for _, ref := range refs {
+		info, err := fs.AbstractFileStorage.Stat(ref)
+		if err != nil {
+			if !errors.Is(err, ErrNotFound) {
+				log.Printf("ERR: Storage: usage of %s: %s", ref, err)
+			}
+			continue
+		}
+		if info.Uploader != "" {
+			usage[info.Uploader] += info.Size
+		}
+	}
+	return usage
+}
+
+// newFileStorage wraps a concrete storage backend with the common helpers.
+func newFileStorage(backend AbstractFileStorage) *FileStorage {
+	fs := &FileStorage{
+		AbstractFileStorage: backend,
+		index:               newFileIndex(backend),
+		remote:              newRefCache(),
+		converted:           newRefCache(),
+	}
+	fs.uploads = newChunkedUploads(fs.release)
+	go fs.janitor(collectPeriod)
+	return fs
+}
+
+// ErrTooLarge is returned when a file exceeds the MaxFileSize.
+var ErrTooLarge = errors.New("file exceeds the maximum size")
+
+// InsertReader streams a File into the backend and records it in the index. The
+// stream is aborted with ErrTooLarge if it exceeds the MaxFileSize.
+func (fs *FileStorage) InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error) {
+	info, _, err := fs.insertReader(name, meta, r)
+	return info, err
+}
+
+// link adds a name to a File in the storage without reading its contents.
+func (fs *FileStorage) link(name, ref string) error {
+	if err := fs.AbstractFileStorage.Link(name, ref); err != nil {
+		return err
+	}
+	if !fs.index.link(ref, name) {
+		return ErrNotFound // collected meanwhile
+	}
+	return nil
+}
+
+// insertReader also returns whether the File was not in the storage before. The
+// stream is staged in a temporary file to inspect it first, unless it is a staged
+// file already.
+func (fs *FileStorage) insertReader(name string, meta FileInfo, r io.Reader) (info *FileInfo, created bool, err error) {
+	if meta.Media, err = CheckMediaType(meta.Media); err != nil {
+		return nil, false, fmt.Errorf("media: %w", err)
+	}
+	meta.Media = wheelMedia(meta.Media, meta.Name)
+	staged, ok := r.(*os.File)
+	if !ok {
+		if fs.MaxFileSize > 0 {
+			r = &limitedReader{r, fs.MaxFileSize}
+		}
+		if staged, _, _, err = spool(r); err != nil {
+			return nil, false, err
+		}
+		defer os.Remove(staged.Name())
+		defer staged.Close()
+	}
+	stat, err := staged.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	if err = fs.inspect(&meta, staged, stat.Size()); err != nil {
+		return nil, false, err
+	}
+	if _, err = staged.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	info, err = fs.AbstractFileStorage.InsertReader(name, meta, staged)
+	if err != nil {
+		return nil, false, err
+	}
+	created = fs.index.insert(info.Ref, name, info.Size)
+	fs.collect(info.Ref)
+	return info, created, nil
+}
+
+// Open a File for reading by Ref or name and update its last-access time.
+func (fs *FileStorage) Open(nameOrRef string) (*FileReader, error) {
+	file, err := fs.AbstractFileStorage.Open(nameOrRef)
+	if err != nil {
+		return nil, err
+	}
+	fs.index.touch(file.Ref)
+	return file, nil
+}
+
+// limitedReader fails with ErrTooLarge when more than n bytes are read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return 0, ErrTooLarge
+	}
+	return n, err
 }
 
 // ResolvePbFile checks if this file is usable as an argument in offloading
-// requests, i.e. if it either contains a blob or is a known file in the
-// storage. If so, set the resolved Ref on the file.
-func (fs *FileStorage) ResolvePbFile(pbf *wasimoff.File) error {
+// requests, i.e. if it either contains a blob, is a known file in the storage
+// or a URL which can be fetched. If so, set the resolved Ref on the file. Names
+// are resolved in the given Namespaces and fetches are charged to the Client in ctx.
+func (fs *FileStorage) ResolvePbFile(ctx context.Context, ns Namespaces, pbf *wasimoff.File) error {
 
 	// argument is nil, no need to do anything
 	if pbf == nil {
@@ -49,24 +256,95 @@ func (fs *FileStorage) ResolvePbFile(pbf *wasimoff.File) error {
 		return nil
 	}
 
-	// Ref is given, look it up in Storage
-	if file := fs.Get(*pbf.Ref); file != nil {
-		pbf.Media = &file.Media
-		pbf.Ref = &file.ref
+	// Ref is a URL, fetch it into Storage once
+	if IsRemote(*pbf.Ref) {
+		info, err := fs.Fetch(ctx, *pbf.Ref)
+		if err != nil {
+			return fmt.Errorf("fetching Ref failed: %w", err)
+		}
+		pbf.Media = proto.String(info.Media)
+		pbf.Ref = proto.String(info.Ref)
 		return nil
 	}
 
-	// couldn't resolve the file
-	return fmt.Errorf("Ref not found in storage")
+	// Ref is given, look it up in Storage
+	info, err := fs.LookupStat(ns, *pbf.Ref)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("Ref not found in storage")
+	} else if err != nil {
+		return fmt.Errorf("looking up Ref failed: %w", err)
+	}
+	pbf.Media = proto.String(info.Media)
+	pbf.Ref = proto.String(info.Ref)
+	return nil
 
 }
 
-func (fs *FileStorage) ResolveTaskFiles(request *wasimoff.Task_Wasip1_Request) error {
+// checkBinary makes sure that a resolved task binary is a WebAssembly module. The
+// media type of inline blobs is optional, so they are sniffed instead.
+func checkBinary(pbf *wasimoff.File) error {
+	if pbf == nil {
+		return nil
+	}
+	media := pbf.GetMedia()
+	if media == "" && pbf.Blob != nil {
+		media = sniff(pbf.Blob)
+	}
+	if media != MediaWasm {
+		return fmt.Errorf("binary must be %s, not %s", MediaWasm, cmp.Or(media, "unknown"))
+	}
+	return nil
+}
+
+// ResolveTaskFiles resolves all files of a Wasip1 or Pyodide task and pins them in
+// storage, so they are not collected while the task is queued or running. A tar
+// rootfs or layer is converted to a zip archive. Call release afterwards.
+func (fs *FileStorage) ResolveTaskFiles(ctx context.Context, ns Namespaces, request proto.Message) (release func(), err error) {
+	var binary, rootfs, stdin *wasimoff.File
+	var layers []*wasimoff.Task_Layer
+	switch r := request.(type) {
+	case *wasimoff.Task_Wasip1_Request:
+		binary, rootfs = r.GetParams().GetBinary(), r.GetParams().GetRootfs()
+		stdin, layers = r.GetParams().GetStdinFile(), r.GetParams().GetLayers()
+	case *wasimoff.Task_Pyodide_Request:
+		rootfs = r.GetParams().GetRootfs()
+	default:
+		return nil, fmt.Errorf("unknown task type %T", request)
+	}
 	// collect errors for all tried files
 	errs := []error{}
-	p := request.GetParams()
-	errs = append(errs, fs.ResolvePbFile(p.Binary))
-	errs = append(errs, fs.ResolvePbFile(p.Rootfs))
-	// will be nil if there are no errs
-	return errors.Join(errs...)
+	errs = append(errs, fs.ResolvePbFile(ctx, ns, binary))
+	errs = append(errs, fs.ResolvePbFile(ctx, ns, rootfs))
+	if err := fs.ResolvePbFile(ctx, ns, stdin); err != nil {
+		errs = append(errs, fmt.Errorf("stdin: %w", err))
+	}
+	for i, layer := range layers {
+		if err := checkLayer(layer); err != nil {
+			errs = append(errs, fmt.Errorf("layer %d: %w", i, err))
+		} else if err := fs.ResolvePbFile(ctx, ns, layer.Archive); err != nil {
+			errs = append(errs, fmt.Errorf("layer %d: %w", i, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := checkBinary(binary); err != nil {
+		return nil, err
+	}
+	if err := fs.NormalizeRootfs(rootfs); err != nil {
+		return nil, err
+	}
+	refs := []string{binary.GetRef(), rootfs.GetRef(), stdin.GetRef()}
+	for i, layer := range layers {
+		if err := fs.NormalizeRootfs(layer.Archive); err != nil {
+			return nil, fmt.Errorf("layer %d: %w", i, err)
+		}
+		refs = append(refs, layer.Archive.GetRef())
+	}
+	// the files might have been collected since they were resolved
+	release, err = fs.index.pin(refs...)
+	if err != nil {
+		return nil, fmt.Errorf("Ref not found in storage")
+	}
+	return release, nil
 }
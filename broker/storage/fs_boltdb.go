@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
 	"log"
+	"slices"
 	"time"
 
 	bolt "go.etcd.io/bbolt"
@@ -17,6 +20,7 @@ type BoltFileStorage struct {
 var (
 	fileBucket      = []byte("files")
 	mediaTypeBucket = []byte("mediatypes")
+	metadataBucket  = []byte("metadata")
 	lookupBucket    = []byte("lookup")
 )
 
@@ -37,6 +41,9 @@ func NewBoltFileStorage(path string) *FileStorage {
 		if _, e := tx.CreateBucketIfNotExists(mediaTypeBucket); e != nil {
 			errors.Join(err, e)
 		}
+		if _, e := tx.CreateBucketIfNotExists(metadataBucket); e != nil {
+			errors.Join(err, e)
+		}
 		if _, e := tx.CreateBucketIfNotExists(lookupBucket); e != nil {
 			errors.Join(err, e)
 		}
@@ -46,31 +53,32 @@ func NewBoltFileStorage(path string) *FileStorage {
 		log.Fatalf("boltfs: cannot create buckets: %s", err)
 	}
 
-	return &FileStorage{
-		AbstractFileStorage: &BoltFileStorage{db},
-	}
+	return newFileStorage(&BoltFileStorage{db})
 }
 
 // Insert a new file into the Storage. The optional `name` will be inserted
 // into the lookup table and can be used to resolve the file later.
-func (fs *BoltFileStorage) Insert(name, media string, blob []byte) (file *File, err error) {
+func (fs *BoltFileStorage) Insert(name string, meta FileInfo, blob []byte) (file *File, err error) {
 
 	// check the media type first because that's cheapest
-	media, err = CheckMediaType(media)
+	meta.Media, err = CheckMediaType(meta.Media)
 	if err != nil {
 		return nil, fmt.Errorf("media: %w", err)
 	}
 
 	// use a *File struct to obtain the content hash
-	file = NewFile(media, blob)
+	file = NewFile(meta.Media, blob)
 	ref := file.Ref()
 
 	err = fs.db.Update(func(tx *bolt.Tx) error {
-		// insert blob and mediatype into buckets
+		// insert blob, mediatype and metadata into buckets
 		if err := tx.Bucket(fileBucket).Put([]byte(ref), blob); err != nil {
 			return err
 		}
-		if err := tx.Bucket(mediaTypeBucket).Put([]byte(ref), []byte(media)); err != nil {
+		if err := tx.Bucket(mediaTypeBucket).Put([]byte(ref), []byte(meta.Media)); err != nil {
+			return err
+		}
+		if err := putInfo(tx.Bucket(metadataBucket), newFileInfo(meta, ref, int64(len(blob)))); err != nil {
 			return err
 		}
 		// insert name in lookup, if given
@@ -118,6 +126,92 @@ func (fs *BoltFileStorage) get(ref string) (f *File) {
 	return
 }
 
+// InsertReader reads a stream into memory and inserts it like Insert, because
+// values in boltdb are written as a whole anyway.
+func (fs *BoltFileStorage) InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error) {
+	blob, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	file, err := fs.Insert(name, meta, blob)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(file.Ref())
+}
+
+// Open a File for reading, either by Ref or a friendly name in lookup bucket.
+func (fs *BoltFileStorage) Open(nameOrRef string) (*FileReader, error) {
+	file := fs.Get(nameOrRef)
+	if file == nil {
+		return nil, ErrNotFound
+	}
+	info, err := fs.Stat(file.Ref())
+	if err != nil {
+		return nil, err
+	}
+	return file.Open(info), nil
+}
+
+// Stat returns the metadata of a File, either by Ref or a friendly name in lookup
+// bucket. Files from before metadata was stored only have their media type and size.
+func (fs *BoltFileStorage) Stat(nameOrRef string) (info *FileInfo, err error) {
+	err = fs.db.View(func(tx *bolt.Tx) error {
+		ref := []byte(nameOrRef)
+		if tx.Bucket(fileBucket).Get(ref) == nil {
+			ref = tx.Bucket(lookupBucket).Get(ref)
+		}
+		value := tx.Bucket(fileBucket).Get(ref)
+		if value == nil {
+			return ErrNotFound
+		}
+		if info = getInfo(tx.Bucket(metadataBucket), string(ref)); info == nil {
+			media := tx.Bucket(mediaTypeBucket).Get(ref)
+			info = &FileInfo{Ref: string(ref), Media: string(media), Size: int64(len(value))}
+		}
+		return nil
+	})
+	return
+}
+
+// Delete a File by its Ref, including all names in the lookup bucket pointing to it.
+func (fs *BoltFileStorage) Delete(ref string) error {
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(fileBucket).Delete([]byte(ref)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(mediaTypeBucket).Delete([]byte(ref)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(metadataBucket).Delete([]byte(ref)); err != nil {
+			return err
+		}
+		return unlinkRef(tx.Bucket(lookupBucket), ref)
+	})
+}
+
+// Link inserts a name for a File, which is in the Storage already.
+func (fs *BoltFileStorage) Link(name, ref string) error {
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(fileBucket).Get([]byte(ref)) == nil {
+			return ErrNotFound
+		}
+		return tx.Bucket(lookupBucket).Put([]byte(name), []byte(ref))
+	})
+}
+
+// Unlink removes a friendly name from the lookup bucket but keeps the File.
+func (fs *BoltFileStorage) Unlink(name string) error {
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lookupBucket).Delete([]byte(name))
+	})
+}
+
+// Iterator over all names in the lookup bucket and the Refs they point to.
+func (fs *BoltFileStorage) Names() iter.Seq2[string, string] {
+	return boltNames(fs.db)
+}
+
 // Iterator over all Files in the storage.
 func (fs *BoltFileStorage) All() iter.Seq2[string, *File] {
 	return func(yield func(string, *File) bool) {
@@ -136,3 +230,74 @@ func (fs *BoltFileStorage) All() iter.Seq2[string, *File] {
 		})
 	}
 }
+
+// Sizes iterates over the sizes of all Files without decoding them.
+func (fs *BoltFileStorage) Sizes() iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		fs.db.View(func(tx *bolt.Tx) error {
+			return tx.Bucket(fileBucket).ForEach(func(k, v []byte) error {
+				if !yield(string(k), int64(len(v))) {
+					return errors.New("end iteration")
+				}
+				return nil
+			})
+		})
+	}
+}
+
+// unlinkRef deletes all names pointing to ref from a lookup bucket. The keys are
+// collected first because a bucket must not be modified while iterating.
+func unlinkRef(lookup *bolt.Bucket, ref string) error {
+	var names [][]byte
+	lookup.ForEach(func(k, v []byte) error {
+		if string(v) == ref {
+			names = append(names, slices.Clone(k))
+		}
+		return nil
+	})
+	for _, name := range names {
+		if err := lookup.Delete(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boltNames iterates over all names in the lookup bucket of a boltdb file.
+func boltNames(db *bolt.DB) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		db.View(func(tx *bolt.Tx) error {
+			return tx.Bucket(lookupBucket).ForEach(func(k, v []byte) error {
+				if !yield(string(k), string(v)) {
+					return errors.New("end iteration")
+				}
+				return nil
+			})
+		})
+	}
+}
+
+// putInfo stores the metadata of a File in a bucket, unless it exists already.
+func putInfo(bucket *bolt.Bucket, info *FileInfo) error {
+	if bucket.Get([]byte(info.Ref)) != nil {
+		return nil
+	}
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(info.Ref), value)
+}
+
+// getInfo reads the metadata of a File from a bucket or returns nil.
+func getInfo(bucket *bolt.Bucket, ref string) *FileInfo {
+	value := bucket.Get([]byte(ref))
+	if value == nil {
+		return nil
+	}
+	info := &FileInfo{}
+	if err := json.Unmarshal(value, info); err != nil {
+		return nil
+	}
+	return info
+}
@@ -1,11 +1,14 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"iter"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
@@ -32,13 +35,16 @@ func NewDirectoryFileStorage(basedir string) *FileStorage {
 		log.Fatalf("dirfs: cannot open lookup db: %s", err)
 	}
 
-	// ensure that lookup bucket exists
+	// ensure that lookup and metadata buckets exist
 	err = db.Update(func(tx *bolt.Tx) (err error) {
-		_, err = tx.CreateBucketIfNotExists(lookupBucket)
+		if _, err = tx.CreateBucketIfNotExists(lookupBucket); err != nil {
+			return
+		}
+		_, err = tx.CreateBucketIfNotExists(metadataBucket)
 		return
 	})
 	if err != nil {
-		log.Fatalf("dirfs: cannot create bucket: %s", err)
+		log.Fatalf("dirfs: cannot create buckets: %s", err)
 	}
 
 	// open the diskv storage
@@ -52,23 +58,21 @@ func NewDirectoryFileStorage(basedir string) *FileStorage {
 		},
 	})
 
-	return &FileStorage{
-		AbstractFileStorage: &DirectoryFileStorage{db, kv},
-	}
+	return newFileStorage(&DirectoryFileStorage{db, kv})
 }
 
 // Insert a new file into the Storage. The optional `name` will be inserted
 // into the lookup table and can be used to resolve the file later.
-func (fs *DirectoryFileStorage) Insert(name, media string, blob []byte) (file *File, err error) {
+func (fs *DirectoryFileStorage) Insert(name string, meta FileInfo, blob []byte) (file *File, err error) {
 
 	// check the media type first because that's cheapest
-	media, err = CheckMediaType(media)
+	meta.Media, err = CheckMediaType(meta.Media)
 	if err != nil {
 		return nil, fmt.Errorf("media: %w", err)
 	}
 
 	// use a *File struct to obtain the content hash
-	file = NewFile(media, blob)
+	file = NewFile(meta.Media, blob)
 	ref := file.Ref()
 
 	// store the file on disk
@@ -76,13 +80,8 @@ func (fs *DirectoryFileStorage) Insert(name, media string, blob []byte) (file *F
 		return nil, fmt.Errorf("failed to write blob: %w", err)
 	}
 
-	// insert lookup name in boltdb bucket
-	if name != "" {
-		err = fs.db.Update(func(tx *bolt.Tx) error {
-			return tx.Bucket(lookupBucket).Put([]byte(name), []byte(ref))
-		})
-	}
-
+	// insert metadata and lookup name in boltdb buckets
+	err = fs.link(name, newFileInfo(meta, ref, int64(len(blob))))
 	return file, err
 
 }
@@ -111,8 +110,11 @@ func (fs *DirectoryFileStorage) get(ref string) (f *File) {
 		return nil // no such file?
 	}
 
-	// parse the mediatype
+	// use the stored mediatype or parse it
 	media := mimetype.Detect(blob).String()
+	if info := fs.metadata(ref); info != nil {
+		media = info.Media
+	}
 
 	// return as *File
 	// TODO: do we need to copy the blob slice?
@@ -120,11 +122,182 @@ func (fs *DirectoryFileStorage) get(ref string) (f *File) {
 
 }
 
+// InsertReader streams a new file into a staging file while hashing it and moves
+// it into the Storage afterwards. The optional `name` is inserted like in Insert.
+func (fs *DirectoryFileStorage) InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error) {
+
+	// check the media type first because that's cheapest
+	media, err := CheckMediaType(meta.Media)
+	if err != nil {
+		return nil, fmt.Errorf("media: %w", err)
+	}
+	meta.Media = media
+
+	// stage the blob to obtain the content hash
+	staged, ref, size, err := spool(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage blob: %w", err)
+	}
+	staged.Close()
+	defer os.Remove(staged.Name())
+
+	// move the file in place on disk
+	if err = fs.kv.Import(staged.Name(), ref, true); err != nil {
+		return nil, fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	// insert metadata and lookup name in boltdb buckets
+	if err = fs.link(name, newFileInfo(meta, ref, size)); err != nil {
+		return nil, err
+	}
+	return fs.Stat(ref)
+
+}
+
+// link stores the metadata of a new File and inserts the optional lookup name.
+func (fs *DirectoryFileStorage) link(name string, info *FileInfo) error {
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		if err := putInfo(tx.Bucket(metadataBucket), info); err != nil {
+			return err
+		}
+		if name != "" {
+			return tx.Bucket(lookupBucket).Put([]byte(name), []byte(info.Ref))
+		}
+		return nil
+	})
+}
+
+// metadata returns the stored metadata of a File or nil.
+func (fs *DirectoryFileStorage) metadata(ref string) (info *FileInfo) {
+	fs.db.View(func(tx *bolt.Tx) error {
+		info = getInfo(tx.Bucket(metadataBucket), ref)
+		return nil
+	})
+	return
+}
+
+// resolve returns the ref of a friendly name or the ref itself.
+func (fs *DirectoryFileStorage) resolve(nameOrRef string) string {
+	ref := nameOrRef
+	if !fs.kv.Has(ref) {
+		fs.db.View(func(tx *bolt.Tx) error {
+			ref = string(tx.Bucket(lookupBucket).Get([]byte(nameOrRef)))
+			return nil
+		})
+	}
+	if !IsRef(ref) {
+		return ""
+	}
+	return ref
+}
+
+// Open a File for reading, either by Ref or a friendly name in lookup db.
+func (fs *DirectoryFileStorage) Open(nameOrRef string) (*FileReader, error) {
+
+	// try to lookup a friendly name
+	ref := fs.resolve(nameOrRef)
+	if ref == "" {
+		return nil, ErrNotFound
+	}
+
+	// open the file on disk
+	file, err := os.Open(filepath.Join(fs.kv.BasePath, "blob", ref))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	info, err := fs.stat(ref, file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileReader{ReadSeekCloser: file, FileInfo: info}, nil
+
+}
+
+// Stat returns the metadata of a File, either by Ref or a friendly name in lookup db.
+func (fs *DirectoryFileStorage) Stat(nameOrRef string) (*FileInfo, error) {
+	ref := fs.resolve(nameOrRef)
+	if ref == "" {
+		return nil, ErrNotFound
+	}
+	file, err := os.Open(filepath.Join(fs.kv.BasePath, "blob", ref))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return fs.stat(ref, file)
+}
+
+// stat returns the stored metadata of an opened File. Files from before metadata
+// was stored get their mediatype parsed from the first bytes and the modification
+// time on disk as upload time. The file is rewound afterwards.
+func (fs *DirectoryFileStorage) stat(ref string, file *os.File) (*FileInfo, error) {
+	if info := fs.metadata(ref); info != nil {
+		return info, nil
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	media, err := mimetype.DetectReader(file)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{Ref: ref, Media: media.String(), Size: stat.Size(), Uploaded: stat.ModTime().UTC()}, nil
+}
+
+// Delete a File by its Ref, including its metadata and all names in the lookup
+// db pointing to it.
+func (fs *DirectoryFileStorage) Delete(ref string) error {
+	if err := fs.kv.Erase(ref); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to erase blob: %w", err)
+	}
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(metadataBucket).Delete([]byte(ref)); err != nil {
+			return err
+		}
+		return unlinkRef(tx.Bucket(lookupBucket), ref)
+	})
+}
+
+// Link inserts a name for a File, which is in the Storage already.
+func (fs *DirectoryFileStorage) Link(name, ref string) error {
+	if !fs.kv.Has(ref) {
+		return ErrNotFound
+	}
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lookupBucket).Put([]byte(name), []byte(ref))
+	})
+}
+
+// Unlink removes a friendly name from the lookup db but keeps the File.
+func (fs *DirectoryFileStorage) Unlink(name string) error {
+	return fs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lookupBucket).Delete([]byte(name))
+	})
+}
+
+// Iterator over all names in the lookup db and the Refs they point to.
+func (fs *DirectoryFileStorage) Names() iter.Seq2[string, string] {
+	return boltNames(fs.db)
+}
+
 // Iterator over all Files in the storage.
 func (fs *DirectoryFileStorage) All() iter.Seq2[string, *File] {
 	return func(yield func(string, *File) bool) {
 		cancel := make(chan struct{})
 		for key := range fs.kv.Keys(cancel) {
+			// the walk also finds the lookup db in the basedir
+			if !IsRef(key) {
+				continue
+			}
 			file := fs.get(key)
 			if file == nil {
 				panic("dirfs: got a nil *File while iterating in All()")
@@ -136,3 +309,25 @@ func (fs *DirectoryFileStorage) All() iter.Seq2[string, *File] {
 		}
 	}
 }
+
+// Sizes iterates over the sizes of all Files on disk without reading them.
+// Files which cannot be accessed are skipped.
+func (fs *DirectoryFileStorage) Sizes() iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		cancel := make(chan struct{})
+		for key := range fs.kv.Keys(cancel) {
+			if !IsRef(key) {
+				continue
+			}
+			stat, err := os.Stat(filepath.Join(fs.kv.BasePath, "blob", key))
+			if err != nil {
+				log.Printf("dirfs: skipping %s: %s", key, err)
+				continue
+			}
+			if !yield(key, stat.Size()) {
+				close(cancel)
+				return
+			}
+		}
+	}
+}
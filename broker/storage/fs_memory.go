@@ -2,82 +2,188 @@ package storage
 
 import (
 	"fmt"
+	"io"
 	"iter"
-	"log"
+	"log/slog"
+	"maps"
+	"sync"
 )
 
-// TODO: this storage is probably not threadsafe
+// DefaultMemoryBudget bounds the total size of a MemoryFileStorage, unless a
+// maximum size is configured explicitly, because files would otherwise pile up
+// in RAM until the Broker is killed.
+const DefaultMemoryBudget = 1 << 30 // 1 GiB
 
+// MemoryFileStorage keeps all files in RAM, so they are lost on restart. It is
+// safe for concurrent use and bounded by the retention limits of the FileStorage.
 type MemoryFileStorage struct {
+	mutex sync.RWMutex
 	// collection of files in storage, keyed by content address
 	files map[string]*File
+	// metadata of the files, keyed by content address
+	infos map[string]*FileInfo
 	// a lookup table of plain names to content addresses
 	lookup map[string]string
 }
 
 func NewMemoryFileStorage() *FileStorage {
-	return &FileStorage{
-		AbstractFileStorage: &MemoryFileStorage{
-			files:  make(map[string]*File),
-			lookup: make(map[string]string),
-		},
-	}
+	fs := newFileStorage(&MemoryFileStorage{
+		files:  make(map[string]*File),
+		infos:  make(map[string]*FileInfo),
+		lookup: make(map[string]string),
+	})
+	fs.budget = DefaultMemoryBudget
+	fs.SetRetention(0, 0, 0)
+	return fs
 }
 
 // Insert a new file into the Storage. The optional `name` will be inserted
 // into the lookup table and can be used to resolve the file later.
-func (fs *MemoryFileStorage) Insert(name, media string, blob []byte) (file *File, err error) {
+func (fs *MemoryFileStorage) Insert(name string, meta FileInfo, blob []byte) (file *File, err error) {
 
 	// check the media type first because that's cheapest
-	media, err = CheckMediaType(media)
+	meta.Media, err = CheckMediaType(meta.Media)
 	if err != nil {
 		return nil, fmt.Errorf("media: %w", err)
 	}
 
 	// we could check if the file exists already here but since we operate on
 	// memory for now, we can just overwrite whatever is there cheaply
-	file = NewFile(media, blob)
+	file = NewFile(meta.Media, blob)
 	ref := file.Ref()
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
 	fs.files[ref] = file
 
+	// keep the metadata of the first upload
+	if _, ok := fs.infos[ref]; !ok {
+		fs.infos[ref] = newFileInfo(meta, ref, int64(len(blob)))
+	}
+
 	// maybe insert name in lookup map, if given
 	if name != "" {
 		fs.lookup[name] = ref
 	}
-	fs.debug()
+	slog.Debug("memfs: inserted file", "ref", ref, "media", meta.Media, "size", len(blob), "name", name)
 	return
 }
 
+// InsertReader reads a stream into memory and inserts it like Insert.
+func (fs *MemoryFileStorage) InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error) {
+	blob, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	file, err := fs.Insert(name, meta, blob)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(file.Ref())
+}
+
+// Link inserts a name for a File, which is in the Storage already.
+func (fs *MemoryFileStorage) Link(name, ref string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if _, ok := fs.files[ref]; !ok {
+		return ErrNotFound
+	}
+	fs.lookup[name] = ref
+	return nil
+}
+
 // Get a File from Storage, either by Ref or a friendly name in lookup map.
 func (fs *MemoryFileStorage) Get(nameOrRef string) *File {
+	fs.mutex.RLock()
+	defer fs.mutex.RUnlock()
+	file, _ := fs.get(nameOrRef)
+	return file
+}
+
+// get resolves a File and its metadata. Must hold the mutex.
+func (fs *MemoryFileStorage) get(nameOrRef string) (*File, *FileInfo) {
 	// try from files directly first
-	if file := fs.files[nameOrRef]; file != nil {
-		return file
+	ref := nameOrRef
+	if _, ok := fs.files[ref]; !ok {
+		// otherwise try to lookup a friendly name
+		ref = fs.lookup[nameOrRef]
 	}
-	// or lookup a friendly name
-	if ref, ok := fs.lookup[nameOrRef]; ok {
-		return fs.files[ref]
+	if file, ok := fs.files[ref]; ok {
+		return file, fs.infos[ref]
 	}
-	return nil
+	return nil, nil
 }
 
-// Iterator over all Files in the storage.
-func (fs *MemoryFileStorage) All() iter.Seq2[string, *File] {
-	return func(yield func(string, *File) bool) {
-		for ref, file := range fs.files {
-			if !yield(ref, file) {
-				return
-			}
-		}
+// Open a File for reading, either by Ref or a friendly name in lookup map.
+func (fs *MemoryFileStorage) Open(nameOrRef string) (*FileReader, error) {
+	fs.mutex.RLock()
+	defer fs.mutex.RUnlock()
+	if file, info := fs.get(nameOrRef); file != nil {
+		return file.Open(info), nil
 	}
+	return nil, ErrNotFound
 }
 
-func (fs *MemoryFileStorage) debug() {
-	log.Println("Inserted in MemoryFileStorage:")
-	for k, v := range fs.lookup {
-		fmt.Printf(" %s => %s\n", k, v)
+// Stat returns the metadata of a File, either by Ref or a friendly name in lookup map.
+func (fs *MemoryFileStorage) Stat(nameOrRef string) (*FileInfo, error) {
+	fs.mutex.RLock()
+	defer fs.mutex.RUnlock()
+	if file, info := fs.get(nameOrRef); file != nil {
+		return info, nil
 	}
-	for k, f := range fs.files {
-		fmt.Printf("+ %s => %s, %d bytes\n", k, f.Media, len(f.Bytes))
+	return nil, ErrNotFound
+}
+
+// Delete a File by its Ref, including all names in the lookup map pointing to it.
+func (fs *MemoryFileStorage) Delete(ref string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if file, ok := fs.files[ref]; ok {
+		slog.Debug("memfs: deleted file", "ref", ref, "size", len(file.Bytes))
+	}
+	delete(fs.files, ref)
+	delete(fs.infos, ref)
+	for name, r := range fs.lookup {
+		if r == ref {
+			delete(fs.lookup, name)
+		}
+	}
+	return nil
+}
+
+// Unlink removes a friendly name from the lookup map but keeps the File.
+func (fs *MemoryFileStorage) Unlink(name string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	delete(fs.lookup, name)
+	return nil
+}
+
+// Iterator over all names in the lookup map and the Refs they point to. It
+// iterates over a snapshot, so the storage can be modified meanwhile.
+func (fs *MemoryFileStorage) Names() iter.Seq2[string, string] {
+	fs.mutex.RLock()
+	lookup := maps.Clone(fs.lookup)
+	fs.mutex.RUnlock()
+	return maps.All(lookup)
+}
+
+// Iterator over all Files in the storage. It iterates over a snapshot, so the
+// storage can be modified meanwhile.
+func (fs *MemoryFileStorage) All() iter.Seq2[string, *File] {
+	fs.mutex.RLock()
+	files := maps.Clone(fs.files)
+	fs.mutex.RUnlock()
+	return maps.All(files)
+}
+
+// Sizes iterates over the sizes of all Files in a snapshot.
+func (fs *MemoryFileStorage) Sizes() iter.Seq2[string, int64] {
+	fs.mutex.RLock()
+	sizes := make(map[string]int64, len(fs.files))
+	for ref := range fs.files {
+		sizes[ref] = fs.infos[ref].Size
 	}
+	fs.mutex.RUnlock()
+	return maps.All(sizes)
 }
@@ -0,0 +1,462 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3FileStorage keeps files in a bucket of any S3-compatible object storage, so
+// multiple Brokers or stateless containers can share them. Blobs are stored as
+// `<prefix>/blobs/<ref>` with their media type as Content-Type and the remaining
+// metadata as user metadata. Lookup names are small objects `<prefix>/lookup/<name>`,
+// which contain the ref, and `<prefix>/refs/<ref>/<name>` map the ref back to its
+// names. Inspection results are too large for user metadata and
+// stored as JSON in `<prefix>/metadata/<ref>`. Other Brokers may add names at any
+// time, so they are read again before a file is collected, see NamesOf.
+type S3FileStorage struct {
+	client  *minio.Client
+	bucket  string
+	prefix  string
+	presign time.Duration
+}
+
+// S3Options configure the connection to the object storage. Credentials are read
+// from the AWS_* or MINIO_* environment variables, ~/.aws/credentials or the
+// instance metadata, in that order.
+type S3Options struct {
+	Endpoint string        // host or URL of the API, plain http:// disables TLS
+	Region   string        // region of the bucket, detected if empty
+	Presign  time.Duration // redirect downloads to presigned URLs valid this long
+}
+
+const (
+	// timeout for single requests to the object storage
+	s3Timeout = time.Minute
+	// minimum throughput expected for blob uploads, which extends their timeout
+	s3MinThroughput = 1 << 20 // 1 MiB/s
+)
+
+// NewS3FileStorage connects to a bucket given as `s3://bucket/prefix`.
+func NewS3FileStorage(location string, opts S3Options) *FileStorage {
+
+	// parse the bucket and prefix
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		// to keep the API clean, we just abort in here since this happens only at startup
+		log.Fatalf("s3fs: expected s3://bucket/prefix, got %q", location)
+	}
+	prefix := strings.Trim(u.Path, "/")
+
+	// the endpoint can be given with a scheme to select plain http
+	endpoint, secure := opts.Endpoint, true
+	if e, err := url.Parse(endpoint); err == nil && e.Host != "" {
+		endpoint, secure = e.Host, e.Scheme != "http"
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Secure: secure,
+		Region: opts.Region,
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		}),
+	})
+	if err != nil {
+		log.Fatalf("s3fs: cannot create client: %s", err)
+	}
+
+	// make sure the bucket is reachable
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	exists, err := client.BucketExists(ctx, u.Host)
+	if err != nil {
+		log.Fatalf("s3fs: cannot access bucket %q: %s", u.Host, err)
+	}
+	if !exists {
+		log.Fatalf("s3fs: bucket %q does not exist", u.Host)
+	}
+
+	backend := &S3FileStorage{client, u.Host, prefix, opts.Presign}
+	fs := newFileStorage(backend)
+	if opts.Presign > 0 {
+		fs.presigner = backend
+	}
+	return fs
+}
+
+// key returns the object name of a blob or lookup name.
+func (fs *S3FileStorage) key(kind string, name ...string) string {
+	return path.Join(append([]string{fs.prefix, kind}, name...)...)
+}
+
+// Insert a new file into the Storage. The optional `name` will be inserted
+// into the lookup table and can be used to resolve the file later.
+func (fs *S3FileStorage) Insert(name string, meta FileInfo, blob []byte) (file *File, err error) {
+
+	// check the media type first because that's cheapest
+	meta.Media, err = CheckMediaType(meta.Media)
+	if err != nil {
+		return nil, fmt.Errorf("media: %w", err)
+	}
+
+	// use a *File struct to obtain the content hash
+	file = NewFile(meta.Media, blob)
+	ref := file.Ref()
+
+	if err = fs.put(name, newFileInfo(meta, ref, int64(len(blob))), bytes.NewReader(blob)); err != nil {
+		return nil, err
+	}
+	return file, nil
+
+}
+
+// InsertReader streams a new file into a staging file while hashing it and uploads
+// it afterwards. The optional `name` is inserted like in Insert.
+func (fs *S3FileStorage) InsertReader(name string, meta FileInfo, r io.Reader) (*FileInfo, error) {
+
+	// check the media type first because that's cheapest
+	media, err := CheckMediaType(meta.Media)
+	if err != nil {
+		return nil, fmt.Errorf("media: %w", err)
+	}
+	meta.Media = media
+
+	// stage the blob to obtain the content hash
+	staged, ref, size, err := spool(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage blob: %w", err)
+	}
+	defer os.Remove(staged.Name())
+	defer staged.Close()
+
+	if err = fs.put(name, newFileInfo(meta, ref, size), staged); err != nil {
+		return nil, err
+	}
+	return fs.Stat(ref)
+
+}
+
+// put uploads a blob with its metadata, unless it exists already, and inserts
+// the optional name.
+func (fs *S3FileStorage) put(name string, info *FileInfo, blob io.Reader) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout+time.Duration(info.Size/s3MinThroughput)*time.Second)
+	defer cancel()
+
+	// upload the blob, unless it exists already
+	if _, err := fs.client.StatObject(ctx, fs.bucket, fs.key("blobs", info.Ref), minio.StatObjectOptions{}); err != nil {
+		if !isNoSuchKey(err) {
+			return fmt.Errorf("failed to stat blob: %w", err)
+		}
+		_, err = fs.client.PutObject(ctx, fs.bucket, fs.key("blobs", info.Ref), blob, info.Size,
+			minio.PutObjectOptions{ContentType: info.Media, UserMetadata: map[string]string{
+				"uploaded": info.Uploaded.Format(time.RFC3339),
+				"uploader": url.PathEscape(info.Uploader),
+				"name":     url.PathEscape(info.Name),
+			}})
+		if err != nil {
+			return fmt.Errorf("failed to put blob: %w", err)
+		}
+		if info.Wasm != nil || info.Zip != nil || info.Tar != nil {
+			inspected, _ := json.Marshal(s3Inspection{info.Wasm, info.Zip, info.Tar})
+			_, err = fs.client.PutObject(ctx, fs.bucket, fs.key("metadata", info.Ref), bytes.NewReader(inspected), int64(len(inspected)),
+				minio.PutObjectOptions{ContentType: "application/json"})
+			if err != nil {
+				return fmt.Errorf("failed to put metadata: %w", err)
+			}
+		}
+	}
+
+	// insert name, if given
+	if name != "" {
+		return fs.link(ctx, name, info.Ref)
+	}
+	return nil
+}
+
+// Link inserts a name for a File, which is in the Storage already.
+func (fs *S3FileStorage) Link(name, ref string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	if _, err := fs.client.StatObject(ctx, fs.bucket, fs.key("blobs", ref), minio.StatObjectOptions{}); err != nil {
+		if isNoSuchKey(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to stat blob: %w", err)
+	}
+	return fs.link(ctx, name, ref)
+}
+
+// link inserts a name in lookup and moves its reverse mapping to ref.
+func (fs *S3FileStorage) link(ctx context.Context, name, ref string) error {
+	previous := fs.resolve(name)
+	_, err := fs.client.PutObject(ctx, fs.bucket, fs.key("lookup", name), strings.NewReader(ref), int64(len(ref)),
+		minio.PutObjectOptions{ContentType: "text/plain"})
+	if err != nil {
+		return fmt.Errorf("failed to put lookup name: %w", err)
+	}
+	_, err = fs.client.PutObject(ctx, fs.bucket, fs.key("refs", ref, name), strings.NewReader(name), int64(len(name)),
+		minio.PutObjectOptions{ContentType: "text/plain"})
+	if err != nil {
+		return fmt.Errorf("failed to put reverse name: %w", err)
+	}
+	if previous != "" && previous != ref {
+		fs.client.RemoveObject(ctx, fs.bucket, fs.key("refs", previous, name), minio.RemoveObjectOptions{})
+	}
+	return nil
+}
+
+// Get a File from Storage, either by Ref or a friendly name in lookup.
+func (fs *S3FileStorage) Get(nameOrRef string) *File {
+	object, err := fs.Open(nameOrRef)
+	if err != nil {
+		return nil
+	}
+	defer object.Close()
+	blob, err := io.ReadAll(object)
+	if err != nil {
+		log.Printf("ERR: s3fs: reading %s failed: %s", object.Ref, err)
+		return nil
+	}
+	return &File{Media: object.Media, Bytes: blob, ref: object.Ref}
+}
+
+// Open a File for reading, either by Ref or a friendly name in lookup. Reading
+// and seeking are forwarded to the object storage. The metadata does not include
+// the inspection results to avoid another request, use Stat for them.
+func (fs *S3FileStorage) Open(nameOrRef string) (*FileReader, error) {
+	ref := fs.resolve(nameOrRef)
+	if ref == "" {
+		return nil, ErrNotFound
+	}
+	object, err := fs.client.GetObject(context.Background(), fs.bucket, fs.key("blobs", ref), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if isNoSuchKey(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &FileReader{ReadSeekCloser: object, FileInfo: objectInfo(ref, stat)}, nil
+}
+
+// Stat returns the metadata of a File, either by Ref or a friendly name in lookup.
+func (fs *S3FileStorage) Stat(nameOrRef string) (*FileInfo, error) {
+	ref := fs.resolve(nameOrRef)
+	if ref == "" {
+		return nil, ErrNotFound
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	stat, err := fs.client.StatObject(ctx, fs.bucket, fs.key("blobs", ref), minio.StatObjectOptions{})
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	info := objectInfo(ref, stat)
+	if inspected, err := fs.read(fs.key("metadata", ref)); err == nil {
+		var sidecar s3Inspection
+		if json.Unmarshal([]byte(inspected), &sidecar) == nil {
+			info.Wasm, info.Zip, info.Tar = sidecar.Wasm, sidecar.Zip, sidecar.Tar
+		}
+	}
+	return info, nil
+}
+
+// s3Inspection are the inspection results of a File, which don't fit in the user
+// metadata of an object and are stored in a separate JSON object.
+type s3Inspection struct {
+	Wasm *WasmInfo    `json:"wasm,omitempty"`
+	Zip  *ArchiveInfo `json:"zip,omitempty"`
+	Tar  *ArchiveInfo `json:"tar,omitempty"`
+}
+
+// objectInfo converts the user metadata of an object to a FileInfo. Objects
+// uploaded without metadata fall back to their modification time.
+func objectInfo(ref string, stat minio.ObjectInfo) *FileInfo {
+	info := &FileInfo{Ref: ref, Media: stat.ContentType, Size: stat.Size, Uploaded: stat.LastModified.UTC()}
+	if t, err := time.Parse(time.RFC3339, stat.UserMetadata["Uploaded"]); err == nil {
+		info.Uploaded = t
+	}
+	info.Uploader, _ = url.PathUnescape(stat.UserMetadata["Uploader"])
+	info.Name, _ = url.PathUnescape(stat.UserMetadata["Name"])
+	return info
+}
+
+// resolve returns the ref of a friendly name or the ref itself.
+func (fs *S3FileStorage) resolve(nameOrRef string) string {
+	if IsRef(nameOrRef) {
+		return nameOrRef
+	}
+	ref, err := fs.read(fs.key("lookup", nameOrRef))
+	if err != nil || !IsRef(ref) {
+		return ""
+	}
+	return ref
+}
+
+// read the contents of a small object.
+func (fs *S3FileStorage) read(key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	object, err := fs.client.GetObject(ctx, fs.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+	value, err := io.ReadAll(object)
+	return string(value), err
+}
+
+// Presign returns the ref and a temporary URL to download a file directly from
+// the object storage.
+func (fs *S3FileStorage) Presign(nameOrRef string) (ref, location string, err error) {
+	ref = fs.resolve(nameOrRef)
+	if ref == "" {
+		return "", "", ErrNotFound
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	if _, err := fs.client.StatObject(ctx, fs.bucket, fs.key("blobs", ref), minio.StatObjectOptions{}); err != nil {
+		if isNoSuchKey(err) {
+			return "", "", ErrNotFound
+		}
+		return "", "", err
+	}
+	u, err := fs.client.PresignedGetObject(ctx, fs.bucket, fs.key("blobs", ref), fs.presign, nil)
+	if err != nil {
+		return "", "", err
+	}
+	return ref, u.String(), nil
+}
+
+// Delete a File by its Ref, including all names in lookup pointing to it.
+func (fs *S3FileStorage) Delete(ref string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	errs := []error{
+		fs.client.RemoveObject(ctx, fs.bucket, fs.key("blobs", ref), minio.RemoveObjectOptions{}),
+		fs.client.RemoveObject(ctx, fs.bucket, fs.key("metadata", ref), minio.RemoveObjectOptions{}),
+	}
+	names, err := fs.NamesOf(ref)
+	errs = append(errs, err)
+	for _, name := range names {
+		// the name might have been moved to another file in the meantime
+		if fs.resolve(name) == ref {
+			errs = append(errs, fs.client.RemoveObject(ctx, fs.bucket, fs.key("lookup", name), minio.RemoveObjectOptions{}))
+		}
+		errs = append(errs, fs.client.RemoveObject(ctx, fs.bucket, fs.key("refs", ref, name), minio.RemoveObjectOptions{}))
+	}
+	return errors.Join(errs...)
+}
+
+// Unlink removes a friendly name from lookup but keeps the File.
+func (fs *S3FileStorage) Unlink(name string) error {
+	ref := fs.resolve(name)
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	err := fs.client.RemoveObject(ctx, fs.bucket, fs.key("lookup", name), minio.RemoveObjectOptions{})
+	if ref != "" {
+		err = errors.Join(err, fs.client.RemoveObject(ctx, fs.bucket, fs.key("refs", ref, name), minio.RemoveObjectOptions{}))
+	}
+	return err
+}
+
+// NamesOf lists the names in lookup pointing to a File with a single request.
+func (fs *S3FileStorage) NamesOf(ref string) (names []string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	prefix := fs.key("refs", ref) + "/"
+	for object := range fs.client.ListObjects(ctx, fs.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		names = append(names, strings.TrimPrefix(object.Key, prefix))
+	}
+	return names, nil
+}
+
+// Iterator over all names in lookup and the Refs they point to.
+func (fs *S3FileStorage) Names() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for key := range fs.list("lookup") {
+			ref, err := fs.read(fs.key("lookup", key))
+			if err != nil || !IsRef(ref) {
+				continue
+			}
+			if !yield(key, ref) {
+				return
+			}
+		}
+	}
+}
+
+// Iterator over all Files in the storage.
+func (fs *S3FileStorage) All() iter.Seq2[string, *File] {
+	return func(yield func(string, *File) bool) {
+		for ref := range fs.list("blobs") {
+			file := fs.Get(ref)
+			if file == nil {
+				continue // deleted in the meantime
+			}
+			if !yield(ref, file) {
+				return
+			}
+		}
+	}
+}
+
+// Sizes iterates over the sizes of all Files without downloading them.
+func (fs *S3FileStorage) Sizes() iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		for ref, size := range fs.list("blobs") {
+			if !yield(ref, size) {
+				return
+			}
+		}
+	}
+}
+
+// list iterates over the names and sizes of all objects of a kind.
+func (fs *S3FileStorage) list(kind string) iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		prefix := fs.key(kind, "") + "/"
+		for object := range fs.client.ListObjects(ctx, fs.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if object.Err != nil {
+				log.Printf("ERR: s3fs: listing %s failed: %s", prefix, object.Err)
+				return
+			}
+			if !yield(strings.TrimPrefix(object.Key, prefix), object.Size) {
+				return
+			}
+		}
+	}
+}
+
+// isNoSuchKey checks if the object storage reported a missing object.
+func isNoSuchKey(err error) bool {
+	return minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
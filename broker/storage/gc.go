@@ -0,0 +1,490 @@
+package storage
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Files are garbage collected by the FileStorage, which keeps an index of their
+// sizes, last-access times and the lookup names pointing to them. The
+// names are references: deleting the last name of a file deletes the file, and
+// files without any names are removed when they were not accessed for the TTL.
+// If the total size exceeds the quota, the least recently used files are evicted,
+// unreferenced ones first. Files used by queued or running tasks are pinned and
+// never collected. The index is kept in memory and rebuilt from the backend at
+// startup, when all access times are reset to the current time. Backends shared
+// by multiple Brokers are asked for the current names of a file before it is
+// deleted, since another Broker may have named it in the meantime.
+
+// collectPeriod is the interval in which the janitor checks the TTL and quota.
+const collectPeriod = 30 * time.Second
+
+var (
+	ErrNotFound   = errors.New("file not found in storage")
+	ErrReferenced = errors.New("file is referenced by names in other namespaces or by tasks")
+	ErrForbidden  = errors.New("cannot delete files of other namespaces or uploaders")
+)
+
+// fileStat holds the garbage collection metadata of a single file.
+type fileStat struct {
+	accessed time.Time
+	size     int64
+	pinned   int                 // number of tasks using this file
+	artifact bool                // stored artifacts or stdin of a task, see artifactTTL
+	names    map[string]struct{} // lookup names referencing this file
+}
+
+// fileIndex tracks all files in a backend, safe for concurrent access.
+type fileIndex struct {
+	mutex sync.Mutex
+	files map[string]*fileStat // keyed by ref
+	names map[string]string    // lookup name to ref
+	size  int64                // total size of all files
+
+	ttl         time.Duration // remove unreferenced files after this idle time
+	artifactTTL time.Duration // remove stored artifacts after this idle time, if shorter
+	maxSize     int64         // evict files above this total size
+}
+
+// sizer is implemented by backends, which can list the sizes of their files more
+// efficiently than reading all of them.
+type sizer interface {
+	Sizes() iter.Seq2[string, int64]
+}
+
+// sharedBackend is implemented by backends, which can be shared with other Brokers.
+// NamesOf returns the current names of a file, which may be unknown to the index.
+type sharedBackend interface {
+	NamesOf(ref string) ([]string, error)
+}
+
+// allSizes reads all files of a backend to get their sizes.
+func allSizes(backend AbstractFileStorage) iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		for ref, file := range backend.All() {
+			if !yield(ref, int64(len(file.Bytes))) {
+				return
+			}
+		}
+	}
+}
+
+// newFileIndex builds the index from all files and names in the backend.
+func newFileIndex(backend AbstractFileStorage) *fileIndex {
+	idx := &fileIndex{
+		files: make(map[string]*fileStat),
+		names: make(map[string]string),
+	}
+	now := time.Now()
+	sizes := allSizes(backend)
+	if s, ok := backend.(sizer); ok {
+		sizes = s.Sizes()
+	}
+	for ref, size := range sizes {
+		idx.files[ref] = &fileStat{accessed: now, size: size, names: make(map[string]struct{})}
+		idx.size += size
+	}
+	for name, ref := range backend.Names() {
+		if stat, ok := idx.files[ref]; ok {
+			stat.names[name] = struct{}{}
+			idx.names[name] = ref
+		}
+	}
+	return idx
+}
+
+// touch updates the last-access time of a file.
+func (idx *fileIndex) touch(ref string) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	if stat, ok := idx.files[ref]; ok {
+		stat.accessed = time.Now()
+	}
+}
+
+// has returns true if a file is in the index.
+func (idx *fileIndex) has(ref string) bool {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	_, ok := idx.files[ref]
+	return ok
+}
+
+// accessed returns the last-access time of a file or the zero time if it is unknown.
+func (idx *fileIndex) accessed(ref string) time.Time {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	if stat, ok := idx.files[ref]; ok {
+		return stat.accessed
+	}
+	return time.Time{}
+}
+
+// insert records a new file or access to an existing one and an optional name,
+// which is moved from the file it pointed to before. Returns true for new files.
+func (idx *fileIndex) insert(ref, name string, size int64) (created bool) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	now := time.Now()
+	stat, ok := idx.files[ref]
+	if created = !ok; created {
+		stat = &fileStat{size: size, names: make(map[string]struct{})}
+		idx.files[ref] = stat
+		idx.size += size
+	}
+	stat.accessed = now
+	if name != "" {
+		idx.name(ref, stat, name)
+	}
+	return created
+}
+
+// link adds a name to an indexed file and returns false if it is not indexed.
+func (idx *fileIndex) link(ref, name string) bool {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	stat, ok := idx.files[ref]
+	if ok {
+		stat.accessed = time.Now()
+		idx.name(ref, stat, name)
+	}
+	return ok
+}
+
+// name points a name to a file, moving it from a previous one. Must hold the mutex.
+func (idx *fileIndex) name(ref string, stat *fileStat, name string) {
+	if previous, ok := idx.names[name]; ok && previous != ref {
+		delete(idx.files[previous].names, name)
+	}
+	idx.names[name] = ref
+	stat.names[name] = struct{}{}
+}
+
+// markArtifact flags newly stored artifacts or stdin of a task for the artifactTTL.
+func (idx *fileIndex) markArtifact(ref string) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	if stat, ok := idx.files[ref]; ok {
+		stat.artifact = true
+	}
+}
+
+// ttlOf returns the effective TTL of an unreferenced file, zero if it is kept.
+// Must hold the mutex.
+func (idx *fileIndex) ttlOf(stat *fileStat) time.Duration {
+	if stat.artifact && idx.artifactTTL > 0 && (idx.ttl == 0 || idx.artifactTTL < idx.ttl) {
+		return idx.artifactTTL
+	}
+	return idx.ttl
+}
+
+// drop removes a file and all its names from the index and returns its previous
+// stat, if any. Must hold the mutex.
+func (idx *fileIndex) drop(ref string) *fileStat {
+	stat, ok := idx.files[ref]
+	if !ok {
+		return nil
+	}
+	for name := range stat.names {
+		delete(idx.names, name)
+	}
+	idx.size -= stat.size
+	delete(idx.files, ref)
+	return stat
+}
+
+// restore puts a dropped file back into the index with the given names, which
+// replace its previous ones, and resets its last-access time.
+func (idx *fileIndex) restore(ref string, stat *fileStat, names []string) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	if _, ok := idx.files[ref]; ok {
+		return // inserted again in the meantime
+	}
+	stat.accessed = time.Now()
+	stat.names = make(map[string]struct{})
+	for _, name := range names {
+		if previous, ok := idx.names[name]; ok && previous != ref {
+			delete(idx.files[previous].names, name)
+		}
+		idx.names[name] = ref
+		stat.names[name] = struct{}{}
+	}
+	idx.files[ref] = stat
+	idx.size += stat.size
+}
+
+// expired selects the files to remove because of the TTL or the quota and drops
+// them from the index. The file in keep is never selected.
+func (idx *fileIndex) expired(keep string) (dropped map[string]*fileStat) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	dropped = make(map[string]*fileStat)
+
+	// unreferenced files, which were not accessed within the TTL
+	if idx.ttl > 0 || idx.artifactTTL > 0 {
+		refs := []string{}
+		for ref, stat := range idx.files {
+			if ttl := idx.ttlOf(stat); ttl > 0 && ref != keep && stat.pinned == 0 && len(stat.names) == 0 && time.Since(stat.accessed) > ttl {
+				refs = append(refs, ref)
+			}
+		}
+		for _, ref := range refs {
+			dropped[ref] = idx.drop(ref)
+		}
+	}
+
+	// least recently used files above the quota, unreferenced first
+	if idx.maxSize > 0 && idx.size > idx.maxSize {
+		candidates := make([]string, 0, len(idx.files))
+		for ref, stat := range idx.files {
+			if ref != keep && stat.pinned == 0 {
+				candidates = append(candidates, ref)
+			}
+		}
+		referenced := func(ref string) int { return min(len(idx.files[ref].names), 1) }
+		slices.SortFunc(candidates, func(a, b string) int {
+			return cmp.Or(
+				cmp.Compare(referenced(a), referenced(b)),
+				idx.files[a].accessed.Compare(idx.files[b].accessed),
+			)
+		})
+		for _, ref := range candidates {
+			if idx.size <= idx.maxSize {
+				break
+			}
+			dropped[ref] = idx.drop(ref)
+		}
+	}
+	return dropped
+}
+
+// pin protects files from collection until release is called, e.g. while a task
+// using them is queued. Fails with ErrNotFound if any file is not in the index.
+func (idx *fileIndex) pin(refs ...string) (release func(), err error) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	refs = slices.DeleteFunc(refs, func(ref string) bool { return ref == "" })
+	pinned := make([]*fileStat, 0, len(refs))
+	for _, ref := range refs {
+		stat, ok := idx.files[ref]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		pinned = append(pinned, stat)
+	}
+	for _, stat := range pinned {
+		stat.pinned++
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			idx.mutex.Lock()
+			defer idx.mutex.Unlock()
+			// release the pinned stats, even if the file was deleted and inserted
+			// again in the meantime, so the new stat is not affected
+			for _, stat := range pinned {
+				stat.pinned--
+			}
+		})
+	}, nil
+}
+
+// SetRetention configures the TTL of unreferenced files, the shorter TTL of stored
+// artifacts and the maximum total size of the storage. Zero values disable either
+// limit, except for backends with a budget, which is used as the default maximum size.
+func (fs *FileStorage) SetRetention(ttl, artifactTTL time.Duration, maxSize int64) {
+	fs.index.mutex.Lock()
+	fs.index.ttl, fs.index.artifactTTL, fs.index.maxSize = ttl, artifactTTL, cmp.Or(maxSize, fs.budget)
+	fs.index.mutex.Unlock()
+	fs.collect("")
+}
+
+// Get a File by Ref or name from the backend and update its last-access time.
+func (fs *FileStorage) Get(nameOrRef string) *File {
+	file := fs.AbstractFileStorage.Get(nameOrRef)
+	if file != nil {
+		fs.index.touch(file.Ref())
+	}
+	return file
+}
+
+// Insert a File into the backend and record it in the index. Other files may be
+// evicted if the storage exceeds its quota afterwards. Files larger than the
+// MaxFileSize are rejected with ErrTooLarge, malformed ones with ErrInvalidFile.
+func (fs *FileStorage) Insert(name string, meta FileInfo, blob []byte) (*File, error) {
+	file, _, err := fs.insert(name, meta, blob)
+	return file, err
+}
+
+// insert also returns whether the File was not in the storage before.
+func (fs *FileStorage) insert(name string, meta FileInfo, blob []byte) (file *File, created bool, err error) {
+	if fs.MaxFileSize > 0 && int64(len(blob)) > fs.MaxFileSize {
+		return nil, false, ErrTooLarge
+	}
+	media, err := CheckMediaType(meta.Media)
+	if err != nil {
+		return nil, false, fmt.Errorf("media: %w", err)
+	}
+	meta.Media = wheelMedia(media, meta.Name)
+	if err := fs.inspect(&meta, bytes.NewReader(blob), int64(len(blob))); err != nil {
+		return nil, false, err
+	}
+	file, err = fs.AbstractFileStorage.Insert(name, meta, blob)
+	if err != nil {
+		return nil, false, err
+	}
+	created = fs.index.insert(file.Ref(), name, int64(len(blob)))
+	fs.collect(file.Ref())
+	return file, created, nil
+}
+
+// Remove deletes a friendly name or a file by its Ref in the Write namespace of
+// the request context. A name is resolved in the Write namespace and the file is
+// deleted along with its last name. A Ref can only be deleted by the Client which
+// uploaded it or if it has a name in the Write namespace, and only if it is not
+// referenced by names in other namespaces; the URLs of fetched files do not count.
+// Files which are used by queued or running tasks cannot be deleted.
+func (fs *FileStorage) Remove(ctx context.Context, nameOrRef string) error {
+	ns := NamespacesFrom(ctx)
+
+	// delete a file and all its names directly
+	if IsRef(nameOrRef) {
+		uploaded := false
+		if uploader := UploaderFrom(ctx); uploader != "" {
+			info, err := fs.AbstractFileStorage.Stat(nameOrRef)
+			uploaded = err == nil && info.Uploader == uploader
+		}
+		fs.index.mutex.Lock()
+		stat, ok := fs.index.files[nameOrRef]
+		if !ok {
+			fs.index.mutex.Unlock()
+			return ErrNotFound
+		}
+		if stat.pinned > 0 {
+			fs.index.mutex.Unlock()
+			return ErrReferenced
+		}
+		owned := uploaded
+		for name := range stat.names {
+			if namespaceOf(name) != ns.Write {
+				fs.index.mutex.Unlock()
+				return ErrReferenced
+			}
+			owned = true
+		}
+		if !owned {
+			fs.index.mutex.Unlock()
+			return ErrForbidden
+		}
+		fs.index.drop(nameOrRef)
+		fs.index.mutex.Unlock()
+		return fs.delete([]string{nameOrRef})
+	}
+
+	// otherwise unlink a name in the Write namespace
+	name := qualify(ns.Write, nameOrRef)
+	if namespace, _, ok := strings.Cut(nameOrRef, namespaceSeparator); ok {
+		if namespace != ns.Write {
+			return ErrForbidden
+		}
+		name = nameOrRef
+	}
+	fs.index.mutex.Lock()
+	ref, ok := fs.index.names[name]
+	if !ok {
+		fs.index.mutex.Unlock()
+		return ErrNotFound
+	}
+	stat := fs.index.files[ref]
+	if len(stat.names) == 1 && stat.pinned > 0 {
+		fs.index.mutex.Unlock()
+		return ErrReferenced // would delete the file
+	}
+	delete(fs.index.names, name)
+	delete(stat.names, name)
+	dropped := map[string]*fileStat{}
+	if len(stat.names) == 0 {
+		dropped[ref] = fs.index.drop(ref)
+	}
+	fs.index.mutex.Unlock()
+	if err := fs.AbstractFileStorage.Unlink(name); err != nil {
+		return err
+	}
+	if refs := fs.unshared(dropped); len(refs) > 0 {
+		return fs.delete(refs)
+	}
+	return nil
+
+}
+
+// unshared returns the refs of dropped files, which can be deleted from the backend.
+// In shared backends, files which were named by another Broker in the meantime or
+// whose names cannot be checked are restored to the index instead.
+func (fs *FileStorage) unshared(dropped map[string]*fileStat) []string {
+	if shared, ok := fs.AbstractFileStorage.(sharedBackend); ok {
+		for ref, stat := range dropped {
+			names, err := shared.NamesOf(ref)
+			if err != nil {
+				log.Printf("ERR: Storage: cannot check names of %s: %s", ref, err)
+				fs.index.restore(ref, stat, slices.Collect(maps.Keys(stat.names)))
+				delete(dropped, ref)
+			} else if slices.ContainsFunc(names, func(name string) bool {
+				_, known := stat.names[name]
+				return !known
+			}) {
+				fs.index.restore(ref, stat, names)
+				delete(dropped, ref)
+			}
+		}
+	}
+	return slices.Collect(maps.Keys(dropped))
+}
+
+// collect removes files which expired or exceed the quota, except for keep.
+func (fs *FileStorage) collect(keep string) {
+	if refs := fs.unshared(fs.index.expired(keep)); len(refs) > 0 {
+		log.Printf("Storage: collecting %d expired or least recently used files", len(refs))
+		if err := fs.delete(refs); err != nil {
+			log.Printf("ERR: Storage: %s", err)
+		}
+	}
+}
+
+// delete removes files, which were already dropped from the index, from the
+// backend, releases their quota and notifies OnRemove.
+func (fs *FileStorage) delete(refs []string) error {
+	errs := []error{}
+	for _, ref := range refs {
+		var info *FileInfo
+		if fs.Release != nil {
+			info, _ = fs.AbstractFileStorage.Stat(ref)
+		}
+		err := fs.AbstractFileStorage.Delete(ref)
+		if err == nil && info != nil {
+			fs.release(info.Uploader, info.Size)
+		}
+		errs = append(errs, err)
+	}
+	if fs.OnRemove != nil {
+		fs.OnRemove(refs)
+	}
+	return errors.Join(errs...)
+}
+
+// janitor regularly collects expired files.
+func (fs *FileStorage) janitor(period time.Duration) {
+	for range time.Tick(period) {
+		fs.collect("")
+	}
+}
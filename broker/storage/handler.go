@@ -1,12 +1,11 @@
 package storage
 
 import (
-	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"time"
 )
 
 // The UploadHandler returns a HTTP handler, which takes the POSTed file
@@ -23,44 +22,73 @@ func (fs *FileStorage) Upload() http.HandlerFunc {
 			}
 		}()
 
-		// read the entire body
-		body, err := io.ReadAll(r.Body)
+		// check the content-type of the request by peeking at the first bytes: accept zip or wasm
+		ft, body, err := DetectMediaType(r.Body)
 		if err != nil {
-			http.Error(w, "reading body failed", http.StatusUnprocessableEntity)
-			err = fmt.Errorf("reading body failed: %w", err)
+			http.Error(w, "unsupported filetype", http.StatusUnsupportedMediaType)
 			return
 		}
 
-		// check the content-type of the request: accept zip or wasm
-		// TODO: could be reordered with a LimitedReader to peek 512 bytes for detection before reading the whole body
-		ft, err := CheckMediaType(http.DetectContentType(body))
-		if err != nil {
-			http.Error(w, "unsupported filetype", http.StatusUnsupportedMediaType)
+		// can have a friendly lookup-name as query parameter, in the uploader's namespace
+		ns := NamespacesFrom(r.Context())
+		name := r.URL.Query().Get("name")
+		if !validName(name) {
+			http.Error(w, ErrInvalidName.Error(), http.StatusBadRequest)
 			return
 		}
+		meta := FileInfo{Media: ft, Uploader: UploaderFrom(r.Context()), Name: name}
+		if name != "" {
+			name = qualify(ns.Write, name)
+		}
 
-		// can have a friendly lookup-name as query parameter
-		name := r.URL.Query().Get("name")
+		// reserve the announced size before the body is staged, so that Clients above
+		// their quota are rejected early; the actual size is charged after the insert
+		reserved := int64(0)
+		if fs.Quota != nil {
+			reserved = max(r.ContentLength, 1)
+			if qerr := fs.Quota(r.Context(), reserved); qerr != nil {
+				http.Error(w, qerr.Error(), http.StatusTooManyRequests)
+				return
+			}
+		}
 
-		// insert file in storage
-		file, err := fs.Insert(name, ft, body)
+		// stream the file into storage
+		info, created, err := fs.insertReader(name, meta, body)
+		fs.release(meta.Uploader, reserved)
+		if errors.Is(err, ErrTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, ErrInvalidFile) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			err = nil
+			return
+		}
 		if err != nil {
 			http.Error(w, "inserting file in storage failed", http.StatusInternalServerError)
 			err = fmt.Errorf("inserting in storage failed: %w", err)
 			return
 		}
 
+		// check the storage quota of the uploader, once the size is known
+		if qerr := fs.charge(r.Context(), info.Ref, info.Size, created); qerr != nil {
+			http.Error(w, qerr.Error(), http.StatusTooManyRequests)
+			return
+		}
+
 		// return the content address to client
-		w.WriteHeader(http.StatusOK)
 		w.Header().Add("content-type", "text/plain")
-		w.Header().Add("x-wasimoff-ref", file.Ref())
-		fmt.Fprintln(w, file.Ref())
+		w.Header().Add("x-wasimoff-ref", info.Ref)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, info.Ref)
 
 	}
 }
 
 // Make the FileStorage a http.Handler, so it can serve files on web requests.
-// Expects a path value '{filename}' to retrieve the correct file.
+// Expects a path value '{filename}' to retrieve the correct file. Since files are
+// content-addressed, the ref is used as the ETag and requests by ref may be cached
+// forever, while names need to be revalidated because they can be overwritten.
 func (fs *FileStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// get the filename from path pattern
@@ -70,21 +98,128 @@ func (fs *FileStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// retrieve the file from storage
-	file := fs.Get(filename)
-	if file == nil {
+	// return the metadata instead of the contents
+	if r.URL.Query().Has("info") {
+		fs.serveInfo(w, r, filename)
+		return
+	}
+
+	// extract a single file from an archive
+	if r.URL.Query().Has("file") {
+		fs.serveArchiveFile(w, r, filename, r.URL.Query().Get("file"))
+		return
+	}
+
+	// redirect to a presigned URL of the backend
+	if fs.presigner != nil {
+		fs.redirect(w, r, filename)
+		return
+	}
+
+	// open the file in storage
+	file, err := fs.LookupReader(NamespacesFrom(r.Context()), filename)
+	if errors.Is(err, ErrNotFound) {
 		http.Error(w, "File not Found in storage", http.StatusNotFound)
 		return
 	}
+	if err != nil {
+		http.Error(w, "opening file in storage failed", http.StatusInternalServerError)
+		log.Printf("ERR: Serve [%s]: %s", r.RemoteAddr, err)
+		return
+	}
+	defer file.Close()
 
-	// put known content-type in a header and serve the file
+	// put known content-type and caching headers and serve the file
 	w.Header().Add("content-type", file.Media)
-	w.Header().Add("x-wasimoff-ref", file.Ref())
+	w.Header().Add("x-wasimoff-ref", file.Ref)
+	w.Header().Set("etag", fmt.Sprintf("%q", file.Ref))
+	cacheControl(w, filename)
 	// TODO: reuse the same config from websocket config
 	w.Header().Add("access-control-allow-origin", "*")
-	http.ServeContent(w, r, "", zerotime, bytes.NewReader(file.Bytes))
+	http.ServeContent(w, r, "", file.Uploaded, file)
 
 }
 
-// TODO: should store the upload time as modtime
-var zerotime = time.UnixMilli(0)
+// cacheControl lets clients cache files requested by ref forever, while names need
+// to be revalidated because they can be overwritten.
+func cacheControl(w http.ResponseWriter, filename string) {
+	if IsRef(filename) {
+		w.Header().Set("cache-control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("cache-control", "no-cache")
+	}
+}
+
+// serveInfo resolves a file in the Read namespaces and returns its metadata,
+// including the inspection results, as JSON.
+func (fs *FileStorage) serveInfo(w http.ResponseWriter, r *http.Request, filename string) {
+	for _, candidate := range NamespacesFrom(r.Context()).candidates(filename) {
+		info, err := fs.Stat(candidate)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			http.Error(w, "reading metadata failed", http.StatusInternalServerError)
+			log.Printf("ERR: Stat [%s]: %s", r.RemoteAddr, err)
+			return
+		}
+		w.Header().Set("content-type", "application/json")
+		w.Header().Add("access-control-allow-origin", "*")
+		json.NewEncoder(w).Encode(info)
+		return
+	}
+	http.Error(w, "File not Found in storage", http.StatusNotFound)
+}
+
+// redirect resolves a file in the Read namespaces and redirects the request to a
+// temporary URL to download it directly from the backend.
+func (fs *FileStorage) redirect(w http.ResponseWriter, r *http.Request, filename string) {
+	for _, candidate := range NamespacesFrom(r.Context()).candidates(filename) {
+		ref, location, err := fs.presigner.Presign(candidate)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			http.Error(w, "presigning file failed", http.StatusInternalServerError)
+			log.Printf("ERR: Presign [%s]: %s", r.RemoteAddr, err)
+			return
+		}
+		fs.index.touch(ref)
+		w.Header().Add("x-wasimoff-ref", ref)
+		w.Header().Add("access-control-allow-origin", "*")
+		http.Redirect(w, r, location, http.StatusTemporaryRedirect)
+		return
+	}
+	http.Error(w, "File not Found in storage", http.StatusNotFound)
+}
+
+// The DeleteHandler returns a HTTP handler, which deletes a name in the Write
+// namespace of the requester or a file by its content address. Providers are
+// notified to evict the deleted files through OnRemove.
+func (fs *FileStorage) DeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// get the filename from path pattern
+		filename := r.PathValue("filename")
+		if filename == "" {
+			http.Error(w, "path pattern not found", http.StatusInternalServerError)
+			return
+		}
+
+		err := fs.Remove(r.Context(), filename)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, ErrReferenced):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case err != nil:
+			http.Error(w, "deleting file from storage failed", http.StatusInternalServerError)
+			log.Printf("ERR: Delete [%s]: %s", r.RemoteAddr, err)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	}
+}
@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Uploaded files are inspected before they are inserted: WebAssembly binaries are
+// parsed for their imports, exports and memory limits and the central directory
+// of zip archives or the headers of tar archives are checked for zip bombs and
+// unsafe paths. Malformed files are rejected and the extracted metadata is stored
+// along with the file.
+
+var ErrInvalidFile = errors.New("file failed validation")
+
+// WasmInfo is the metadata extracted from a WebAssembly binary.
+type WasmInfo struct {
+	Component bool        `json:"component,omitempty"` // a component instead of a core module
+	Version   uint16      `json:"version"`             // binary format version
+	Wasi      string      `json:"wasi,omitempty"`      // "preview1" if it imports WASI functions
+	Imports   []string    `json:"imports,omitempty"`   // imported functions as `module.name`
+	Exports   []string    `json:"exports,omitempty"`   // exported functions
+	Memory    *WasmMemory `json:"memory,omitempty"`    // limits of the first memory
+}
+
+// WasmMemory are the limits of a linear memory in 64 KiB pages.
+type WasmMemory struct {
+	Min uint64  `json:"min"`
+	Max *uint64 `json:"max,omitempty"` // unbounded if nil
+}
+
+// ArchiveInfo is the metadata extracted from a zip or tar archive.
+type ArchiveInfo struct {
+	Files    int    `json:"files"`    // number of entries
+	Unpacked uint64 `json:"unpacked"` // total uncompressed size in bytes
+}
+
+// archives with more entries are rejected
+const archiveMaxFiles = 1 << 16
+
+// wasiPreview1 are the import modules of WASI preview 1 functions.
+var wasiPreview1 = []string{"wasi_snapshot_preview1", "wasi_unstable"}
+
+// inspect validates a staged file by its media type and fills in the metadata.
+func (fs *FileStorage) inspect(meta *FileInfo, r io.ReaderAt, size int64) (err error) {
+	switch meta.Media {
+	case MediaWasm:
+		meta.Wasm, err = InspectWasm(io.NewSectionReader(r, 0, size))
+	case MediaZip, MediaWheel:
+		meta.Zip, err = InspectZip(r, size, fs.MaxUnpackedSize)
+	case MediaTar, MediaGzip:
+		meta.Tar, err = InspectTar(io.NewSectionReader(r, 0, size), meta.Media == MediaGzip, fs.MaxUnpackedSize)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+	return nil
+}
+
+// InspectWasm parses the header and the import, memory and export sections of a
+// WebAssembly binary. Components are only recognized by their header.
+func InspectWasm(r io.Reader) (*WasmInfo, error) {
+	br := bufio.NewReader(r)
+
+	// magic and version
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("wasm: truncated header")
+	}
+	if !bytes.Equal(header[:4], []byte("\x00asm")) {
+		return nil, fmt.Errorf("wasm: bad magic")
+	}
+	info := &WasmInfo{Version: binary.LittleEndian.Uint16(header[4:6])}
+	switch layer := binary.LittleEndian.Uint16(header[6:8]); {
+	case layer == 0 && info.Version == 1:
+		// core module
+	case layer == 1:
+		info.Component = true
+		return info, nil
+	default:
+		return nil, fmt.Errorf("wasm: unsupported version %d, layer %d", info.Version, layer)
+	}
+
+	// iterate over the sections and only parse the interesting ones
+	for {
+		id, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("wasm: %w", err)
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("wasm: section %d: bad length", id)
+		}
+		switch id {
+		case 2, 5, 7: // import, memory, export
+			section := make([]byte, 0, min(length, 1<<20))
+			buf := bytes.NewBuffer(section)
+			if n, err := io.CopyN(buf, br, int64(length)); err != nil || uint64(n) != length {
+				return nil, fmt.Errorf("wasm: section %d: truncated", id)
+			}
+			s := &wasmSection{Reader: bytes.NewReader(buf.Bytes())}
+			switch id {
+			case 2:
+				err = s.imports(info)
+			case 5:
+				err = s.memories(info)
+			case 7:
+				err = s.exports(info)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("wasm: section %d: %w", id, err)
+			}
+		default:
+			if n, err := io.CopyN(io.Discard, br, int64(length)); err != nil || uint64(n) != length {
+				return nil, fmt.Errorf("wasm: section %d: truncated", id)
+			}
+		}
+	}
+	return info, nil
+}
+
+// wasmSection reads the contents of a single section.
+type wasmSection struct {
+	*bytes.Reader
+}
+
+func (s *wasmSection) u32() (uint32, error) {
+	v, err := binary.ReadUvarint(s)
+	if err != nil || v > 1<<32-1 {
+		return 0, fmt.Errorf("bad integer")
+	}
+	return uint32(v), nil
+}
+
+func (s *wasmSection) name() (string, error) {
+	n, err := s.u32()
+	if err != nil || int(n) > s.Len() {
+		return "", fmt.Errorf("bad name")
+	}
+	b := make([]byte, n)
+	s.Read(b)
+	return string(b), nil
+}
+
+// count reads the length of a vector, which can't have more elements than bytes left.
+func (s *wasmSection) count() (uint32, error) {
+	n, err := s.u32()
+	if err != nil || int(n) > s.Len() {
+		return 0, fmt.Errorf("bad vector length")
+	}
+	return n, nil
+}
+
+// valtype skips a value or reference type, which may reference a heap type.
+func (s *wasmSection) valtype() error {
+	t, err := s.ReadByte()
+	if err != nil {
+		return err
+	}
+	if t == 0x63 || t == 0x64 { // (ref null ht), (ref ht)
+		if _, err = binary.ReadUvarint(s); err != nil {
+			return fmt.Errorf("bad heap type")
+		}
+	}
+	return nil
+}
+
+func (s *wasmSection) limits() (*WasmMemory, error) {
+	flags, err := s.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	mem := &WasmMemory{}
+	if mem.Min, err = binary.ReadUvarint(s); err != nil {
+		return nil, fmt.Errorf("bad limits")
+	}
+	if flags&0x01 != 0 {
+		max, err := binary.ReadUvarint(s)
+		if err != nil || max < mem.Min {
+			return nil, fmt.Errorf("bad limits")
+		}
+		mem.Max = &max
+	}
+	return mem, nil
+}
+
+func (s *wasmSection) imports(info *WasmInfo) error {
+	n, err := s.count()
+	if err != nil {
+		return err
+	}
+	for range n {
+		module, err := s.name()
+		if err != nil {
+			return err
+		}
+		field, err := s.name()
+		if err != nil {
+			return err
+		}
+		kind, err := s.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case 0x00: // function
+			if _, err = s.u32(); err != nil {
+				return err
+			}
+			info.Imports = append(info.Imports, module+"."+field)
+			if slices.Contains(wasiPreview1, module) {
+				info.Wasi = "preview1"
+			}
+		case 0x01: // table
+			if err = s.valtype(); err != nil {
+				return err
+			}
+			if _, err = s.limits(); err != nil {
+				return err
+			}
+		case 0x02: // memory
+			mem, err := s.limits()
+			if err != nil {
+				return err
+			}
+			if info.Memory == nil {
+				info.Memory = mem
+			}
+		case 0x03: // global
+			if err = s.valtype(); err != nil {
+				return err
+			}
+			if _, err = s.ReadByte(); err != nil { // mutability
+				return err
+			}
+		case 0x04: // tag
+			if _, err = s.ReadByte(); err != nil {
+				return err
+			}
+			if _, err = s.u32(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown import kind %d", kind)
+		}
+	}
+	return nil
+}
+
+func (s *wasmSection) memories(info *WasmInfo) error {
+	n, err := s.count()
+	if err != nil {
+		return err
+	}
+	for range n {
+		mem, err := s.limits()
+		if err != nil {
+			return err
+		}
+		if info.Memory == nil {
+			info.Memory = mem
+		}
+	}
+	return nil
+}
+
+func (s *wasmSection) exports(info *WasmInfo) error {
+	n, err := s.count()
+	if err != nil {
+		return err
+	}
+	for range n {
+		name, err := s.name()
+		if err != nil {
+			return err
+		}
+		kind, err := s.ReadByte()
+		if err != nil {
+			return err
+		}
+		if _, err = s.u32(); err != nil {
+			return err
+		}
+		if kind == 0x00 { // function
+			info.Exports = append(info.Exports, name)
+		}
+	}
+	return nil
+}
+
+// InspectZip checks the central directory of a zip archive. Archives with too
+// many entries, unsafe paths or more than maxUnpacked
+// bytes in total are rejected as likely zip bombs. Zero disables the size limit.
+func InspectZip(r io.ReaderAt, size int64, maxUnpacked int64) (*ArchiveInfo, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("zip: %w", err)
+	}
+	if len(archive.File) > archiveMaxFiles {
+		return nil, fmt.Errorf("zip: more than %d entries", archiveMaxFiles)
+	}
+	info := &ArchiveInfo{Files: len(archive.File)}
+	for _, f := range archive.File {
+		if unsafePath(f.Name) {
+			return nil, fmt.Errorf("zip: unsafe path %q", f.Name)
+		}
+		info.Unpacked += f.UncompressedSize64
+		if maxUnpacked > 0 && info.Unpacked > uint64(maxUnpacked) {
+			return nil, fmt.Errorf("zip: unpacks to more than %d bytes", maxUnpacked)
+		}
+	}
+	return info, nil
+}
+
+// InspectTar walks the headers of a tar archive, which may be gzip-compressed, with
+// the same limits as InspectZip. Gzip-compressed files, which don't contain a tar
+// archive, are plain blobs and yield no metadata.
+func InspectTar(r io.Reader, gzipped bool, maxUnpacked int64) (*ArchiveInfo, error) {
+	if gzipped {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer gz.Close()
+		br := bufio.NewReaderSize(gz, 512)
+		if head, _ := br.Peek(512); !isTar(head) {
+			return nil, nil
+		}
+		r = br
+	}
+	archive := tar.NewReader(r)
+	info := &ArchiveInfo{}
+	for {
+		hdr, err := archive.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("tar: %w", err)
+		}
+		if info.Files++; info.Files > archiveMaxFiles {
+			return nil, fmt.Errorf("tar: more than %d entries", archiveMaxFiles)
+		}
+		if unsafePath(hdr.Name) {
+			return nil, fmt.Errorf("tar: unsafe path %q", hdr.Name)
+		}
+		info.Unpacked += uint64(max(hdr.Size, 0))
+		if maxUnpacked > 0 && info.Unpacked > uint64(maxUnpacked) {
+			return nil, fmt.Errorf("tar: unpacks to more than %d bytes", maxUnpacked)
+		}
+	}
+	return info, nil
+}
+
+// unsafePath checks if an archive entry would be extracted outside of its target.
+func unsafePath(name string) bool {
+	name = path.Clean(name)
+	return path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../")
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Listings contain the named files in the Read namespaces of a requester, ordered
+// by their qualified names. Files without names are not listed, since their refs
+// could belong to anyone. Large listings are split into pages and the last name
+// on a page is the cursor to continue from.
+
+const (
+	// DefaultListLimit is the page size of listings, if none is requested.
+	DefaultListLimit = 100
+	// MaxListLimit caps the requested page size of listings.
+	MaxListLimit = 1000
+)
+
+// FileEntry is a named File in a listing.
+type FileEntry struct {
+	Name string `json:"name"` // qualified as `namespace/name` outside the default namespace
+	*FileInfo
+	Accessed time.Time `json:"accessed"` // last access since startup
+}
+
+// List returns a page of named files in the Read namespaces, whose names start
+// with prefix and sort after the cursor. An unqualified prefix matches names in
+// all Read namespaces. The returned next cursor is empty on the last page.
+func (fs *FileStorage) List(ns Namespaces, prefix, cursor string, limit int) (entries []FileEntry, next string, err error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	limit = min(limit, MaxListLimit)
+
+	// collect the matching names from the index
+	fs.index.mutex.Lock()
+	names := make([]string, 0)
+	for name := range fs.index.names {
+		if name <= cursor || !slices.Contains(ns.Read, namespaceOf(name)) {
+			continue
+		}
+		if strings.Contains(prefix, namespaceSeparator) {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+		} else if !strings.HasPrefix(strings.TrimPrefix(name, namespaceOf(name)+namespaceSeparator), prefix) {
+			continue
+		}
+		names = append(names, name)
+	}
+	fs.index.mutex.Unlock()
+	slices.Sort(names)
+	if len(names) > limit {
+		names = names[:limit]
+		next = names[limit-1]
+	}
+
+	// fetch the metadata of each file from the backend
+	entries = make([]FileEntry, 0, len(names))
+	for _, name := range names {
+		info, err := fs.Stat(name)
+		if errors.Is(err, ErrNotFound) {
+			continue // deleted in the meantime
+		} else if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, FileEntry{name, info, fs.index.accessed(info.Ref)})
+	}
+	return entries, next, nil
+}
+
+// The ListHandler returns a HTTP handler, which lists the named files readable
+// by the requester as JSON. The query parameters `prefix`, `limit` and `cursor`
+// select a page of the listing.
+func (fs *FileStorage) ListHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		query := r.URL.Query()
+		limit := 0
+		if l := query.Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		entries, next, err := fs.List(NamespacesFrom(r.Context()), query.Get("prefix"), query.Get("cursor"), limit)
+		if err != nil {
+			http.Error(w, "listing files in storage failed", http.StatusInternalServerError)
+			log.Printf("ERR: List [%s]: %s", r.RemoteAddr, err)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Files []FileEntry `json:"files"`
+			Next  string      `json:"next,omitempty"`
+		}{entries, next})
+
+	}
+}
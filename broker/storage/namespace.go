@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Namespaces scope the friendly names of files, so different tenants can use the
+// same name without repointing each other's aliases. Content-addressed refs are
+// always global. Names are stored in the lookup table of the backends with their
+// namespace as a prefix, e.g. `alice/tsp.wasm`; the default namespace is empty,
+// which keeps names from before namespaces existed.
+type Namespaces struct {
+	Write string   // namespace to insert new names into
+	Read  []string // namespaces to resolve names from, in order
+}
+
+// DefaultNamespaces is used when no namespace was derived from a request.
+var DefaultNamespaces = Namespaces{Write: "", Read: []string{""}}
+
+const namespaceSeparator = "/"
+
+var ErrInvalidName = errors.New(`names must not contain "` + namespaceSeparator + `" or be "." or ".."`)
+
+// validName checks a friendly name before it is qualified with a namespace. Names
+// become path segments in some backends, so dot segments are rejected as well.
+func validName(name string) bool {
+	return !strings.Contains(name, namespaceSeparator) && name != "." && name != ".."
+}
+
+var reNamespace = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,63}$`)
+
+// ValidNamespace checks the format of a namespace.
+func ValidNamespace(namespace string) bool {
+	return reNamespace.MatchString(namespace) && namespace != "sha256"
+}
+
+// qualify prefixes a name with its namespace.
+func qualify(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + namespaceSeparator + name
+}
+
+// namespaceOf returns the namespace of a qualified name.
+func namespaceOf(name string) string {
+	if namespace, _, ok := strings.Cut(name, namespaceSeparator); ok {
+		return namespace
+	}
+	return ""
+}
+
+// InsertNamed inserts a file and its optional friendly name in the Write namespace
+// of the request context, recording the uploader and the original name. New files
+// are charged to the Quota of the uploader.
+func (fs *FileStorage) InsertNamed(ctx context.Context, name, media string, blob []byte) (*File, error) {
+	if !validName(name) {
+		return nil, ErrInvalidName
+	}
+	meta := FileInfo{Media: media, Uploader: UploaderFrom(ctx), Name: name}
+	if name != "" {
+		name = qualify(NamespacesFrom(ctx).Write, name)
+	}
+	file, created, err := fs.insert(name, meta, blob)
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.charge(ctx, file.Ref(), int64(len(blob)), created); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// LookupStat resolves a ref or a friendly name in the Read namespaces and returns
+// the metadata of the File, without reading its contents. A name can be qualified
+// explicitly as `namespace/name` if the namespace is readable.
+func (fs *FileStorage) LookupStat(ns Namespaces, nameOrRef string) (*FileInfo, error) {
+	for _, candidate := range ns.candidates(nameOrRef) {
+		info, err := fs.AbstractFileStorage.Stat(candidate)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		fs.index.touch(info.Ref)
+		return info, nil
+	}
+	return nil, ErrNotFound
+}
+
+// LookupReader opens a ref or a friendly name in the Read namespaces for reading
+// like LookupStat.
+func (fs *FileStorage) LookupReader(ns Namespaces, nameOrRef string) (*FileReader, error) {
+	for _, candidate := range ns.candidates(nameOrRef) {
+		file, err := fs.Open(candidate)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return file, err
+	}
+	return nil, ErrNotFound
+}
+
+// candidates returns the qualified names to try in order when resolving a ref or
+// a name in the Read namespaces.
+func (ns Namespaces) candidates(nameOrRef string) []string {
+	if IsRef(nameOrRef) {
+		return []string{nameOrRef}
+	}
+	if namespace, _, ok := strings.Cut(nameOrRef, namespaceSeparator); ok {
+		if !slices.Contains(ns.Read, namespace) {
+			return nil
+		}
+		return []string{nameOrRef}
+	}
+	names := make([]string, 0, len(ns.Read))
+	for _, namespace := range ns.Read {
+		names = append(names, qualify(namespace, nameOrRef))
+	}
+	return names
+}
+
+// -------------------- request context -------------------- >>
+
+type namespacesContextKey struct{}
+
+// WithNamespaces returns a context carrying the Namespaces of a request.
+func WithNamespaces(ctx context.Context, ns Namespaces) context.Context {
+	return context.WithValue(ctx, namespacesContextKey{}, ns)
+}
+
+// NamespacesFrom returns the Namespaces of a request or the DefaultNamespaces.
+func NamespacesFrom(ctx context.Context) Namespaces {
+	if ns, ok := ctx.Value(namespacesContextKey{}).(Namespaces); ok {
+		return ns
+	}
+	return DefaultNamespaces
+}
+
+type uploaderContextKey struct{}
+
+// WithUploader returns a context carrying the identity of an authenticated Client,
+// which is recorded in the metadata of uploaded files.
+func WithUploader(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, uploaderContextKey{}, identity)
+}
+
+// UploaderFrom returns the identity of the uploader or an empty string.
+func UploaderFrom(ctx context.Context) string {
+	identity, _ := ctx.Value(uploaderContextKey{}).(string)
+	return identity
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tasks can reference files by http(s) URLs instead of uploading them first. The
+// Broker fetches them once, if their host is in the allowlist, and inserts them
+// content-addressed without a name, charged to the requesting Client. The URL is
+// remembered in memory, so later tasks reuse the cached copy until it is evicted
+// from the storage, e.g. by the TTL.
+
+// remoteTimeout limits the duration of a single fetch.
+const remoteTimeout = 2 * time.Minute
+
+var ErrRemoteForbidden = errors.New("fetching from this host is not allowed")
+
+// IsRemote checks if a ref is a URL to fetch from.
+func IsRemote(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// remoteAllowed checks the host of a URL against the allowlist. Entries are plain
+// hostnames or wildcards like `*.example.com`, which match all subdomains.
+func (fs *FileStorage) remoteAllowed(u *url.URL) bool {
+	host := u.Hostname()
+	for _, allowed := range fs.RemoteHosts {
+		if host == allowed || strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// refCache serializes concurrent work on the same key, like fetching a URL or
+// converting an archive, and remembers the resulting refs.
+type refCache struct {
+	mutex    sync.Mutex
+	inflight map[string]chan struct{}
+	refs     map[string]string // key to ref of the result
+}
+
+func newRefCache() *refCache {
+	return &refCache{inflight: make(map[string]chan struct{}), refs: make(map[string]string)}
+}
+
+// cached returns the remembered ref for a key.
+func (c *refCache) cached(key string) (ref string, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ref, ok = c.refs[key]
+	return
+}
+
+// remember the resulting ref for a key.
+func (c *refCache) remember(key, ref string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.refs[key] = ref
+}
+
+// lock waits for other work on this key to finish and returns the unlock func.
+func (c *refCache) lock(key string) (unlock func()) {
+	for {
+		c.mutex.Lock()
+		wait, busy := c.inflight[key]
+		if !busy {
+			done := make(chan struct{})
+			c.inflight[key] = done
+			c.mutex.Unlock()
+			return func() {
+				c.mutex.Lock()
+				delete(c.inflight, key)
+				c.mutex.Unlock()
+				close(done)
+			}
+		}
+		c.mutex.Unlock()
+		<-wait
+	}
+}
+
+// Fetch resolves a URL to a File in storage and downloads it first, if there is
+// no cached copy yet. The download is limited to the RemoteMaxSize, in addition
+// to the MaxFileSize of all files, and charged to the Quota of the Client in ctx.
+func (fs *FileStorage) Fetch(ctx context.Context, rawurl string) (*FileInfo, error) {
+
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL: %q", rawurl)
+	}
+	if !fs.remoteAllowed(u) {
+		return nil, ErrRemoteForbidden
+	}
+
+	// reuse a cached copy, unless it was collected in the meantime
+	defer fs.remote.lock(rawurl)()
+	if ref, ok := fs.remote.cached(rawurl); ok && fs.index.has(ref) {
+		if info, err := fs.Stat(ref); err == nil {
+			fs.index.touch(info.Ref)
+			return info, nil
+		}
+	}
+
+	// only follow redirects to allowed hosts
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !fs.remoteAllowed(req.URL) {
+				return ErrRemoteForbidden
+			}
+			return nil
+		},
+	}
+	fetchctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(fetchctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch failed: %s", resp.Status)
+	}
+
+	// check the size limit early, if the length is announced
+	var body io.Reader = resp.Body
+	if fs.RemoteMaxSize > 0 {
+		if resp.ContentLength > fs.RemoteMaxSize {
+			return nil, ErrTooLarge
+		}
+		body = &limitedReader{body, fs.RemoteMaxSize}
+	}
+
+	// reserve the announced size like an upload, the actual size is charged below
+	meta := FileInfo{Uploader: UploaderFrom(ctx), Name: rawurl}
+	reserved := int64(0)
+	if fs.Quota != nil {
+		reserved = max(resp.ContentLength, 1)
+		if err := fs.Quota(ctx, reserved); err != nil {
+			return nil, err
+		}
+	}
+
+	// insert the file without a name, so it is subject to the TTL
+	media, r, err := DetectMediaType(body)
+	if err != nil {
+		fs.release(meta.Uploader, reserved)
+		return nil, fmt.Errorf("media: %w", err)
+	}
+	meta.Media = media
+	info, created, err := fs.insertReader("", meta, r)
+	fs.release(meta.Uploader, reserved)
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.charge(ctx, info.Ref, info.Size, created); err != nil {
+		return nil, err
+	}
+	fs.remote.remember(rawurl, info.Ref)
+	log.Printf("Storage: fetched %s as %s (%d bytes)", rawurl, info.Ref, info.Size)
+	return info, nil
+
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Providers unpack the rootfs and the layers of a task from zip archives. Tar
+// archives, which may be gzip-compressed, are converted when they are first used
+// and the converted archive is kept in storage like any other unnamed file. Only
+// directories and regular files are converted; links and special files are
+// skipped, since zip archives can't represent them portably.
+
+// checkLayer validates a filesystem layer, whose archive is resolved like a rootfs.
+// The mount path must be absolute and clean, so it can't escape the filesystem.
+func checkLayer(layer *wasimoff.Task_Layer) error {
+	if layer.GetArchive() == nil {
+		return fmt.Errorf("archive is missing")
+	}
+	if mount := layer.GetMount(); mount != "" && (!path.IsAbs(mount) || path.Clean(mount) != mount) {
+		return fmt.Errorf("mount path %q must be absolute and clean", mount)
+	}
+	return nil
+}
+
+// NormalizeRootfs converts a resolved rootfs archive to the zip layout, which is
+// expected by Providers, and updates the File in place. Wheels are zip archives
+// already. Files without a media type are passed through unchanged.
+func (fs *FileStorage) NormalizeRootfs(pbf *wasimoff.File) error {
+	if pbf == nil || pbf.GetMedia() == "" {
+		return nil
+	}
+	switch pbf.GetMedia() {
+	case MediaZip:
+		return nil
+	case MediaWheel:
+		pbf.Media = proto.String(MediaZip)
+		return nil
+	case MediaTar, MediaGzip:
+		// convert below
+	default:
+		return fmt.Errorf("rootfs must be a zip or tar archive, not %s", pbf.GetMedia())
+	}
+	gzipped := pbf.GetMedia() == MediaGzip
+
+	// convert an inline archive in memory
+	if pbf.Blob != nil {
+		var buf bytes.Buffer
+		if err := tarToZip(&buf, bytes.NewReader(pbf.Blob), gzipped, fs.MaxUnpackedSize); err != nil {
+			return fmt.Errorf("converting rootfs failed: %w", err)
+		}
+		pbf.Blob, pbf.Media = buf.Bytes(), proto.String(MediaZip)
+		return nil
+	}
+
+	// otherwise convert the archive in storage once
+	ref, err := fs.convert(pbf.GetRef(), gzipped)
+	if err != nil {
+		return fmt.Errorf("converting rootfs failed: %w", err)
+	}
+	pbf.Ref, pbf.Media = proto.String(ref), proto.String(MediaZip)
+	return nil
+}
+
+// convert a tar archive in storage to a zip archive and return its ref. Previous
+// conversions are reused, as long as their result wasn't collected yet.
+func (fs *FileStorage) convert(ref string, gzipped bool) (string, error) {
+	defer fs.converted.lock(ref)()
+	if zipref, ok := fs.converted.cached(ref); ok {
+		if _, err := fs.Stat(zipref); err == nil {
+			fs.index.touch(zipref)
+			return zipref, nil
+		}
+	}
+
+	file, err := fs.Open(ref)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	// stage the converted archive, it is inspected again when inserting it
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarToZip(pw, file, gzipped, fs.MaxUnpackedSize))
+	}()
+	staged, _, _, err := spool(pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		return "", err
+	}
+	defer os.Remove(staged.Name())
+	defer staged.Close()
+	info, _, err := fs.insertReader("", FileInfo{Media: MediaZip}, staged)
+	if err != nil {
+		return "", err
+	}
+	fs.converted.remember(ref, info.Ref)
+	return info.Ref, nil
+}
+
+// tarToZip converts a tar archive, optionally gzip-compressed, to a zip archive
+// with the same limits as InspectTar.
+func tarToZip(w io.Writer, r io.Reader, gzipped bool, maxUnpacked int64) error {
+	if gzipped {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("gzip: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	archive := tar.NewReader(r)
+	out := zip.NewWriter(w)
+	files, unpacked := 0, int64(0)
+	for {
+		hdr, err := archive.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("tar: %w", err)
+		}
+		if files++; files > archiveMaxFiles {
+			return fmt.Errorf("tar: more than %d entries", archiveMaxFiles)
+		}
+		if unsafePath(hdr.Name) {
+			return fmt.Errorf("tar: unsafe path %q", hdr.Name)
+		}
+		name := path.Clean(hdr.Name)
+		if name == "." || (hdr.Typeflag != tar.TypeDir && hdr.Typeflag != tar.TypeReg) {
+			continue
+		}
+		if unpacked += hdr.Size; maxUnpacked > 0 && unpacked > maxUnpacked {
+			return fmt.Errorf("tar: unpacks to more than %d bytes", maxUnpacked)
+		}
+
+		header, err := zip.FileInfoHeader(hdr.FileInfo())
+		if err != nil {
+			return fmt.Errorf("zip: %w", err)
+		}
+		header.Name = name
+		if hdr.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+		entry, err := out.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("zip: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err = io.Copy(entry, archive); err != nil {
+				return fmt.Errorf("tar: %w", err)
+			}
+		}
+	}
+	return out.Close()
+}
@@ -0,0 +1,194 @@
+package tracing
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Task traces can be exported in the Chrome trace-event format, which can be
+// loaded into https://ui.perfetto.dev or chrome://tracing to inspect a timeline.
+// Every Provider is a process with one track per concurrently running task, so
+// the tracks approximate its workers. Each task is a slice on such a track with
+// nested slices for the same phases as the OTLP spans.
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+
+type chromeTrace struct {
+	TraceEvents     []chromeEvent `json:"traceEvents"`
+	DisplayTimeUnit string        `json:"displayTimeUnit"`
+}
+
+type chromeEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`
+	Timestamp float64        `json:"ts"` // microseconds
+	Duration  *float64       `json:"dur,omitempty"`
+	Scope     string         `json:"s,omitempty"`
+	Pid       int            `json:"pid"`
+	Tid       int            `json:"tid"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace converts the traces of all given tasks into a single Chrome
+// trace-event JSON file. Tasks without any traced events are skipped. The clock
+// skew correction is applied to copies of the traces.
+func WriteChromeTrace(w io.Writer, tasks []*wasimoff.Task_Metadata) error {
+	type timeline struct {
+		info       *wasimoff.Task_Metadata
+		trace      *wasimoff.Task_Trace
+		events     []*wasimoff.Task_TraceEvent
+		start, end int64
+	}
+
+	// correct the traces and group them by provider
+	byProvider := make(map[string][]timeline)
+	for _, info := range tasks {
+		if len(info.GetTrace().GetEvents()) == 0 {
+			continue
+		}
+		trace := proto.Clone(info.GetTrace()).(*wasimoff.Task_Trace)
+		_ = trace.ClockSkewCorrection()
+		t := timeline{info: info, trace: trace, events: trace.GetEvents()}
+		t.start, t.end = t.events[0].GetUnixnano(), t.events[0].GetUnixnano()
+		for _, e := range t.events {
+			t.start, t.end = min(t.start, e.GetUnixnano()), max(t.end, e.GetUnixnano())
+		}
+		provider := cmp.Or(info.GetProvider(), "unscheduled")
+		byProvider[provider] = append(byProvider[provider], t)
+	}
+	providers := make([]string, 0, len(byProvider))
+	for name := range byProvider {
+		providers = append(providers, name)
+	}
+	slices.Sort(providers)
+
+	out := chromeTrace{TraceEvents: []chromeEvent{}, DisplayTimeUnit: "ms"}
+	for i, provider := range providers {
+		pid := i + 1
+		out.TraceEvents = append(out.TraceEvents, chromeEvent{
+			Name: "process_name", Phase: "M", Pid: pid,
+			Args: map[string]any{"name": "provider " + provider},
+		})
+
+		// place each task on the first track that is free at its start
+		timelines := byProvider[provider]
+		slices.SortStableFunc(timelines, func(a, b timeline) int {
+			return cmp.Compare(a.start, b.start)
+		})
+		var tracks []int64 // end of the last task on each track
+		for _, t := range timelines {
+			tid := slices.IndexFunc(tracks, func(end int64) bool { return end <= t.start })
+			if tid < 0 {
+				tid = len(tracks)
+				tracks = append(tracks, 0)
+				out.TraceEvents = append(out.TraceEvents, chromeEvent{
+					Name: "thread_name", Phase: "M", Pid: pid, Tid: tid,
+					Args: map[string]any{"name": fmt.Sprintf("slot %d", tid)},
+				})
+			}
+			tracks[tid] = t.end
+
+			args := map[string]any{"id": t.info.GetId()}
+			if requester := t.info.GetRequester(); requester != "" {
+				args["requester"] = requester
+			}
+			if ref := t.info.GetReference(); ref != "" {
+				args["reference"] = ref
+			}
+			if ns := t.info.GetNamespace(); ns != "" {
+				args["namespace"] = ns
+			}
+			out.TraceEvents = append(out.TraceEvents, completeEvent(t.info.GetId(), "task", pid, tid, t.start, t.end, args))
+			for _, ph := range wasimoff.TracePhases {
+				s, e, ok := t.trace.Phase(ph)
+				if !ok {
+					continue
+				}
+				out.TraceEvents = append(out.TraceEvents, completeEvent(ph.Name, "phase", pid, tid, s, max(s, e), nil))
+			}
+			for _, e := range t.events {
+				switch e.GetEvent() {
+				case wasimoff.Task_TraceEvent_ClientError, wasimoff.Task_TraceEvent_BrokerError, wasimoff.Task_TraceEvent_ProviderError:
+					out.TraceEvents = append(out.TraceEvents, chromeEvent{
+						Name: e.GetEvent().String(), Category: "error", Phase: "i", Scope: "t",
+						Timestamp: micros(e.GetUnixnano()), Pid: pid, Tid: tid,
+						Args: map[string]any{"details": e.GetDetails()},
+					})
+				}
+			}
+		}
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+func completeEvent(name, category string, pid, tid int, start, end int64, args map[string]any) chromeEvent {
+	duration := micros(end) - micros(start)
+	return chromeEvent{
+		Name: name, Category: category, Phase: "X",
+		Timestamp: micros(start), Duration: &duration,
+		Pid: pid, Tid: tid, Args: args,
+	}
+}
+
+func micros(unixnano int64) float64 {
+	return float64(unixnano) / 1000
+}
+
+// -------------------- history of recent traces -------------------- >>
+
+// History keeps the metadata of the most recently traced tasks in a ring buffer,
+// so their timelines can be exported on demand. A nil History records nothing.
+type History struct {
+	mutex sync.Mutex
+	tasks []*wasimoff.Task_Metadata
+	next  int
+}
+
+// NewHistory returns a History for up to size tasks or nil if size is not positive.
+func NewHistory(size int) *History {
+	if size <= 0 {
+		return nil
+	}
+	return &History{tasks: make([]*wasimoff.Task_Metadata, 0, size)}
+}
+
+// Record a copy of the task metadata, if it contains a trace.
+func (h *History) Record(info *wasimoff.Task_Metadata) {
+	if h == nil || len(info.GetTrace().GetEvents()) == 0 {
+		return
+	}
+	info = proto.Clone(info).(*wasimoff.Task_Metadata)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if len(h.tasks) < cap(h.tasks) {
+		h.tasks = append(h.tasks, info)
+		return
+	}
+	h.tasks[h.next] = info
+	h.next = (h.next + 1) % len(h.tasks)
+}
+
+// Tasks returns the recorded tasks for which keep returns true, oldest first.
+func (h *History) Tasks(keep func(*wasimoff.Task_Metadata) bool) []*wasimoff.Task_Metadata {
+	if h == nil {
+		return nil
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	tasks := make([]*wasimoff.Task_Metadata, 0, len(h.tasks))
+	for i := range h.tasks {
+		info := h.tasks[(h.next+i)%len(h.tasks)]
+		if keep(info) {
+			tasks = append(tasks, info)
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,329 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	wasimoff "wasi.team/proto/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// The Exporter converts completed task traces into spans and sends them to an
+// OpenTelemetry collector, e.g. Jaeger or Tempo. Only a minimal subset of OTLP is
+// implemented: JSON-encoded spans sent over HTTP to the `/v1/traces` endpoint.
+
+const (
+	serviceName = "wasimoff-broker"
+	batchSize   = 512             // maximum spans per request
+	batchDelay  = 5 * time.Second // maximum time to wait for a full batch
+)
+
+// Exporter sends spans in batches in the background. A nil Exporter discards all
+// traces, so it can be used unconditionally.
+type Exporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	queue    chan []span
+	flushed  chan struct{}
+
+	// guards the queue against sends after Shutdown
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// NewExporter starts an Exporter for the given collector URL, e.g. the default
+// `http://localhost:4318`. Headers are added to every request, e.g. for auth.
+func NewExporter(endpoint string, headers map[string]string) *Exporter {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	e := &Exporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		queue:    make(chan []span, 1024),
+		flushed:  make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Export converts a task's trace into spans and queues them for sending. Tasks
+// without a trace are ignored; when the queue is full, the trace is dropped.
+func (e *Exporter) Export(info *wasimoff.Task_Metadata, taskErr error) {
+	if e == nil || info.GetTrace() == nil || len(info.GetTrace().GetEvents()) == 0 {
+		return
+	}
+	spans := taskSpans(info, taskErr)
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.queue <- spans:
+	default:
+		log.Printf("WARN: trace export queue full, dropping trace of task %s", info.GetId())
+	}
+}
+
+// Shutdown sends all queued spans, until the context expires.
+func (e *Exporter) Shutdown(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	e.mutex.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.mutex.Unlock()
+	select {
+	case <-e.flushed:
+	case <-ctx.Done():
+	}
+}
+
+// run collects spans in batches and sends them when a batch is full or old enough.
+func (e *Exporter) run() {
+	defer close(e.flushed)
+	timer := time.NewTimer(batchDelay)
+	batch := make([]span, 0, batchSize)
+	send := func() {
+		if len(batch) > 0 {
+			if err := e.send(batch); err != nil {
+				log.Printf("ERR: trace export: %s", err)
+			}
+			batch = batch[:0]
+		}
+		timer.Reset(batchDelay)
+	}
+	for {
+		select {
+		case spans, ok := <-e.queue:
+			if !ok {
+				send()
+				return
+			}
+			batch = append(batch, spans...)
+			if len(batch) >= batchSize {
+				send()
+			}
+		case <-timer.C:
+			send()
+		}
+	}
+}
+
+// send posts a batch of spans to the collector.
+func (e *Exporter) send(spans []span) error {
+	body, err := json.Marshal(exportRequest{ResourceSpans: []resourceSpans{{
+		Resource: resource{Attributes: []attribute{stringAttribute("service.name", serviceName)}},
+		ScopeSpans: []scopeSpans{{
+			Scope: scope{Name: "wasi.team/broker"},
+			Spans: spans,
+		}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("failed marshalling spans: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector responded %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// -------------------- conversion to spans -------------------- >>
+
+// taskSpans converts a trace into a root span for the whole task and nested spans
+// for the phases of client, broker and provider. All events are attached to the
+// root span, too.
+func taskSpans(info *wasimoff.Task_Metadata, taskErr error) []span {
+	trace := proto.Clone(info.GetTrace()).(*wasimoff.Task_Trace)
+	_ = trace.ClockSkewCorrection() // keep a partial correction of unexpected orders
+	events := trace.GetEvents()
+
+	traceID := randomID(16)
+	attributes := []attribute{
+		stringAttribute("wasimoff.task.id", info.GetId()),
+		stringAttribute("wasimoff.task.requester", info.GetRequester()),
+	}
+	for _, a := range []attribute{
+		stringAttribute("wasimoff.task.provider", info.GetProvider()),
+		stringAttribute("wasimoff.task.reference", info.GetReference()),
+		stringAttribute("wasimoff.task.namespace", info.GetNamespace()),
+	} {
+		if a.Value.StringValue != "" {
+			attributes = append(attributes, a)
+		}
+	}
+
+	// the root span covers all events
+	start, end := events[0].GetUnixnano(), events[0].GetUnixnano()
+	for _, e := range events {
+		start, end = min(start, e.GetUnixnano()), max(end, e.GetUnixnano())
+	}
+	root := span{
+		TraceID:    traceID,
+		SpanID:     randomID(8),
+		Name:       "task",
+		Kind:       spanKindServer,
+		Start:      nanos(start),
+		End:        nanos(end),
+		Attributes: attributes,
+	}
+	for _, e := range events {
+		root.Events = append(root.Events, spanEvent{
+			Time:       nanos(e.GetUnixnano()),
+			Name:       e.GetEvent().String(),
+			Attributes: detailsAttribute(e.GetDetails()),
+		})
+		switch e.GetEvent() {
+		case wasimoff.Task_TraceEvent_ClientError, wasimoff.Task_TraceEvent_BrokerError, wasimoff.Task_TraceEvent_ProviderError:
+			root.Status = &status{Code: statusError, Message: e.GetDetails()}
+		}
+	}
+	if taskErr != nil {
+		root.Status = &status{Code: statusError, Message: taskErr.Error()}
+	}
+
+	// nested spans for each phase
+	spans := []span{root}
+	ids := map[string]string{"task": root.SpanID}
+	for _, ph := range wasimoff.TracePhases {
+		parent, ok := ids[ph.Parent]
+		if !ok {
+			continue
+		}
+		s, e, ok := trace.Phase(ph)
+		if !ok {
+			continue
+		}
+		id := randomID(8)
+		ids[ph.Name] = id
+		spans = append(spans, span{
+			TraceID:      traceID,
+			SpanID:       id,
+			ParentSpanID: parent,
+			Name:         ph.Name,
+			Kind:         spanKindInternal,
+			Start:        nanos(s),
+			End:          nanos(max(s, e)),
+			Attributes:   attributes[:1],
+		})
+	}
+	return spans
+}
+
+func randomID(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func nanos(unixnano int64) string {
+	return strconv.FormatInt(unixnano, 10)
+}
+
+func detailsAttribute(details string) []attribute {
+	if details == "" {
+		return nil
+	}
+	return []attribute{stringAttribute("details", details)}
+}
+
+// -------------------- OTLP JSON encoding -------------------- >>
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID      string      `json:"traceId"`
+	SpanID       string      `json:"spanId"`
+	ParentSpanID string      `json:"parentSpanId,omitempty"`
+	Name         string      `json:"name"`
+	Kind         int         `json:"kind"`
+	Start        string      `json:"startTimeUnixNano"`
+	End          string      `json:"endTimeUnixNano"`
+	Attributes   []attribute `json:"attributes,omitempty"`
+	Events       []spanEvent `json:"events,omitempty"`
+	Status       *status     `json:"status,omitempty"`
+}
+
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+)
+
+type spanEvent struct {
+	Time       string      `json:"timeUnixNano"`
+	Name       string      `json:"name"`
+	Attributes []attribute `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const statusError = 2
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func stringAttribute(key, value string) attribute {
+	return attribute{Key: key, Value: attributeValue{StringValue: value}}
+}
@@ -3,11 +3,13 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/proto"
@@ -23,6 +25,7 @@ type WasimoffClient interface {
 	Upload(buf []byte, name string) (ref string, err error)
 	RunWasip1(ctx context.Context, request *wasimoff.Task_Wasip1_Request) (*wasimoff.Task_Wasip1_Response, error)
 	RunPyodide(ctx context.Context, request *wasimoff.Task_Pyodide_Request) (*wasimoff.Task_Pyodide_Response, error)
+	ListFiles(ctx context.Context, request *wasimoff.Filesystem_List_Request) (*wasimoff.Filesystem_List_Response, error)
 }
 
 //  ConnectRPC
@@ -110,6 +113,49 @@ func (c *WasimoffConnectRpcClient) RunPyodide(ctx context.Context, request *wasi
 	return resp.Msg, nil
 }
 
+// ListFiles returns a page of the named files in storage, which are readable with
+// the client's credentials.
+func (c *WasimoffConnectRpcClient) ListFiles(ctx context.Context, request *wasimoff.Filesystem_List_Request) (*wasimoff.Filesystem_List_Response, error) {
+	resp, err := c.ConnectRPC.ListFiles(ctx, connect.NewRequest(request))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// WithAPIKey returns a copy of the http.Client, which authenticates all requests
+// to the Broker with the given API key.
+func WithAPIKey(httpClient *http.Client, key string) *http.Client {
+	return withHeader(httpClient, "Authorization", "Bearer "+key)
+}
+
+// WithNamespace returns a copy of the http.Client, which selects a storage namespace
+// for all requests, e.g. to upload into a shared namespace.
+func WithNamespace(httpClient *http.Client, namespace string) *http.Client {
+	return withHeader(httpClient, "X-Wasimoff-Namespace", namespace)
+}
+
+func withHeader(httpClient *http.Client, key, value string) *http.Client {
+	c := *httpClient
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.Transport = &headerTransport{base, key, value}
+	return &c
+}
+
+type headerTransport struct {
+	base       http.RoundTripper
+	key, value string
+}
+
+func (t *headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set(t.key, t.value)
+	return t.base.RoundTrip(r)
+}
+
 //  WebSocket
 // ------------------------------------------------------------------------------------
 
@@ -121,6 +167,9 @@ type WasimoffWebsocketClient struct {
 	Messenger *transport.Messenger
 }
 
+// NewWasimoffWebsocketClient connects to the Broker. An API key can be passed as
+// a `?token=` and a storage namespace as a `?namespace=` query parameter in the
+// broker URL.
 func NewWasimoffWebsocketClient(ctx context.Context, broker string) (*WasimoffWebsocketClient, error) {
 
 	// construct path to client endpoint
@@ -148,6 +197,11 @@ func (c *WasimoffWebsocketClient) Upload(buf []byte, name string) (ref string, e
 	// detect mediatype
 	mt := mimetype.Detect(buf)
 
+	// large files are uploaded in chunks
+	if len(buf) > UploadChunkSize {
+		return c.uploadChunked(buf, name, mt.String())
+	}
+
 	resp := &wasimoff.Filesystem_Upload_Response{}
 	err = c.Messenger.RequestSync(
 		c.ctx,
@@ -167,6 +221,54 @@ func (c *WasimoffWebsocketClient) Upload(buf []byte, name string) (ref string, e
 
 }
 
+// UploadChunkSize is the length of chunks when uploading large files over WebSocket.
+const UploadChunkSize = 1 << 20 // 1 MiB
+
+// uploadChunked sends a file in sequential chunks, resuming from any previously
+// interrupted upload of the same file.
+func (c *WasimoffWebsocketClient) uploadChunked(buf []byte, name, media string) (ref string, err error) {
+	ref = sha256Ref(buf)
+	size := uint64(len(buf))
+
+	// query the current offset first
+	request := &wasimoff.Filesystem_Chunk_Upload_Request{
+		Ref:   &ref,
+		Name:  &name,
+		Media: &media,
+		Size:  &size,
+	}
+	resp := &wasimoff.Filesystem_Chunk_Upload_Response{}
+	if err = c.Messenger.RequestSync(c.ctx, request, resp); err != nil {
+		return "", err
+	}
+
+	// send chunks until the broker returns the completed ref
+	for resp.GetRef() == "" {
+		offset := resp.GetOffset()
+		if offset >= size {
+			return "", fmt.Errorf("chunked upload: all bytes sent but no ref returned")
+		}
+		chunk := buf[offset:min(offset+UploadChunkSize, size)]
+		request.Offset = &offset
+		request.Data = chunk
+		request.Digest = proto.String(sha256Ref(chunk))
+		resp.Reset()
+		if err = c.Messenger.RequestSync(c.ctx, request, resp); err != nil {
+			return "", fmt.Errorf("chunked upload at offset %d: %w", offset, err)
+		}
+		if resp.GetRef() == "" && resp.GetOffset() <= offset {
+			return "", fmt.Errorf("chunked upload: chunk at offset %d not accepted", offset)
+		}
+	}
+	return resp.GetRef(), nil
+
+}
+
+// sha256Ref computes the content address of a buffer like the broker does.
+func sha256Ref(buf []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(buf))
+}
+
 func (c *WasimoffWebsocketClient) RunWasip1(ctx context.Context, request *wasimoff.Task_Wasip1_Request) (response *wasimoff.Task_Wasip1_Response, err error) {
 	request.GetInfo().TraceEvent(wasimoff.Task_TraceEvent_ClientTransmitRequest)
 	response = &wasimoff.Task_Wasip1_Response{}
@@ -183,6 +285,25 @@ func (c *WasimoffWebsocketClient) RunPyodide(ctx context.Context, request *wasim
 	return
 }
 
+// ListFiles returns a page of the named files in storage, which are readable with
+// the client's credentials.
+func (c *WasimoffWebsocketClient) ListFiles(ctx context.Context, request *wasimoff.Filesystem_List_Request) (response *wasimoff.Filesystem_List_Response, err error) {
+	response = &wasimoff.Filesystem_List_Response{}
+	err = c.Messenger.RequestSync(ctx, request, response)
+	return
+}
+
+// SubscribeClusterEvents asks the Broker to send ClusterInfo and Throughput events
+// at the given interval, which then arrive on the Messenger's Events() channel. An
+// interval of zero unsubscribes. Returns the effective interval.
+func (c *WasimoffWebsocketClient) SubscribeClusterEvents(ctx context.Context, interval time.Duration) (time.Duration, error) {
+	response := &wasimoff.Event_Subscribe_Response{}
+	err := c.Messenger.RequestSync(ctx, &wasimoff.Event_Subscribe_Request{
+		Interval: proto.Uint32(uint32(interval.Milliseconds())),
+	}, response)
+	return time.Duration(response.GetInterval()) * time.Millisecond, err
+}
+
 //  example Helpers on interface
 // ------------------------------------------------------------------------------------
 
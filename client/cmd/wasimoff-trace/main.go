@@ -0,0 +1,216 @@
+package main
+
+// Analyze task traces, which were recorded by tracebench as delimited Task_Metadata
+// messages. Prints the latency breakdown per phase with percentiles, optionally
+// per Provider, and writes a time-series CSV file for plotting or a Chrome
+// trace-event file to inspect the timeline in Perfetto.
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"wasi.team/broker/tracing"
+	wasimoff "wasi.team/proto/v1"
+)
+
+var quantiles = []float64{0.5, 0.9, 0.99}
+
+func main() {
+
+	csvfile := flag.String("csv", "", "write per-task phase durations to this CSV file")
+	chromefile := flag.String("chrome", "", "write the task timelines to this Chrome trace-event JSON file")
+	perProvider := flag.Bool("providers", false, "compare the latency breakdown per Provider")
+	noSkew := flag.Bool("noskew", false, "do not apply clock skew correction")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [tracebench.pb ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// read traces from all files or stdin
+	var infos []*wasimoff.Task_Metadata
+	read := func(name string, r io.Reader) {
+		n, err := readTraces(bufio.NewReader(r), func(info *wasimoff.Task_Metadata) {
+			infos = append(infos, info)
+		})
+		if err != nil {
+			log.Fatalf("read %s: message %d: %v", name, n, err)
+		}
+	}
+	if flag.NArg() == 0 {
+		read("stdin", os.Stdin)
+	}
+	for _, filename := range flag.Args() {
+		file, err := os.Open(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		read(filename, file)
+		file.Close()
+	}
+	if len(infos) == 0 {
+		log.Fatal("no traced tasks found")
+	}
+
+	// write the timeline before correcting the traces in place, which the
+	// export does on its own copies
+	if *chromefile != "" {
+		if err := writeChrome(*chromefile, infos); err != nil {
+			log.Fatalf("write chrome trace: %v", err)
+		}
+	}
+
+	// measure the phases
+	tasks := make([]measurement, 0, len(infos))
+	skewed := 0
+	for _, info := range infos {
+		if !*noSkew && info.Trace.ClockSkewCorrection() != nil {
+			skewed++
+		}
+		tasks = append(tasks, measure(info))
+	}
+	slices.SortStableFunc(tasks, func(a, b measurement) int {
+		return a.start.Compare(b.start)
+	})
+	if skewed > 0 {
+		log.Printf("WARN: %d traces in unexpected order, clock skew correction incomplete", skewed)
+	}
+
+	// print the breakdown over all tasks
+	first, last := tasks[0].start, tasks[len(tasks)-1].start
+	fmt.Printf("%d tasks over %s\n\n", len(tasks), last.Sub(first).Round(time.Millisecond))
+	printBreakdown(tasks)
+
+	// compare the breakdown of each provider
+	if *perProvider {
+		byProvider := make(map[string][]measurement)
+		for _, m := range tasks {
+			byProvider[m.provider] = append(byProvider[m.provider], m)
+		}
+		names := make([]string, 0, len(byProvider))
+		for name := range byProvider {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			fmt.Printf("\nProvider %s: %d tasks\n\n", name, len(byProvider[name]))
+			printBreakdown(byProvider[name])
+		}
+	}
+
+	// write the time-series
+	if *csvfile != "" {
+		if err := writeCsv(*csvfile, first, tasks); err != nil {
+			log.Fatalf("write csv: %v", err)
+		}
+	}
+
+}
+
+// readTraces unmarshals delimited Task_Metadata messages until EOF and calls fn
+// for each task with a trace. Returns the number of messages.
+func readTraces(r protodelim.Reader, fn func(*wasimoff.Task_Metadata)) (n int, err error) {
+	for ; ; n++ {
+		info := &wasimoff.Task_Metadata{}
+		err := protodelim.UnmarshalFrom(r, info)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("protodelim: %w", err)
+		}
+		if len(info.GetTrace().GetEvents()) == 0 {
+			continue
+		}
+		fn(info)
+	}
+}
+
+// printBreakdown prints a table with the duration statistics of each phase in milliseconds.
+func printBreakdown(tasks []measurement) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "phase\tcount\tmean\tp50\tp90\tp99\tmax\t")
+	for _, ph := range phases {
+		values := make([]float64, 0, len(tasks))
+		for _, m := range tasks {
+			if d, ok := m.phases[ph.name]; ok {
+				values = append(values, millis(d))
+			}
+		}
+		if len(values) == 0 {
+			fmt.Fprintf(tw, "%s\t0\t-\t-\t-\t-\t-\t\n", ph.name)
+			continue
+		}
+		slices.Sort(values)
+		fmt.Fprintf(tw, "%s\t%d\t%.3f", ph.name, len(values), stat.Mean(values, nil))
+		for _, q := range quantiles {
+			fmt.Fprintf(tw, "\t%.3f", stat.Quantile(q, stat.Empirical, values, nil))
+		}
+		fmt.Fprintf(tw, "\t%.3f\t\n", values[len(values)-1])
+	}
+	tw.Flush()
+}
+
+// writeCsv writes one row per task with its start relative to the first task in
+// seconds and the phase durations in milliseconds. Missing phases are left empty.
+func writeCsv(filename string, first time.Time, tasks []measurement) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	header := []string{"start", "id", "provider"}
+	for _, ph := range phases {
+		header = append(header, ph.name)
+	}
+	w.Write(header)
+	for _, m := range tasks {
+		row := []string{
+			strconv.FormatFloat(m.start.Sub(first).Seconds(), 'f', 6, 64),
+			m.id,
+			m.provider,
+		}
+		for _, ph := range phases {
+			if d, ok := m.phases[ph.name]; ok {
+				row = append(row, strconv.FormatFloat(millis(d), 'f', 3, 64))
+			} else {
+				row = append(row, "")
+			}
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeChrome exports the task timelines in the Chrome trace-event format.
+func writeChrome(filename string, infos []*wasimoff.Task_Metadata) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := tracing.WriteChromeTrace(file, infos); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	wasimoff "wasi.team/proto/v1"
+)
+
+// A phase of the breakdown combines the shared trace phases by name: it is the
+// sum of the phases in add minus the ones in sub, e.g. the transfer in both
+// directions or the Provider's overhead around the worker.
+type phase struct {
+	name     string
+	add, sub []string
+}
+
+var phases = []phase{
+	{"total", []string{"client.request", "broker", "client.response"}, nil},
+	{"queue", []string{"broker.queue"}, nil},
+	{"schedule", []string{"broker.schedule"}, nil},
+	{"transfer", []string{"broker.transmit", "provider.result"}, nil},
+	{"provider", []string{"provider"}, []string{"worker.prepare", "worker.execute"}},
+	{"prepare", []string{"worker.prepare"}, nil},
+	{"execute", []string{"worker.execute"}, nil},
+}
+
+// measurement holds the phase durations of a single task. A phase is missing if
+// any of its events was not traced.
+type measurement struct {
+	id       string
+	provider string
+	start    time.Time
+	phases   map[string]time.Duration
+}
+
+// measure computes the phase durations of a task trace.
+func measure(info *wasimoff.Task_Metadata) measurement {
+	trace := info.GetTrace()
+	m := measurement{
+		id:       info.GetId(),
+		provider: info.GetProvider(),
+		start:    time.Unix(0, trace.GetCreated()),
+		phases:   make(map[string]time.Duration, len(phases)),
+	}
+	if m.provider == "" {
+		m.provider = "unknown"
+	}
+
+	// durations of the shared phases, which were traced completely
+	traced := make(map[string]time.Duration, len(wasimoff.TracePhases))
+	for _, ph := range wasimoff.TracePhases {
+		if s, e, ok := trace.Phase(ph); ok {
+			traced[ph.Name] = time.Duration(max(0, e-s))
+		}
+	}
+
+	for _, ph := range phases {
+		var sum time.Duration
+		complete := true
+		for i, name := range append(ph.add, ph.sub...) {
+			d, ok := traced[name]
+			if !ok {
+				complete = false
+				break
+			}
+			if i < len(ph.add) {
+				sum += d
+			} else {
+				sum -= d
+			}
+		}
+		if complete {
+			m.phases[ph.name] = max(0, sum)
+		}
+	}
+	return m
+}
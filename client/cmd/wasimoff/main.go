@@ -1,6 +1,7 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/base64"
 	"flag"
@@ -8,9 +9,11 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"wasi.team/client"
@@ -28,11 +31,15 @@ var ( // config flags
 	readstdin = false                   // read stdin for exec
 	websock   = false                   // use websocket to send tasks
 	rootfs    = ""                      // include a rootfs in exec
+	layers    []*wasimoff.Task_Layer    // additional filesystem layers in exec
 	trace     = false                   // enable tracing on task
+	apikey    = ""                      // authenticate with an api key
+	namespace = ""                      // select a storage namespace
 )
 
 var ( // command flags, pick one
 	cmdUpload  = ""    // upload this file
+	cmdList    = false // list files in storage
 	cmdExec    = false // execute cmdline in wasip1
 	cmdRunTask = ""    // run prepared task json
 	cmdPyodide = ""    // run python file
@@ -43,6 +50,8 @@ func init() {
 	if url, ok := os.LookupEnv("BROKER"); ok {
 		brokerUrl = strings.TrimRight(url, "/")
 	}
+	// get an api key from env
+	apikey = os.Getenv("WASIMOFF_API_KEY")
 }
 
 var c client.WasimoffClient
@@ -51,7 +60,8 @@ func main() {
 
 	// commandline parser
 	flag.StringVar(&brokerUrl, "broker", brokerUrl, "URL to the Broker to use")
-	flag.StringVar(&cmdUpload, "upload", "", "Upload a file (wasm or zip) to the Broker and receive its ref")
+	flag.StringVar(&cmdUpload, "upload", "", "Upload a file (wasm, zip, tar, wheel or data) to the Broker and receive its ref")
+	flag.BoolVar(&cmdList, "ls", false, "List uploaded files, optionally filtered by a name prefix argument")
 	flag.BoolVar(&cmdExec, "exec", false, "Execute an uploaded binary by passing all non-flag args")
 	flag.StringVar(&cmdPyodide, "pyodide", "", "Run a Python script file with Pyodide")
 	flag.StringVar(&cmdRunTask, "task", "", "Run a prepared JSON task file (either Wasip1 or Pyodide)")
@@ -59,19 +69,47 @@ func main() {
 	flag.BoolVar(&readstdin, "stdin", readstdin, "Read and send stdin when using -exec (not streamed)")
 	flag.BoolVar(&websock, "ws", websock, "Use a WebSocket to connect to Broker")
 	flag.StringVar(&rootfs, "rootfs", rootfs, "Use a rootfs ZIP in -exec task")
+	flag.Func("layer", "Add a filesystem layer as `ref=/mount:ro` in -exec task (repeatable)", func(spec string) error {
+		layer, err := wasimoff.ParseLayer(spec)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, layer)
+		return nil
+	})
 	flag.BoolVar(&trace, "trace", trace, "Collect timestamps during task lifetime")
+	flag.StringVar(&apikey, "apikey", apikey, "API key to authenticate with the Broker")
+	flag.StringVar(&namespace, "namespace", namespace, "Storage namespace for uploads and names")
 	flag.Parse()
 
 	// establish a connection to the broker
 	if websock {
-		wc, err := client.NewWasimoffWebsocketClient(context.Background(), brokerUrl)
+		query := url.Values{}
+		if apikey != "" {
+			query.Set("token", apikey)
+		}
+		if namespace != "" {
+			query.Set("namespace", namespace)
+		}
+		wsUrl := brokerUrl
+		if len(query) > 0 {
+			wsUrl += "?" + query.Encode()
+		}
+		wc, err := client.NewWasimoffWebsocketClient(context.Background(), wsUrl)
 		if err != nil {
 			log.Fatalf("ERR: can't connect to Broker: %s", err)
 		} else {
 			c = wc
 		}
 	} else {
-		c = client.NewWasimoffConnectRpcClient(http.DefaultClient, brokerUrl)
+		httpClient := http.DefaultClient
+		if apikey != "" {
+			httpClient = client.WithAPIKey(httpClient, apikey)
+		}
+		if namespace != "" {
+			httpClient = client.WithNamespace(httpClient, namespace)
+		}
+		c = client.NewWasimoffConnectRpcClient(httpClient, brokerUrl)
 	}
 
 	switch true {
@@ -81,6 +119,10 @@ func main() {
 		alias := flag.Arg(0)
 		UploadFile(cmdUpload, alias)
 
+	// list files in storage, optionally take a name prefix
+	case cmdList:
+		ListFiles(flag.Arg(0))
+
 	// execute an ad-hoc command, as if you were to run it locally
 	case cmdExec:
 		envs := []string{}
@@ -97,7 +139,7 @@ func main() {
 
 	// no command specified
 	default:
-		fmt.Fprintln(os.Stderr, "ERR: at least one of -upload, -exec, -run, -runpy must be used")
+		fmt.Fprintln(os.Stderr, "ERR: at least one of -upload, -ls, -exec, -task, -pyodide must be used")
 		flag.Usage()
 		os.Exit(2)
 	}
@@ -128,6 +170,36 @@ func UploadFile(filename, name string) {
 
 }
 
+// list all files in storage, page by page
+func ListFiles(prefix string) {
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tREF\tMEDIA\tSIZE\tUPLOADED\tUPLOADER")
+	request := &wasimoff.Filesystem_List_Request{Prefix: &prefix}
+	for {
+		response, err := c.ListFiles(context.Background(), request)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, f := range response.GetFiles() {
+			uploaded := "-"
+			if f.Uploaded != nil {
+				uploaded = f.GetUploaded().AsTime().Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", f.GetName(), f.GetRef(), f.GetMedia(),
+				f.GetSize(), uploaded, cmp.Or(f.GetUploader(), "-"))
+		}
+		if response.GetNext() == "" {
+			break
+		}
+		request.Cursor = proto.String(response.GetNext())
+	}
+	tw.Flush()
+	os.Exit(0)
+
+}
+
 // execute an ad-hoc command line
 func Execute(args, envs []string) {
 	if len(args) == 0 {
@@ -154,10 +226,11 @@ func Execute(args, envs []string) {
 		request.Params.Stdin = stdin
 	}
 
-	// optionally add rootfs ref
+	// optionally add rootfs ref and layers
 	if rootfs != "" {
 		request.Params.Rootfs = &wasimoff.File{Ref: &rootfs}
 	}
+	request.Params.Layers = layers
 
 	// optionally enable tracing
 	if trace {
@@ -300,7 +373,9 @@ func maybePrintTrace(info *wasimoff.Task_Metadata) {
 		info.Trace = nil
 
 		// perform span correction
-		trace.ClockSkewCorrection()
+		if err := trace.ClockSkewCorrection(); err != nil {
+			fmt.Fprintf(os.Stderr, "\033[33mclock skew correction incomplete: %s\033[0m\n", err)
+		}
 
 		// print metadata and the list of steps
 		fmt.Fprintf(os.Stderr, "\033[1mTask Metadata:\n\033[0;36m%s\033[0m\n", prototext.Format(info))
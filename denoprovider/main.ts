@@ -47,6 +47,13 @@ const flags: (FlagOptions & { help?: string })[] = [
       return v;
     },
   },
+  {
+    name: "token",
+    type: "string",
+    aliases: ["t"],
+    default: undefined,
+    help: "Enrollment token or JWT for the Broker",
+  },
   {
     name: "storage",
     type: "string",
@@ -83,7 +90,7 @@ if (args.help) {
   manual();
   Deno.exit(0);
 }
-console.log(args);
+console.log({ ...args, token: args.token && "<redacted>" });
 
 // get random client ID from localStorage
 let id: string | null;
@@ -101,21 +108,26 @@ try {
 console.log("%c[Wasimoff]", "color: red;", "starting Deno Provider");
 let fs: ProviderStorageFileSystem = new MemoryFileSystem();
 if (args.storage !== undefined) fs = await DenoFileSystem.open(args.storage);
-const provider = await WasimoffProvider.init(args.workers, args.url, fs, id);
+const provider = await WasimoffProvider.init(args.workers, args.url, fs, id, args.token);
 const workers = await provider.pool.scale();
 
 // run initial benchmark
 // await provider.runBenchmark();
 
 // register signal handler for clean exits
-new Terminator(provider.pool, 30_000, (_) => provider.disconnect());
+new Terminator(
+  provider.pool,
+  30_000,
+  (_) => provider.disconnect(),
+  () => provider.drain("terminated"),
+);
 
 // retry loop for provider connection
 for (;;) {
   // maybe reconnect
   if (provider.messenger && provider.messenger.closed.aborted) {
     try {
-      await provider.connect(args.url, id);
+      await provider.connect(args.url, id, args.token);
     } catch (_) {
       console.error("%c[Wasimoff]", "color: red;", "failed to reconnect!");
       await new Promise((r) => setTimeout(r, 3000));
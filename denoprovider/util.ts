@@ -22,6 +22,7 @@ export class Terminator {
     private pool: WasiWorkerPool,
     private readonly grace = 30_000,
     private beforeexit?: (forced: boolean) => void | Promise<void>,
+    private beforedrain?: () => void | Promise<void>,
   ) {
     // handle SIGTERM (15) and SIGINT (2) the same
     const terminator = () => {
@@ -45,7 +46,12 @@ export class Terminator {
     this.forcequit = true;
     if (this.grace > 0) setTimeout(this.terminate, this.grace);
 
-    // wait to finish all current tasks, then quit
+    // stop receiving new tasks, wait to finish all current tasks, then quit
+    try {
+      if (this.beforedrain) await this.beforedrain();
+    } catch {
+      /* the connection may be lost already */
+    }
     await this.pool.scale(0);
     await new Promise((r) => setTimeout(r, 50)); // ~ flush messages
     if (this.beforeexit) await this.beforeexit(false);
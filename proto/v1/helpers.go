@@ -1,5 +1,12 @@
 package wasimoffv1
 
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
 // Additional helpers on the generated types.
 
 // Fill any unset (nil) task parameters from a parent task specification.
@@ -26,12 +33,18 @@ func (wt *Task_Wasip1_Params) InheritNil(parent *Task_Wasip1_Params) *Task_Wasip
 	if wt.Artifacts == nil {
 		wt.Artifacts = parent.Artifacts
 	}
+	if wt.Layers == nil {
+		wt.Layers = parent.Layers
+	}
+	if wt.StdinFile == nil {
+		wt.StdinFile = parent.StdinFile
+	}
 	return wt
 }
 
 // Return a string list of needed files for a task request.
 func (tr *Task_Wasip1_Request) GetRequiredFiles() (files []string) {
-	files = make([]string, 0, 2) // usually max. binary + rootfs
+	files = make([]string, 0, 3+len(tr.GetParams().GetLayers())) // binary + rootfs + stdin + layers
 	p := tr.Params
 
 	if p.Binary != nil && p.Binary.GetRef() != "" {
@@ -40,6 +53,31 @@ func (tr *Task_Wasip1_Request) GetRequiredFiles() (files []string) {
 	if p.Rootfs != nil && p.Rootfs.GetRef() != "" {
 		files = append(files, *p.Rootfs.Ref)
 	}
+	if p.StdinFile != nil && p.StdinFile.GetRef() != "" {
+		files = append(files, *p.StdinFile.Ref)
+	}
+	for _, layer := range p.Layers {
+		if ref := layer.GetArchive().GetRef(); ref != "" {
+			files = append(files, ref)
+		}
+	}
 
 	return files
 }
+
+// Parse a layer from a string like `ref=/mount:ro`, where the mount path and the
+// read-only suffix are optional.
+func ParseLayer(spec string) (*Task_Layer, error) {
+	layer := &Task_Layer{}
+	if i := strings.LastIndex(spec, "="); i >= 0 && strings.HasPrefix(spec[i+1:], "/") {
+		spec, layer.Mount = spec[:i], proto.String(spec[i+1:])
+		if mount, ok := strings.CutSuffix(layer.GetMount(), ":ro"); ok {
+			layer.Mount, layer.Readonly = proto.String(mount), proto.Bool(true)
+		}
+	}
+	if spec == "" {
+		return nil, fmt.Errorf("layer without archive ref")
+	}
+	layer.Archive = &File{Ref: proto.String(spec)}
+	return layer, nil
+}
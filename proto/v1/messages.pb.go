@@ -557,6 +557,7 @@ type Task_Metadata struct {
 	Provider      *string                `protobuf:"bytes,3,opt,name=provider" json:"provider,omitempty"`   // which provider executed this task
 	Reference     *string                `protobuf:"bytes,4,opt,name=reference" json:"reference,omitempty"` // identifier given by client
 	Trace         *Task_Trace            `protobuf:"bytes,5,opt,name=trace" json:"trace,omitempty"`         // existence signals that events should be traced
+	Namespace     *string                `protobuf:"bytes,6,opt,name=namespace" json:"namespace,omitempty"` // tenant namespace of the requester
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -626,6 +627,13 @@ func (x *Task_Metadata) GetTrace() *Task_Trace {
 	return nil
 }
 
+func (x *Task_Metadata) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
 // Quality of Service (QoS) parameters for a given task.
 type Task_QoS struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -861,29 +869,30 @@ func (x *Task_Cancel) GetReason() string {
 	return ""
 }
 
-//	WebAssembly System Interface (WASI), preview1
-//
-// ===============================================
-type Task_Wasip1 struct {
+// Resume is sent by the Broker after a Provider reconnected with a session token.
+// It lists all tasks that were running when the previous connection was lost and
+// whose results are still expected. The Provider answers with those task IDs that
+// it does not know about anymore, so they can be retried elsewhere.
+type Task_Resume struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1) Reset() {
-	*x = Task_Wasip1{}
+func (x *Task_Resume) Reset() {
+	*x = Task_Resume{}
 	mi := &file_proto_v1_messages_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1) String() string {
+func (x *Task_Resume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Wasip1) ProtoMessage() {}
+func (*Task_Resume) ProtoMessage() {}
 
-func (x *Task_Wasip1) ProtoReflect() protoreflect.Message {
+func (x *Task_Resume) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_messages_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -895,34 +904,33 @@ func (x *Task_Wasip1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Wasip1.ProtoReflect.Descriptor instead.
-func (*Task_Wasip1) Descriptor() ([]byte, []int) {
+// Deprecated: Use Task_Resume.ProtoReflect.Descriptor instead.
+func (*Task_Resume) Descriptor() ([]byte, []int) {
 	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5}
 }
 
-//	Pyodide Python scripts
-//
-// ========================
-type Task_Pyodide struct {
+// Deliver is sent by a Provider on a resumed session to return the result of a
+// task, whose original Request was received on a previous connection.
+type Task_Deliver struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide) Reset() {
-	*x = Task_Pyodide{}
+func (x *Task_Deliver) Reset() {
+	*x = Task_Deliver{}
 	mi := &file_proto_v1_messages_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Pyodide) String() string {
+func (x *Task_Deliver) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Pyodide) ProtoMessage() {}
+func (*Task_Deliver) ProtoMessage() {}
 
-func (x *Task_Pyodide) ProtoReflect() protoreflect.Message {
+func (x *Task_Deliver) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_messages_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -934,38 +942,37 @@ func (x *Task_Pyodide) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Pyodide.ProtoReflect.Descriptor instead.
-func (*Task_Pyodide) Descriptor() ([]byte, []int) {
+// Deprecated: Use Task_Deliver.ProtoReflect.Descriptor instead.
+func (*Task_Deliver) Descriptor() ([]byte, []int) {
 	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6}
 }
 
-// Parameters to instantiate a WebAssembly WASI preview 1 task.
-type Task_Wasip1_Params struct {
+// Layer is an archive, which is unpacked at a mount path in the filesystem of a
+// task. The rootfs is the base layer at "/" and further layers are merged on top
+// in order, so later layers overwrite the files of earlier ones.
+type Task_Layer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Binary        *File                  `protobuf:"bytes,1,opt,name=binary" json:"binary,omitempty"`
-	Args          []string               `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
-	Envs          []string               `protobuf:"bytes,3,rep,name=envs" json:"envs,omitempty"`
-	Stdin         []byte                 `protobuf:"bytes,4,opt,name=stdin" json:"stdin,omitempty"`
-	Rootfs        *File                  `protobuf:"bytes,5,opt,name=rootfs" json:"rootfs,omitempty"`
-	Artifacts     []string               `protobuf:"bytes,6,rep,name=artifacts" json:"artifacts,omitempty"`
+	Archive       *File                  `protobuf:"bytes,1,opt,name=archive" json:"archive,omitempty"`    // zip or tar archive
+	Mount         *string                `protobuf:"bytes,2,opt,name=mount" json:"mount,omitempty"`        // absolute path to unpack at, "/" if empty
+	Readonly      *bool                  `protobuf:"varint,3,opt,name=readonly" json:"readonly,omitempty"` // files of this layer can't be modified by the task
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1_Params) Reset() {
-	*x = Task_Wasip1_Params{}
+func (x *Task_Layer) Reset() {
+	*x = Task_Layer{}
 	mi := &file_proto_v1_messages_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1_Params) String() string {
+func (x *Task_Layer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Wasip1_Params) ProtoMessage() {}
+func (*Task_Layer) ProtoMessage() {}
 
-func (x *Task_Wasip1_Params) ProtoReflect() protoreflect.Message {
+func (x *Task_Layer) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_messages_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -977,79 +984,55 @@ func (x *Task_Wasip1_Params) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Wasip1_Params.ProtoReflect.Descriptor instead.
-func (*Task_Wasip1_Params) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 0}
-}
-
-func (x *Task_Wasip1_Params) GetBinary() *File {
-	if x != nil {
-		return x.Binary
-	}
-	return nil
-}
-
-func (x *Task_Wasip1_Params) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-func (x *Task_Wasip1_Params) GetEnvs() []string {
-	if x != nil {
-		return x.Envs
-	}
-	return nil
+// Deprecated: Use Task_Layer.ProtoReflect.Descriptor instead.
+func (*Task_Layer) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 7}
 }
 
-func (x *Task_Wasip1_Params) GetStdin() []byte {
+func (x *Task_Layer) GetArchive() *File {
 	if x != nil {
-		return x.Stdin
+		return x.Archive
 	}
 	return nil
 }
 
-func (x *Task_Wasip1_Params) GetRootfs() *File {
-	if x != nil {
-		return x.Rootfs
+func (x *Task_Layer) GetMount() string {
+	if x != nil && x.Mount != nil {
+		return *x.Mount
 	}
-	return nil
+	return ""
 }
 
-func (x *Task_Wasip1_Params) GetArtifacts() []string {
-	if x != nil {
-		return x.Artifacts
+func (x *Task_Layer) GetReadonly() bool {
+	if x != nil && x.Readonly != nil {
+		return *x.Readonly
 	}
-	return nil
+	return false
 }
 
-// The result of an execution from a Wasip1.Params message. It should only be
-// returned if the WebAssembly module was instantiated successfully at all.
-type Task_Wasip1_Output struct {
+//	WebAssembly System Interface (WASI), preview1
+//
+// ===============================================
+type Task_Wasip1 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        *int32                 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
-	Stdout        []byte                 `protobuf:"bytes,2,opt,name=stdout" json:"stdout,omitempty"`
-	Stderr        []byte                 `protobuf:"bytes,3,opt,name=stderr" json:"stderr,omitempty"`
-	Artifacts     *File                  `protobuf:"bytes,4,opt,name=artifacts" json:"artifacts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1_Output) Reset() {
-	*x = Task_Wasip1_Output{}
+func (x *Task_Wasip1) Reset() {
+	*x = Task_Wasip1{}
 	mi := &file_proto_v1_messages_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1_Output) String() string {
+func (x *Task_Wasip1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Wasip1_Output) ProtoMessage() {}
+func (*Task_Wasip1) ProtoMessage() {}
 
-func (x *Task_Wasip1_Output) ProtoReflect() protoreflect.Message {
+func (x *Task_Wasip1) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_messages_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1061,64 +1044,72 @@ func (x *Task_Wasip1_Output) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Wasip1_Output.ProtoReflect.Descriptor instead.
-func (*Task_Wasip1_Output) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 1}
+// Deprecated: Use Task_Wasip1.ProtoReflect.Descriptor instead.
+func (*Task_Wasip1) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 8}
 }
 
-func (x *Task_Wasip1_Output) GetStatus() int32 {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return 0
+//	Pyodide Python scripts
+//
+// ========================
+type Task_Pyodide struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1_Output) GetStdout() []byte {
-	if x != nil {
-		return x.Stdout
-	}
-	return nil
+func (x *Task_Pyodide) Reset() {
+	*x = Task_Pyodide{}
+	mi := &file_proto_v1_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1_Output) GetStderr() []byte {
-	if x != nil {
-		return x.Stderr
-	}
-	return nil
+func (x *Task_Pyodide) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Task_Wasip1_Output) GetArtifacts() *File {
+func (*Task_Pyodide) ProtoMessage() {}
+
+func (x *Task_Pyodide) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[15]
 	if x != nil {
-		return x.Artifacts
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-// Offload a Wasip1 task.
-type Task_Wasip1_Request struct {
+// Deprecated: Use Task_Pyodide.ProtoReflect.Descriptor instead.
+func (*Task_Pyodide) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 9}
+}
+
+type Task_Resume_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Info          *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
-	Qos           *Task_QoS              `protobuf:"bytes,2,opt,name=qos" json:"qos,omitempty"`
-	Params        *Task_Wasip1_Params    `protobuf:"bytes,3,opt,name=params" json:"params,omitempty"`
+	Pending       []string               `protobuf:"bytes,1,rep,name=pending" json:"pending,omitempty"` // task IDs still awaiting a result
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1_Request) Reset() {
-	*x = Task_Wasip1_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[15]
+func (x *Task_Resume_Request) Reset() {
+	*x = Task_Resume_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1_Request) String() string {
+func (x *Task_Resume_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Wasip1_Request) ProtoMessage() {}
+func (*Task_Resume_Request) ProtoMessage() {}
 
-func (x *Task_Wasip1_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[15]
+func (x *Task_Resume_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1129,60 +1120,88 @@ func (x *Task_Wasip1_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Wasip1_Request.ProtoReflect.Descriptor instead.
-func (*Task_Wasip1_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 2}
+// Deprecated: Use Task_Resume_Request.ProtoReflect.Descriptor instead.
+func (*Task_Resume_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 0}
 }
 
-func (x *Task_Wasip1_Request) GetInfo() *Task_Metadata {
+func (x *Task_Resume_Request) GetPending() []string {
 	if x != nil {
-		return x.Info
+		return x.Pending
 	}
 	return nil
 }
 
-func (x *Task_Wasip1_Request) GetQos() *Task_QoS {
+type Task_Resume_Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unknown       []string               `protobuf:"bytes,1,rep,name=unknown" json:"unknown,omitempty"` // task IDs which will never be delivered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task_Resume_Response) Reset() {
+	*x = Task_Resume_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Resume_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Resume_Response) ProtoMessage() {}
+
+func (x *Task_Resume_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[17]
 	if x != nil {
-		return x.Qos
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Task_Wasip1_Request) GetParams() *Task_Wasip1_Params {
+// Deprecated: Use Task_Resume_Response.ProtoReflect.Descriptor instead.
+func (*Task_Resume_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 1}
+}
+
+func (x *Task_Resume_Response) GetUnknown() []string {
 	if x != nil {
-		return x.Params
+		return x.Unknown
 	}
 	return nil
 }
 
-// Response for a single Wasip1 task, which can be an Error or OK.
-type Task_Wasip1_Response struct {
+type Task_Deliver_Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Info  *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
 	// Types that are valid to be assigned to Result:
 	//
-	//	*Task_Wasip1_Response_Error
-	//	*Task_Wasip1_Response_Ok
-	Result        isTask_Wasip1_Response_Result `protobuf_oneof:"result"`
+	//	*Task_Deliver_Request_Wasip1
+	//	*Task_Deliver_Request_Pyodide
+	Result        isTask_Deliver_Request_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Wasip1_Response) Reset() {
-	*x = Task_Wasip1_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[16]
+func (x *Task_Deliver_Request) Reset() {
+	*x = Task_Deliver_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Wasip1_Response) String() string {
+func (x *Task_Deliver_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Wasip1_Response) ProtoMessage() {}
+func (*Task_Deliver_Request) ProtoMessage() {}
 
-func (x *Task_Wasip1_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[16]
+func (x *Task_Deliver_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1193,91 +1212,73 @@ func (x *Task_Wasip1_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Wasip1_Response.ProtoReflect.Descriptor instead.
-func (*Task_Wasip1_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 5, 3}
-}
-
-func (x *Task_Wasip1_Response) GetInfo() *Task_Metadata {
-	if x != nil {
-		return x.Info
-	}
-	return nil
+// Deprecated: Use Task_Deliver_Request.ProtoReflect.Descriptor instead.
+func (*Task_Deliver_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 0}
 }
 
-func (x *Task_Wasip1_Response) GetResult() isTask_Wasip1_Response_Result {
+func (x *Task_Deliver_Request) GetResult() isTask_Deliver_Request_Result {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-func (x *Task_Wasip1_Response) GetError() string {
+func (x *Task_Deliver_Request) GetWasip1() *Task_Wasip1_Response {
 	if x != nil {
-		if x, ok := x.Result.(*Task_Wasip1_Response_Error); ok {
-			return x.Error
+		if x, ok := x.Result.(*Task_Deliver_Request_Wasip1); ok {
+			return x.Wasip1
 		}
 	}
-	return ""
+	return nil
 }
 
-func (x *Task_Wasip1_Response) GetOk() *Task_Wasip1_Output {
+func (x *Task_Deliver_Request) GetPyodide() *Task_Pyodide_Response {
 	if x != nil {
-		if x, ok := x.Result.(*Task_Wasip1_Response_Ok); ok {
-			return x.Ok
+		if x, ok := x.Result.(*Task_Deliver_Request_Pyodide); ok {
+			return x.Pyodide
 		}
 	}
 	return nil
 }
 
-type isTask_Wasip1_Response_Result interface {
-	isTask_Wasip1_Response_Result()
+type isTask_Deliver_Request_Result interface {
+	isTask_Deliver_Request_Result()
 }
 
-type Task_Wasip1_Response_Error struct {
-	Error string `protobuf:"bytes,2,opt,name=error,oneof"`
+type Task_Deliver_Request_Wasip1 struct {
+	Wasip1 *Task_Wasip1_Response `protobuf:"bytes,1,opt,name=wasip1,oneof"`
 }
 
-type Task_Wasip1_Response_Ok struct {
-	Ok *Task_Wasip1_Output `protobuf:"bytes,3,opt,name=ok,oneof"`
+type Task_Deliver_Request_Pyodide struct {
+	Pyodide *Task_Pyodide_Response `protobuf:"bytes,2,opt,name=pyodide,oneof"`
 }
 
-func (*Task_Wasip1_Response_Error) isTask_Wasip1_Response_Result() {}
+func (*Task_Deliver_Request_Wasip1) isTask_Deliver_Request_Result() {}
 
-func (*Task_Wasip1_Response_Ok) isTask_Wasip1_Response_Result() {}
+func (*Task_Deliver_Request_Pyodide) isTask_Deliver_Request_Result() {}
 
-// Parameters to instantiate a Pyodide task.
-type Task_Pyodide_Params struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	Packages []string               `protobuf:"bytes,1,rep,name=packages" json:"packages,omitempty"` // preloaded packages
-	// Types that are valid to be assigned to Run:
-	//
-	//	*Task_Pyodide_Params_Script
-	//	*Task_Pyodide_Params_Pickle
-	Run           isTask_Pyodide_Params_Run `protobuf_oneof:"run"`
-	Envs          []string                  `protobuf:"bytes,4,rep,name=envs" json:"envs,omitempty"`           // environment as ENV=var strings
-	Stdin         []byte                    `protobuf:"bytes,5,opt,name=stdin" json:"stdin,omitempty"`         // buffer for stdin, reading errors if none
-	Rootfs        *File                     `protobuf:"bytes,6,opt,name=rootfs" json:"rootfs,omitempty"`       // zip file to unpack
-	Artifacts     []string                  `protobuf:"bytes,7,rep,name=artifacts" json:"artifacts,omitempty"` // files to pack and send back afterwards
+type Task_Deliver_Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide_Params) Reset() {
-	*x = Task_Pyodide_Params{}
-	mi := &file_proto_v1_messages_proto_msgTypes[17]
+func (x *Task_Deliver_Response) Reset() {
+	*x = Task_Deliver_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Pyodide_Params) String() string {
+func (x *Task_Deliver_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Pyodide_Params) ProtoMessage() {}
+func (*Task_Deliver_Response) ProtoMessage() {}
 
-func (x *Task_Pyodide_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[17]
+func (x *Task_Deliver_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1288,115 +1289,147 @@ func (x *Task_Pyodide_Params) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Pyodide_Params.ProtoReflect.Descriptor instead.
-func (*Task_Pyodide_Params) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 0}
+// Deprecated: Use Task_Deliver_Response.ProtoReflect.Descriptor instead.
+func (*Task_Deliver_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 1}
 }
 
-func (x *Task_Pyodide_Params) GetPackages() []string {
-	if x != nil {
-		return x.Packages
-	}
-	return nil
+// Parameters to instantiate a WebAssembly WASI preview 1 task.
+type Task_Wasip1_Params struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Binary         *File                  `protobuf:"bytes,1,opt,name=binary" json:"binary,omitempty"`
+	Args           []string               `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
+	Envs           []string               `protobuf:"bytes,3,rep,name=envs" json:"envs,omitempty"`
+	Stdin          []byte                 `protobuf:"bytes,4,opt,name=stdin" json:"stdin,omitempty"`
+	Rootfs         *File                  `protobuf:"bytes,5,opt,name=rootfs" json:"rootfs,omitempty"`
+	Artifacts      []string               `protobuf:"bytes,6,rep,name=artifacts" json:"artifacts,omitempty"`
+	StoreArtifacts *bool                  `protobuf:"varint,7,opt,name=store_artifacts,json=storeArtifacts" json:"store_artifacts,omitempty"` // insert artifacts in Broker storage and return their ref
+	Layers         []*Task_Layer          `protobuf:"bytes,8,rep,name=layers" json:"layers,omitempty"`                                        // additional filesystem layers on top of the rootfs
+	StdinFile      *File                  `protobuf:"bytes,9,opt,name=stdin_file,json=stdinFile" json:"stdin_file,omitempty"`                 // large stdin in Broker storage, instead of the inline stdin
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide_Params) GetRun() isTask_Pyodide_Params_Run {
+func (x *Task_Wasip1_Params) Reset() {
+	*x = Task_Wasip1_Params{}
+	mi := &file_proto_v1_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Wasip1_Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Wasip1_Params) ProtoMessage() {}
+
+func (x *Task_Wasip1_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[20]
 	if x != nil {
-		return x.Run
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Task_Pyodide_Params) GetScript() string {
+// Deprecated: Use Task_Wasip1_Params.ProtoReflect.Descriptor instead.
+func (*Task_Wasip1_Params) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 8, 0}
+}
+
+func (x *Task_Wasip1_Params) GetBinary() *File {
 	if x != nil {
-		if x, ok := x.Run.(*Task_Pyodide_Params_Script); ok {
-			return x.Script
-		}
+		return x.Binary
 	}
-	return ""
+	return nil
 }
 
-func (x *Task_Pyodide_Params) GetPickle() []byte {
+func (x *Task_Wasip1_Params) GetArgs() []string {
 	if x != nil {
-		if x, ok := x.Run.(*Task_Pyodide_Params_Pickle); ok {
-			return x.Pickle
-		}
+		return x.Args
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Params) GetEnvs() []string {
+func (x *Task_Wasip1_Params) GetEnvs() []string {
 	if x != nil {
 		return x.Envs
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Params) GetStdin() []byte {
+func (x *Task_Wasip1_Params) GetStdin() []byte {
 	if x != nil {
 		return x.Stdin
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Params) GetRootfs() *File {
+func (x *Task_Wasip1_Params) GetRootfs() *File {
 	if x != nil {
 		return x.Rootfs
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Params) GetArtifacts() []string {
+func (x *Task_Wasip1_Params) GetArtifacts() []string {
 	if x != nil {
 		return x.Artifacts
 	}
 	return nil
 }
 
-type isTask_Pyodide_Params_Run interface {
-	isTask_Pyodide_Params_Run()
+func (x *Task_Wasip1_Params) GetStoreArtifacts() bool {
+	if x != nil && x.StoreArtifacts != nil {
+		return *x.StoreArtifacts
+	}
+	return false
 }
 
-type Task_Pyodide_Params_Script struct {
-	Script string `protobuf:"bytes,2,opt,name=script,oneof"` // plaintext script
+func (x *Task_Wasip1_Params) GetLayers() []*Task_Layer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
 }
 
-type Task_Pyodide_Params_Pickle struct {
-	Pickle []byte `protobuf:"bytes,3,opt,name=pickle,oneof"` // serialized [ func, [args], {kwargs} ]
+func (x *Task_Wasip1_Params) GetStdinFile() *File {
+	if x != nil {
+		return x.StdinFile
+	}
+	return nil
 }
 
-func (*Task_Pyodide_Params_Script) isTask_Pyodide_Params_Run() {}
-
-func (*Task_Pyodide_Params_Pickle) isTask_Pyodide_Params_Run() {}
-
-// The result of an execution from a Pyodide.Params message. It should only be
-// returned if the Pyodide interpreter was started successfully at all.
-type Task_Pyodide_Output struct {
+// The result of an execution from a Wasip1.Params message. It should only be
+// returned if the WebAssembly module was instantiated successfully at all.
+type Task_Wasip1_Output struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pickle        []byte                 `protobuf:"bytes,1,opt,name=pickle" json:"pickle,omitempty"`
+	Status        *int32                 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
 	Stdout        []byte                 `protobuf:"bytes,2,opt,name=stdout" json:"stdout,omitempty"`
 	Stderr        []byte                 `protobuf:"bytes,3,opt,name=stderr" json:"stderr,omitempty"`
-	Version       *string                `protobuf:"bytes,4,opt,name=version" json:"version,omitempty"`
-	Artifacts     *File                  `protobuf:"bytes,5,opt,name=artifacts" json:"artifacts,omitempty"`
+	Artifacts     *File                  `protobuf:"bytes,4,opt,name=artifacts" json:"artifacts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide_Output) Reset() {
-	*x = Task_Pyodide_Output{}
-	mi := &file_proto_v1_messages_proto_msgTypes[18]
+func (x *Task_Wasip1_Output) Reset() {
+	*x = Task_Wasip1_Output{}
+	mi := &file_proto_v1_messages_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Pyodide_Output) String() string {
+func (x *Task_Wasip1_Output) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Pyodide_Output) ProtoMessage() {}
+func (*Task_Wasip1_Output) ProtoMessage() {}
 
-func (x *Task_Pyodide_Output) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[18]
+func (x *Task_Wasip1_Output) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,71 +1440,64 @@ func (x *Task_Pyodide_Output) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Pyodide_Output.ProtoReflect.Descriptor instead.
-func (*Task_Pyodide_Output) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 1}
+// Deprecated: Use Task_Wasip1_Output.ProtoReflect.Descriptor instead.
+func (*Task_Wasip1_Output) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 8, 1}
 }
 
-func (x *Task_Pyodide_Output) GetPickle() []byte {
-	if x != nil {
-		return x.Pickle
+func (x *Task_Wasip1_Output) GetStatus() int32 {
+	if x != nil && x.Status != nil {
+		return *x.Status
 	}
-	return nil
+	return 0
 }
 
-func (x *Task_Pyodide_Output) GetStdout() []byte {
+func (x *Task_Wasip1_Output) GetStdout() []byte {
 	if x != nil {
 		return x.Stdout
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Output) GetStderr() []byte {
+func (x *Task_Wasip1_Output) GetStderr() []byte {
 	if x != nil {
 		return x.Stderr
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Output) GetVersion() string {
-	if x != nil && x.Version != nil {
-		return *x.Version
-	}
-	return ""
-}
-
-func (x *Task_Pyodide_Output) GetArtifacts() *File {
+func (x *Task_Wasip1_Output) GetArtifacts() *File {
 	if x != nil {
 		return x.Artifacts
 	}
 	return nil
 }
 
-// Offload a Pyodide task.
-type Task_Pyodide_Request struct {
+// Offload a Wasip1 task.
+type Task_Wasip1_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Info          *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
 	Qos           *Task_QoS              `protobuf:"bytes,2,opt,name=qos" json:"qos,omitempty"`
-	Params        *Task_Pyodide_Params   `protobuf:"bytes,3,opt,name=params" json:"params,omitempty"`
+	Params        *Task_Wasip1_Params    `protobuf:"bytes,3,opt,name=params" json:"params,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide_Request) Reset() {
-	*x = Task_Pyodide_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[19]
+func (x *Task_Wasip1_Request) Reset() {
+	*x = Task_Wasip1_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Pyodide_Request) String() string {
+func (x *Task_Wasip1_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Pyodide_Request) ProtoMessage() {}
+func (*Task_Wasip1_Request) ProtoMessage() {}
 
-func (x *Task_Pyodide_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[19]
+func (x *Task_Wasip1_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1482,60 +1508,60 @@ func (x *Task_Pyodide_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Pyodide_Request.ProtoReflect.Descriptor instead.
-func (*Task_Pyodide_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 2}
+// Deprecated: Use Task_Wasip1_Request.ProtoReflect.Descriptor instead.
+func (*Task_Wasip1_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 8, 2}
 }
 
-func (x *Task_Pyodide_Request) GetInfo() *Task_Metadata {
+func (x *Task_Wasip1_Request) GetInfo() *Task_Metadata {
 	if x != nil {
 		return x.Info
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Request) GetQos() *Task_QoS {
+func (x *Task_Wasip1_Request) GetQos() *Task_QoS {
 	if x != nil {
 		return x.Qos
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Request) GetParams() *Task_Pyodide_Params {
+func (x *Task_Wasip1_Request) GetParams() *Task_Wasip1_Params {
 	if x != nil {
 		return x.Params
 	}
 	return nil
 }
 
-// Response for a single Pyodide task, which can be an Error or OK.
-type Task_Pyodide_Response struct {
+// Response for a single Wasip1 task, which can be an Error or OK.
+type Task_Wasip1_Response struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Info  *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
 	// Types that are valid to be assigned to Result:
 	//
-	//	*Task_Pyodide_Response_Error
-	//	*Task_Pyodide_Response_Ok
-	Result        isTask_Pyodide_Response_Result `protobuf_oneof:"result"`
+	//	*Task_Wasip1_Response_Error
+	//	*Task_Wasip1_Response_Ok
+	Result        isTask_Wasip1_Response_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task_Pyodide_Response) Reset() {
-	*x = Task_Pyodide_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[20]
+func (x *Task_Wasip1_Response) Reset() {
+	*x = Task_Wasip1_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task_Pyodide_Response) String() string {
+func (x *Task_Wasip1_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task_Pyodide_Response) ProtoMessage() {}
+func (*Task_Wasip1_Response) ProtoMessage() {}
 
-func (x *Task_Pyodide_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[20]
+func (x *Task_Wasip1_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1546,81 +1572,1121 @@ func (x *Task_Pyodide_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task_Pyodide_Response.ProtoReflect.Descriptor instead.
-func (*Task_Pyodide_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 6, 3}
+// Deprecated: Use Task_Wasip1_Response.ProtoReflect.Descriptor instead.
+func (*Task_Wasip1_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 8, 3}
 }
 
-func (x *Task_Pyodide_Response) GetInfo() *Task_Metadata {
+func (x *Task_Wasip1_Response) GetInfo() *Task_Metadata {
 	if x != nil {
 		return x.Info
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Response) GetResult() isTask_Pyodide_Response_Result {
+func (x *Task_Wasip1_Response) GetResult() isTask_Wasip1_Response_Result {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-func (x *Task_Pyodide_Response) GetError() string {
+func (x *Task_Wasip1_Response) GetError() string {
 	if x != nil {
-		if x, ok := x.Result.(*Task_Pyodide_Response_Error); ok {
+		if x, ok := x.Result.(*Task_Wasip1_Response_Error); ok {
 			return x.Error
 		}
 	}
 	return ""
 }
 
-func (x *Task_Pyodide_Response) GetOk() *Task_Pyodide_Output {
+func (x *Task_Wasip1_Response) GetOk() *Task_Wasip1_Output {
 	if x != nil {
-		if x, ok := x.Result.(*Task_Pyodide_Response_Ok); ok {
+		if x, ok := x.Result.(*Task_Wasip1_Response_Ok); ok {
 			return x.Ok
 		}
 	}
 	return nil
 }
 
-type isTask_Pyodide_Response_Result interface {
-	isTask_Pyodide_Response_Result()
+type isTask_Wasip1_Response_Result interface {
+	isTask_Wasip1_Response_Result()
 }
 
-type Task_Pyodide_Response_Error struct {
+type Task_Wasip1_Response_Error struct {
 	Error string `protobuf:"bytes,2,opt,name=error,oneof"`
 }
 
-type Task_Pyodide_Response_Ok struct {
-	Ok *Task_Pyodide_Output `protobuf:"bytes,3,opt,name=ok,oneof"`
+type Task_Wasip1_Response_Ok struct {
+	Ok *Task_Wasip1_Output `protobuf:"bytes,3,opt,name=ok,oneof"`
 }
 
-func (*Task_Pyodide_Response_Error) isTask_Pyodide_Response_Result() {}
+func (*Task_Wasip1_Response_Error) isTask_Wasip1_Response_Result() {}
 
-func (*Task_Pyodide_Response_Ok) isTask_Pyodide_Response_Result() {}
+func (*Task_Wasip1_Response_Ok) isTask_Wasip1_Response_Result() {}
 
-// Listing asks for a listing of all available files on Provider
-type Filesystem_Listing struct {
+// Parameters to instantiate a Pyodide task.
+type Task_Pyodide_Params struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Packages []string               `protobuf:"bytes,1,rep,name=packages" json:"packages,omitempty"` // preloaded packages
+	// Types that are valid to be assigned to Run:
+	//
+	//	*Task_Pyodide_Params_Script
+	//	*Task_Pyodide_Params_Pickle
+	Run            isTask_Pyodide_Params_Run `protobuf_oneof:"run"`
+	Envs           []string                  `protobuf:"bytes,4,rep,name=envs" json:"envs,omitempty"`                                            // environment as ENV=var strings
+	Stdin          []byte                    `protobuf:"bytes,5,opt,name=stdin" json:"stdin,omitempty"`                                          // buffer for stdin, reading errors if none
+	Rootfs         *File                     `protobuf:"bytes,6,opt,name=rootfs" json:"rootfs,omitempty"`                                        // zip or tar archive to unpack
+	Artifacts      []string                  `protobuf:"bytes,7,rep,name=artifacts" json:"artifacts,omitempty"`                                  // files to pack and send back afterwards
+	StoreArtifacts *bool                     `protobuf:"varint,8,opt,name=store_artifacts,json=storeArtifacts" json:"store_artifacts,omitempty"` // insert artifacts in Broker storage and return their ref
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Task_Pyodide_Params) Reset() {
+	*x = Task_Pyodide_Params{}
+	mi := &file_proto_v1_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Pyodide_Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Pyodide_Params) ProtoMessage() {}
+
+func (x *Task_Pyodide_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task_Pyodide_Params.ProtoReflect.Descriptor instead.
+func (*Task_Pyodide_Params) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 9, 0}
+}
+
+func (x *Task_Pyodide_Params) GetPackages() []string {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetRun() isTask_Pyodide_Params_Run {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetScript() string {
+	if x != nil {
+		if x, ok := x.Run.(*Task_Pyodide_Params_Script); ok {
+			return x.Script
+		}
+	}
+	return ""
+}
+
+func (x *Task_Pyodide_Params) GetPickle() []byte {
+	if x != nil {
+		if x, ok := x.Run.(*Task_Pyodide_Params_Pickle); ok {
+			return x.Pickle
+		}
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetEnvs() []string {
+	if x != nil {
+		return x.Envs
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetStdin() []byte {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetRootfs() *File {
+	if x != nil {
+		return x.Rootfs
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Params) GetStoreArtifacts() bool {
+	if x != nil && x.StoreArtifacts != nil {
+		return *x.StoreArtifacts
+	}
+	return false
+}
+
+type isTask_Pyodide_Params_Run interface {
+	isTask_Pyodide_Params_Run()
+}
+
+type Task_Pyodide_Params_Script struct {
+	Script string `protobuf:"bytes,2,opt,name=script,oneof"` // plaintext script
+}
+
+type Task_Pyodide_Params_Pickle struct {
+	Pickle []byte `protobuf:"bytes,3,opt,name=pickle,oneof"` // serialized [ func, [args], {kwargs} ]
+}
+
+func (*Task_Pyodide_Params_Script) isTask_Pyodide_Params_Run() {}
+
+func (*Task_Pyodide_Params_Pickle) isTask_Pyodide_Params_Run() {}
+
+// The result of an execution from a Pyodide.Params message. It should only be
+// returned if the Pyodide interpreter was started successfully at all.
+type Task_Pyodide_Output struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pickle        []byte                 `protobuf:"bytes,1,opt,name=pickle" json:"pickle,omitempty"`
+	Stdout        []byte                 `protobuf:"bytes,2,opt,name=stdout" json:"stdout,omitempty"`
+	Stderr        []byte                 `protobuf:"bytes,3,opt,name=stderr" json:"stderr,omitempty"`
+	Version       *string                `protobuf:"bytes,4,opt,name=version" json:"version,omitempty"`
+	Artifacts     *File                  `protobuf:"bytes,5,opt,name=artifacts" json:"artifacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task_Pyodide_Output) Reset() {
+	*x = Task_Pyodide_Output{}
+	mi := &file_proto_v1_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Pyodide_Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Pyodide_Output) ProtoMessage() {}
+
+func (x *Task_Pyodide_Output) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task_Pyodide_Output.ProtoReflect.Descriptor instead.
+func (*Task_Pyodide_Output) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 9, 1}
+}
+
+func (x *Task_Pyodide_Output) GetPickle() []byte {
+	if x != nil {
+		return x.Pickle
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Output) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Output) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Output) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+func (x *Task_Pyodide_Output) GetArtifacts() *File {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+// Offload a Pyodide task.
+type Task_Pyodide_Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
+	Qos           *Task_QoS              `protobuf:"bytes,2,opt,name=qos" json:"qos,omitempty"`
+	Params        *Task_Pyodide_Params   `protobuf:"bytes,3,opt,name=params" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task_Pyodide_Request) Reset() {
+	*x = Task_Pyodide_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Pyodide_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Pyodide_Request) ProtoMessage() {}
+
+func (x *Task_Pyodide_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task_Pyodide_Request.ProtoReflect.Descriptor instead.
+func (*Task_Pyodide_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 9, 2}
+}
+
+func (x *Task_Pyodide_Request) GetInfo() *Task_Metadata {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Request) GetQos() *Task_QoS {
+	if x != nil {
+		return x.Qos
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Request) GetParams() *Task_Pyodide_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Response for a single Pyodide task, which can be an Error or OK.
+type Task_Pyodide_Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Info  *Task_Metadata         `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*Task_Pyodide_Response_Error
+	//	*Task_Pyodide_Response_Ok
+	Result        isTask_Pyodide_Response_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task_Pyodide_Response) Reset() {
+	*x = Task_Pyodide_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task_Pyodide_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task_Pyodide_Response) ProtoMessage() {}
+
+func (x *Task_Pyodide_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task_Pyodide_Response.ProtoReflect.Descriptor instead.
+func (*Task_Pyodide_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{1, 9, 3}
+}
+
+func (x *Task_Pyodide_Response) GetInfo() *Task_Metadata {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Response) GetResult() isTask_Pyodide_Response_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Task_Pyodide_Response) GetError() string {
+	if x != nil {
+		if x, ok := x.Result.(*Task_Pyodide_Response_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+func (x *Task_Pyodide_Response) GetOk() *Task_Pyodide_Output {
+	if x != nil {
+		if x, ok := x.Result.(*Task_Pyodide_Response_Ok); ok {
+			return x.Ok
+		}
+	}
+	return nil
+}
+
+type isTask_Pyodide_Response_Result interface {
+	isTask_Pyodide_Response_Result()
+}
+
+type Task_Pyodide_Response_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,oneof"`
+}
+
+type Task_Pyodide_Response_Ok struct {
+	Ok *Task_Pyodide_Output `protobuf:"bytes,3,opt,name=ok,oneof"`
+}
+
+func (*Task_Pyodide_Response_Error) isTask_Pyodide_Response_Result() {}
+
+func (*Task_Pyodide_Response_Ok) isTask_Pyodide_Response_Result() {}
+
+// Listing asks for a listing of all available files on Provider
+type Filesystem_Listing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Listing) Reset() {
+	*x = Filesystem_Listing{}
+	mi := &file_proto_v1_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Listing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Listing) ProtoMessage() {}
+
+func (x *Filesystem_Listing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Listing.ProtoReflect.Descriptor instead.
+func (*Filesystem_Listing) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0}
+}
+
+// List returns the named files in storage, which are readable by the Client,
+// ordered by their qualified names and split into pages.
+type Filesystem_List struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_List) Reset() {
+	*x = Filesystem_List{}
+	mi := &file_proto_v1_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_List) ProtoMessage() {}
+
+func (x *Filesystem_List) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_List.ProtoReflect.Descriptor instead.
+func (*Filesystem_List) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1}
+}
+
+// WasmInfo is the metadata extracted from an uploaded WebAssembly binary.
+type Filesystem_WasmInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Component     *bool                  `protobuf:"varint,1,opt,name=component" json:"component,omitempty"`                  // a component instead of a core module
+	Version       *uint32                `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`                      // binary format version
+	Wasi          *string                `protobuf:"bytes,3,opt,name=wasi" json:"wasi,omitempty"`                             // "preview1" if it imports WASI functions
+	Imports       []string               `protobuf:"bytes,4,rep,name=imports" json:"imports,omitempty"`                       // imported functions as `module.name`
+	Exports       []string               `protobuf:"bytes,5,rep,name=exports" json:"exports,omitempty"`                       // exported functions
+	MemoryMin     *uint64                `protobuf:"varint,6,opt,name=memory_min,json=memoryMin" json:"memory_min,omitempty"` // limits of the first memory in 64 KiB pages
+	MemoryMax     *uint64                `protobuf:"varint,7,opt,name=memory_max,json=memoryMax" json:"memory_max,omitempty"` // unset if unbounded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_WasmInfo) Reset() {
+	*x = Filesystem_WasmInfo{}
+	mi := &file_proto_v1_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_WasmInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_WasmInfo) ProtoMessage() {}
+
+func (x *Filesystem_WasmInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_WasmInfo.ProtoReflect.Descriptor instead.
+func (*Filesystem_WasmInfo) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Filesystem_WasmInfo) GetComponent() bool {
+	if x != nil && x.Component != nil {
+		return *x.Component
+	}
+	return false
+}
+
+func (x *Filesystem_WasmInfo) GetVersion() uint32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *Filesystem_WasmInfo) GetWasi() string {
+	if x != nil && x.Wasi != nil {
+		return *x.Wasi
+	}
+	return ""
+}
+
+func (x *Filesystem_WasmInfo) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *Filesystem_WasmInfo) GetExports() []string {
+	if x != nil {
+		return x.Exports
+	}
+	return nil
+}
+
+func (x *Filesystem_WasmInfo) GetMemoryMin() uint64 {
+	if x != nil && x.MemoryMin != nil {
+		return *x.MemoryMin
+	}
+	return 0
+}
+
+func (x *Filesystem_WasmInfo) GetMemoryMax() uint64 {
+	if x != nil && x.MemoryMax != nil {
+		return *x.MemoryMax
+	}
+	return 0
+}
+
+// ArchiveInfo is the metadata extracted from an uploaded zip or tar archive.
+type Filesystem_ArchiveInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         *uint32                `protobuf:"varint,1,opt,name=files" json:"files,omitempty"`       // number of entries
+	Unpacked      *uint64                `protobuf:"varint,2,opt,name=unpacked" json:"unpacked,omitempty"` // total uncompressed size in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_ArchiveInfo) Reset() {
+	*x = Filesystem_ArchiveInfo{}
+	mi := &file_proto_v1_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_ArchiveInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_ArchiveInfo) ProtoMessage() {}
+
+func (x *Filesystem_ArchiveInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_ArchiveInfo.ProtoReflect.Descriptor instead.
+func (*Filesystem_ArchiveInfo) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 3}
+}
+
+func (x *Filesystem_ArchiveInfo) GetFiles() uint32 {
+	if x != nil && x.Files != nil {
+		return *x.Files
+	}
+	return 0
+}
+
+func (x *Filesystem_ArchiveInfo) GetUnpacked() uint64 {
+	if x != nil && x.Unpacked != nil {
+		return *x.Unpacked
+	}
+	return 0
+}
+
+// Probe checks if a certain file exists on Provider
+type Filesystem_Probe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Probe) Reset() {
+	*x = Filesystem_Probe{}
+	mi := &file_proto_v1_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Probe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Probe) ProtoMessage() {}
+
+func (x *Filesystem_Probe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Probe.ProtoReflect.Descriptor instead.
+func (*Filesystem_Probe) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 4}
+}
+
+// Upload pushes a file to the other peer.
+type Filesystem_Upload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Upload) Reset() {
+	*x = Filesystem_Upload{}
+	mi := &file_proto_v1_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Upload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Upload) ProtoMessage() {}
+
+func (x *Filesystem_Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Upload.ProtoReflect.Descriptor instead.
+func (*Filesystem_Upload) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 5}
+}
+
+// Download can request a file download from the other peer.
+type Filesystem_Download struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Download) Reset() {
+	*x = Filesystem_Download{}
+	mi := &file_proto_v1_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Download) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Download) ProtoMessage() {}
+
+func (x *Filesystem_Download) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Download.ProtoReflect.Descriptor instead.
+func (*Filesystem_Download) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 6}
+}
+
+// Chunk transfers large files in sequential pieces, so that no single message
+// needs to hold an entire file. Every chunk carries its offset and a digest of
+// its data, so transfers can be verified and resumed after an interruption.
+type Filesystem_Chunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Chunk) Reset() {
+	*x = Filesystem_Chunk{}
+	mi := &file_proto_v1_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Chunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Chunk) ProtoMessage() {}
+
+func (x *Filesystem_Chunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Chunk.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7}
+}
+
+type Filesystem_Listing_Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Listing_Request) Reset() {
+	*x = Filesystem_Listing_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Listing_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Listing_Request) ProtoMessage() {}
+
+func (x *Filesystem_Listing_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Listing_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Listing_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0, 0}
+}
+
+type Filesystem_Listing_Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []string               `protobuf:"bytes,1,rep,name=files" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_Listing_Response) Reset() {
+	*x = Filesystem_Listing_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_Listing_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_Listing_Response) ProtoMessage() {}
+
+func (x *Filesystem_Listing_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_Listing_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Listing_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0, 1}
+}
+
+func (x *Filesystem_Listing_Response) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type Filesystem_List_Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *string                `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"` // only names starting with this prefix, may be qualified
+	Limit         *uint32                `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`  // maximum number of entries per page, uses a default if unset
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor" json:"cursor,omitempty"` // continue after this name, from a previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_List_Request) Reset() {
+	*x = Filesystem_List_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_List_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_List_Request) ProtoMessage() {}
+
+func (x *Filesystem_List_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_List_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_List_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1, 0}
+}
+
+func (x *Filesystem_List_Request) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+func (x *Filesystem_List_Request) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *Filesystem_List_Request) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type Filesystem_List_Response struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Files         []*Filesystem_List_Entry `protobuf:"bytes,1,rep,name=files" json:"files,omitempty"`
+	Next          *string                  `protobuf:"bytes,2,opt,name=next" json:"next,omitempty"` // cursor of the next page, empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_List_Response) Reset() {
+	*x = Filesystem_List_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_List_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_List_Response) ProtoMessage() {}
+
+func (x *Filesystem_List_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_List_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_List_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1, 1}
+}
+
+func (x *Filesystem_List_Response) GetFiles() []*Filesystem_List_Entry {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Filesystem_List_Response) GetNext() string {
+	if x != nil && x.Next != nil {
+		return *x.Next
+	}
+	return ""
+}
+
+type Filesystem_List_Entry struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          *string                 `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`         // name, qualified as `namespace/name` outside the default namespace
+	Ref           *string                 `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`           // content address
+	Media         *string                 `protobuf:"bytes,3,opt,name=media" json:"media,omitempty"`       // media type in MIME notation
+	Size          *uint64                 `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`        // size in bytes
+	Uploaded      *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=uploaded" json:"uploaded,omitempty"` // time of the first upload
+	Accessed      *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=accessed" json:"accessed,omitempty"` // last access since the Broker started
+	Uploader      *string                 `protobuf:"bytes,7,opt,name=uploader" json:"uploader,omitempty"` // identity of the first uploader, if authenticated
+	Wasm          *Filesystem_WasmInfo    `protobuf:"bytes,8,opt,name=wasm" json:"wasm,omitempty"`         // inspected WebAssembly binary
+	Zip           *Filesystem_ArchiveInfo `protobuf:"bytes,9,opt,name=zip" json:"zip,omitempty"`           // inspected zip archive or wheel
+	Tar           *Filesystem_ArchiveInfo `protobuf:"bytes,10,opt,name=tar" json:"tar,omitempty"`          // inspected tar archive, maybe compressed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filesystem_List_Entry) Reset() {
+	*x = Filesystem_List_Entry{}
+	mi := &file_proto_v1_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filesystem_List_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filesystem_List_Entry) ProtoMessage() {}
+
+func (x *Filesystem_List_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filesystem_List_Entry.ProtoReflect.Descriptor instead.
+func (*Filesystem_List_Entry) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1, 2}
+}
+
+func (x *Filesystem_List_Entry) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Filesystem_List_Entry) GetRef() string {
+	if x != nil && x.Ref != nil {
+		return *x.Ref
+	}
+	return ""
+}
+
+func (x *Filesystem_List_Entry) GetMedia() string {
+	if x != nil && x.Media != nil {
+		return *x.Media
+	}
+	return ""
+}
+
+func (x *Filesystem_List_Entry) GetSize() uint64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Filesystem_List_Entry) GetUploaded() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Uploaded
+	}
+	return nil
+}
+
+func (x *Filesystem_List_Entry) GetAccessed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Accessed
+	}
+	return nil
+}
+
+func (x *Filesystem_List_Entry) GetUploader() string {
+	if x != nil && x.Uploader != nil {
+		return *x.Uploader
+	}
+	return ""
+}
+
+func (x *Filesystem_List_Entry) GetWasm() *Filesystem_WasmInfo {
+	if x != nil {
+		return x.Wasm
+	}
+	return nil
+}
+
+func (x *Filesystem_List_Entry) GetZip() *Filesystem_ArchiveInfo {
+	if x != nil {
+		return x.Zip
+	}
+	return nil
+}
+
+func (x *Filesystem_List_Entry) GetTar() *Filesystem_ArchiveInfo {
+	if x != nil {
+		return x.Tar
+	}
+	return nil
+}
+
+type Filesystem_Probe_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *string                `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Listing) Reset() {
-	*x = Filesystem_Listing{}
-	mi := &file_proto_v1_messages_proto_msgTypes[21]
+func (x *Filesystem_Probe_Request) Reset() {
+	*x = Filesystem_Probe_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Listing) String() string {
+func (x *Filesystem_Probe_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Listing) ProtoMessage() {}
+func (*Filesystem_Probe_Request) ProtoMessage() {}
 
-func (x *Filesystem_Listing) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[21]
+func (x *Filesystem_Probe_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,33 +2697,40 @@ func (x *Filesystem_Listing) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Listing.ProtoReflect.Descriptor instead.
-func (*Filesystem_Listing) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use Filesystem_Probe_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Probe_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 4, 0}
 }
 
-// Probe checks if a certain file exists on Provider
-type Filesystem_Probe struct {
+func (x *Filesystem_Probe_Request) GetFile() string {
+	if x != nil && x.File != nil {
+		return *x.File
+	}
+	return ""
+}
+
+type Filesystem_Probe_Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            *bool                  `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Probe) Reset() {
-	*x = Filesystem_Probe{}
-	mi := &file_proto_v1_messages_proto_msgTypes[22]
+func (x *Filesystem_Probe_Response) Reset() {
+	*x = Filesystem_Probe_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Probe) String() string {
+func (x *Filesystem_Probe_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Probe) ProtoMessage() {}
+func (*Filesystem_Probe_Response) ProtoMessage() {}
 
-func (x *Filesystem_Probe) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[22]
+func (x *Filesystem_Probe_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1668,33 +2741,40 @@ func (x *Filesystem_Probe) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Probe.ProtoReflect.Descriptor instead.
-func (*Filesystem_Probe) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use Filesystem_Probe_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Probe_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 4, 1}
 }
 
-// Upload pushes a file to the other peer.
-type Filesystem_Upload struct {
+func (x *Filesystem_Probe_Response) GetOk() bool {
+	if x != nil && x.Ok != nil {
+		return *x.Ok
+	}
+	return false
+}
+
+type Filesystem_Upload_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upload        *File                  `protobuf:"bytes,1,opt,name=upload" json:"upload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Upload) Reset() {
-	*x = Filesystem_Upload{}
-	mi := &file_proto_v1_messages_proto_msgTypes[23]
+func (x *Filesystem_Upload_Request) Reset() {
+	*x = Filesystem_Upload_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Upload) String() string {
+func (x *Filesystem_Upload_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Upload) ProtoMessage() {}
+func (*Filesystem_Upload_Request) ProtoMessage() {}
 
-func (x *Filesystem_Upload) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[23]
+func (x *Filesystem_Upload_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1705,33 +2785,40 @@ func (x *Filesystem_Upload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Upload.ProtoReflect.Descriptor instead.
-func (*Filesystem_Upload) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 2}
+// Deprecated: Use Filesystem_Upload_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Upload_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 5, 0}
 }
 
-// Download can request a file download from the other peer.
-type Filesystem_Download struct {
+func (x *Filesystem_Upload_Request) GetUpload() *File {
+	if x != nil {
+		return x.Upload
+	}
+	return nil
+}
+
+type Filesystem_Upload_Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           *string                `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Download) Reset() {
-	*x = Filesystem_Download{}
-	mi := &file_proto_v1_messages_proto_msgTypes[24]
+func (x *Filesystem_Upload_Response) Reset() {
+	*x = Filesystem_Upload_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Download) String() string {
+func (x *Filesystem_Upload_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Download) ProtoMessage() {}
+func (*Filesystem_Upload_Response) ProtoMessage() {}
 
-func (x *Filesystem_Download) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[24]
+func (x *Filesystem_Upload_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1742,32 +2829,40 @@ func (x *Filesystem_Download) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Download.ProtoReflect.Descriptor instead.
-func (*Filesystem_Download) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 3}
+// Deprecated: Use Filesystem_Upload_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Upload_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 5, 1}
 }
 
-type Filesystem_Listing_Request struct {
+func (x *Filesystem_Upload_Response) GetRef() string {
+	if x != nil && x.Ref != nil {
+		return *x.Ref
+	}
+	return ""
+}
+
+type Filesystem_Download_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *string                `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Listing_Request) Reset() {
-	*x = Filesystem_Listing_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[25]
+func (x *Filesystem_Download_Request) Reset() {
+	*x = Filesystem_Download_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Listing_Request) String() string {
+func (x *Filesystem_Download_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Listing_Request) ProtoMessage() {}
+func (*Filesystem_Download_Request) ProtoMessage() {}
 
-func (x *Filesystem_Listing_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[25]
+func (x *Filesystem_Download_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1778,33 +2873,41 @@ func (x *Filesystem_Listing_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Listing_Request.ProtoReflect.Descriptor instead.
-func (*Filesystem_Listing_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0, 0}
+// Deprecated: Use Filesystem_Download_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Download_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 6, 0}
 }
 
-type Filesystem_Listing_Response struct {
+func (x *Filesystem_Download_Request) GetFile() string {
+	if x != nil && x.File != nil {
+		return *x.File
+	}
+	return ""
+}
+
+type Filesystem_Download_Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []string               `protobuf:"bytes,1,rep,name=files" json:"files,omitempty"`
+	Download      *File                  `protobuf:"bytes,1,opt,name=download" json:"download,omitempty"`
+	Err           *string                `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Listing_Response) Reset() {
-	*x = Filesystem_Listing_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[26]
+func (x *Filesystem_Download_Response) Reset() {
+	*x = Filesystem_Download_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Listing_Response) String() string {
+func (x *Filesystem_Download_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Listing_Response) ProtoMessage() {}
+func (*Filesystem_Download_Response) ProtoMessage() {}
 
-func (x *Filesystem_Listing_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[26]
+func (x *Filesystem_Download_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,40 +2918,48 @@ func (x *Filesystem_Listing_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Listing_Response.ProtoReflect.Descriptor instead.
-func (*Filesystem_Listing_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 0, 1}
+// Deprecated: Use Filesystem_Download_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Download_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 6, 1}
 }
 
-func (x *Filesystem_Listing_Response) GetFiles() []string {
+func (x *Filesystem_Download_Response) GetDownload() *File {
 	if x != nil {
-		return x.Files
+		return x.Download
 	}
 	return nil
 }
 
-type Filesystem_Probe_Request struct {
+func (x *Filesystem_Download_Response) GetErr() string {
+	if x != nil && x.Err != nil {
+		return *x.Err
+	}
+	return ""
+}
+
+// Upload pushes the next chunk of a file to the other peer. Send a request
+// without data to query the current offset when resuming a transfer.
+type Filesystem_Chunk_Upload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	File          *string                `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Probe_Request) Reset() {
-	*x = Filesystem_Probe_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[27]
+func (x *Filesystem_Chunk_Upload) Reset() {
+	*x = Filesystem_Chunk_Upload{}
+	mi := &file_proto_v1_messages_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Probe_Request) String() string {
+func (x *Filesystem_Chunk_Upload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Probe_Request) ProtoMessage() {}
+func (*Filesystem_Chunk_Upload) ProtoMessage() {}
 
-func (x *Filesystem_Probe_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[27]
+func (x *Filesystem_Chunk_Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1859,40 +2970,33 @@ func (x *Filesystem_Probe_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Probe_Request.ProtoReflect.Descriptor instead.
-func (*Filesystem_Probe_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1, 0}
-}
-
-func (x *Filesystem_Probe_Request) GetFile() string {
-	if x != nil && x.File != nil {
-		return *x.File
-	}
-	return ""
+// Deprecated: Use Filesystem_Chunk_Upload.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Upload) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 0}
 }
 
-type Filesystem_Probe_Response struct {
+// Download requests a single chunk of a file from the other peer.
+type Filesystem_Chunk_Download struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            *bool                  `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Probe_Response) Reset() {
-	*x = Filesystem_Probe_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[28]
+func (x *Filesystem_Chunk_Download) Reset() {
+	*x = Filesystem_Chunk_Download{}
+	mi := &file_proto_v1_messages_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Probe_Response) String() string {
+func (x *Filesystem_Chunk_Download) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Probe_Response) ProtoMessage() {}
+func (*Filesystem_Chunk_Download) ProtoMessage() {}
 
-func (x *Filesystem_Probe_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[28]
+func (x *Filesystem_Chunk_Download) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1903,40 +3007,39 @@ func (x *Filesystem_Probe_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Probe_Response.ProtoReflect.Descriptor instead.
-func (*Filesystem_Probe_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 1, 1}
-}
-
-func (x *Filesystem_Probe_Response) GetOk() bool {
-	if x != nil && x.Ok != nil {
-		return *x.Ok
-	}
-	return false
+// Deprecated: Use Filesystem_Chunk_Download.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Download) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 1}
 }
 
-type Filesystem_Upload_Request struct {
+type Filesystem_Chunk_Upload_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Upload        *File                  `protobuf:"bytes,1,opt,name=upload" json:"upload,omitempty"`
+	Ref           *string                `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`        // content address of the complete file, identifies the transfer
+	Name          *string                `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`      // optional friendly name for lookup
+	Media         *string                `protobuf:"bytes,3,opt,name=media" json:"media,omitempty"`    // media type of the complete file
+	Size          *uint64                `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`     // total size of the complete file
+	Offset        *uint64                `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"` // offset of this chunk within the file
+	Data          []byte                 `protobuf:"bytes,6,opt,name=data" json:"data,omitempty"`      // chunk contents
+	Digest        *string                `protobuf:"bytes,7,opt,name=digest" json:"digest,omitempty"`  // sha256 digest of data, encoded like a ref
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Upload_Request) Reset() {
-	*x = Filesystem_Upload_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[29]
+func (x *Filesystem_Chunk_Upload_Request) Reset() {
+	*x = Filesystem_Chunk_Upload_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Upload_Request) String() string {
+func (x *Filesystem_Chunk_Upload_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Upload_Request) ProtoMessage() {}
+func (*Filesystem_Chunk_Upload_Request) ProtoMessage() {}
 
-func (x *Filesystem_Upload_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[29]
+func (x *Filesystem_Chunk_Upload_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1947,40 +3050,83 @@ func (x *Filesystem_Upload_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Upload_Request.ProtoReflect.Descriptor instead.
-func (*Filesystem_Upload_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 2, 0}
+// Deprecated: Use Filesystem_Chunk_Upload_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Upload_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 0, 0}
 }
 
-func (x *Filesystem_Upload_Request) GetUpload() *File {
+func (x *Filesystem_Chunk_Upload_Request) GetRef() string {
+	if x != nil && x.Ref != nil {
+		return *x.Ref
+	}
+	return ""
+}
+
+func (x *Filesystem_Chunk_Upload_Request) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Filesystem_Chunk_Upload_Request) GetMedia() string {
+	if x != nil && x.Media != nil {
+		return *x.Media
+	}
+	return ""
+}
+
+func (x *Filesystem_Chunk_Upload_Request) GetSize() uint64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Upload_Request) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Upload_Request) GetData() []byte {
 	if x != nil {
-		return x.Upload
+		return x.Data
 	}
 	return nil
 }
 
-type Filesystem_Upload_Response struct {
+func (x *Filesystem_Chunk_Upload_Request) GetDigest() string {
+	if x != nil && x.Digest != nil {
+		return *x.Digest
+	}
+	return ""
+}
+
+type Filesystem_Chunk_Upload_Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ref           *string                `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`
+	Offset        *uint64                `protobuf:"varint,1,opt,name=offset" json:"offset,omitempty"` // contiguous bytes received so far, i.e. the next expected offset
+	Ref           *string                `protobuf:"bytes,2,opt,name=ref" json:"ref,omitempty"`        // content address, only set once the file is complete
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
-
-func (x *Filesystem_Upload_Response) Reset() {
-	*x = Filesystem_Upload_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[30]
+
+func (x *Filesystem_Chunk_Upload_Response) Reset() {
+	*x = Filesystem_Chunk_Upload_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Upload_Response) String() string {
+func (x *Filesystem_Chunk_Upload_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Upload_Response) ProtoMessage() {}
+func (*Filesystem_Chunk_Upload_Response) ProtoMessage() {}
 
-func (x *Filesystem_Upload_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[30]
+func (x *Filesystem_Chunk_Upload_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1991,40 +3137,49 @@ func (x *Filesystem_Upload_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Upload_Response.ProtoReflect.Descriptor instead.
-func (*Filesystem_Upload_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 2, 1}
+// Deprecated: Use Filesystem_Chunk_Upload_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Upload_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 0, 1}
 }
 
-func (x *Filesystem_Upload_Response) GetRef() string {
+func (x *Filesystem_Chunk_Upload_Response) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Upload_Response) GetRef() string {
 	if x != nil && x.Ref != nil {
 		return *x.Ref
 	}
 	return ""
 }
 
-type Filesystem_Download_Request struct {
+type Filesystem_Chunk_Download_Request struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	File          *string                `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`
+	File          *string                `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`      // filename or content address
+	Offset        *uint64                `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"` // offset of the requested chunk
+	Length        *uint32                `protobuf:"varint,3,opt,name=length" json:"length,omitempty"` // maximum length of the chunk, uses a default if unset
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Download_Request) Reset() {
-	*x = Filesystem_Download_Request{}
-	mi := &file_proto_v1_messages_proto_msgTypes[31]
+func (x *Filesystem_Chunk_Download_Request) Reset() {
+	*x = Filesystem_Chunk_Download_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Download_Request) String() string {
+func (x *Filesystem_Chunk_Download_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Download_Request) ProtoMessage() {}
+func (*Filesystem_Chunk_Download_Request) ProtoMessage() {}
 
-func (x *Filesystem_Download_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[31]
+func (x *Filesystem_Chunk_Download_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2035,41 +3190,59 @@ func (x *Filesystem_Download_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Download_Request.ProtoReflect.Descriptor instead.
-func (*Filesystem_Download_Request) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 3, 0}
+// Deprecated: Use Filesystem_Chunk_Download_Request.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Download_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 1, 0}
 }
 
-func (x *Filesystem_Download_Request) GetFile() string {
+func (x *Filesystem_Chunk_Download_Request) GetFile() string {
 	if x != nil && x.File != nil {
 		return *x.File
 	}
 	return ""
 }
 
-type Filesystem_Download_Response struct {
+func (x *Filesystem_Chunk_Download_Request) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Download_Request) GetLength() uint32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+type Filesystem_Chunk_Download_Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Download      *File                  `protobuf:"bytes,1,opt,name=download" json:"download,omitempty"`
-	Err           *string                `protobuf:"bytes,2,opt,name=err" json:"err,omitempty"`
+	Ref           *string                `protobuf:"bytes,1,opt,name=ref" json:"ref,omitempty"`        // content address of the complete file
+	Media         *string                `protobuf:"bytes,2,opt,name=media" json:"media,omitempty"`    // media type of the complete file
+	Size          *uint64                `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`     // total size of the complete file
+	Offset        *uint64                `protobuf:"varint,4,opt,name=offset" json:"offset,omitempty"` // offset of this chunk within the file
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data" json:"data,omitempty"`      // chunk contents
+	Digest        *string                `protobuf:"bytes,6,opt,name=digest" json:"digest,omitempty"`  // sha256 digest of data, encoded like a ref
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Filesystem_Download_Response) Reset() {
-	*x = Filesystem_Download_Response{}
-	mi := &file_proto_v1_messages_proto_msgTypes[32]
+func (x *Filesystem_Chunk_Download_Response) Reset() {
+	*x = Filesystem_Chunk_Download_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filesystem_Download_Response) String() string {
+func (x *Filesystem_Chunk_Download_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filesystem_Download_Response) ProtoMessage() {}
+func (*Filesystem_Chunk_Download_Response) ProtoMessage() {}
 
-func (x *Filesystem_Download_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[32]
+func (x *Filesystem_Chunk_Download_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2080,21 +3253,49 @@ func (x *Filesystem_Download_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filesystem_Download_Response.ProtoReflect.Descriptor instead.
-func (*Filesystem_Download_Response) Descriptor() ([]byte, []int) {
-	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 3, 1}
+// Deprecated: Use Filesystem_Chunk_Download_Response.ProtoReflect.Descriptor instead.
+func (*Filesystem_Chunk_Download_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{3, 7, 1, 1}
 }
 
-func (x *Filesystem_Download_Response) GetDownload() *File {
+func (x *Filesystem_Chunk_Download_Response) GetRef() string {
+	if x != nil && x.Ref != nil {
+		return *x.Ref
+	}
+	return ""
+}
+
+func (x *Filesystem_Chunk_Download_Response) GetMedia() string {
+	if x != nil && x.Media != nil {
+		return *x.Media
+	}
+	return ""
+}
+
+func (x *Filesystem_Chunk_Download_Response) GetSize() uint64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Download_Response) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *Filesystem_Chunk_Download_Response) GetData() []byte {
 	if x != nil {
-		return x.Download
+		return x.Data
 	}
 	return nil
 }
 
-func (x *Filesystem_Download_Response) GetErr() string {
-	if x != nil && x.Err != nil {
-		return *x.Err
+func (x *Filesystem_Chunk_Download_Response) GetDigest() string {
+	if x != nil && x.Digest != nil {
+		return *x.Digest
 	}
 	return ""
 }
@@ -2109,7 +3310,7 @@ type Event_GenericMessage struct {
 
 func (x *Event_GenericMessage) Reset() {
 	*x = Event_GenericMessage{}
-	mi := &file_proto_v1_messages_proto_msgTypes[33]
+	mi := &file_proto_v1_messages_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2121,7 +3322,7 @@ func (x *Event_GenericMessage) String() string {
 func (*Event_GenericMessage) ProtoMessage() {}
 
 func (x *Event_GenericMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[33]
+	mi := &file_proto_v1_messages_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2155,7 +3356,7 @@ type Event_ProviderResources struct {
 
 func (x *Event_ProviderResources) Reset() {
 	*x = Event_ProviderResources{}
-	mi := &file_proto_v1_messages_proto_msgTypes[34]
+	mi := &file_proto_v1_messages_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2167,7 +3368,7 @@ func (x *Event_ProviderResources) String() string {
 func (*Event_ProviderResources) ProtoMessage() {}
 
 func (x *Event_ProviderResources) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[34]
+	mi := &file_proto_v1_messages_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2201,13 +3402,16 @@ func (x *Event_ProviderResources) GetTasks() uint32 {
 type Event_ClusterInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Providers     *uint32                `protobuf:"varint,1,opt,name=providers" json:"providers,omitempty"` // number of currently connected providers
+	Workers       *uint32                `protobuf:"varint,2,opt,name=workers" json:"workers,omitempty"`     // total concurrency across all providers
+	Busy          *uint32                `protobuf:"varint,3,opt,name=busy" json:"busy,omitempty"`           // currently running tasks across all providers
+	Queued        *uint32                `protobuf:"varint,4,opt,name=queued" json:"queued,omitempty"`       // tasks waiting in the broker's queue
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_ClusterInfo) Reset() {
 	*x = Event_ClusterInfo{}
-	mi := &file_proto_v1_messages_proto_msgTypes[35]
+	mi := &file_proto_v1_messages_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2219,7 +3423,7 @@ func (x *Event_ClusterInfo) String() string {
 func (*Event_ClusterInfo) ProtoMessage() {}
 
 func (x *Event_ClusterInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[35]
+	mi := &file_proto_v1_messages_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2242,6 +3446,27 @@ func (x *Event_ClusterInfo) GetProviders() uint32 {
 	return 0
 }
 
+func (x *Event_ClusterInfo) GetWorkers() uint32 {
+	if x != nil && x.Workers != nil {
+		return *x.Workers
+	}
+	return 0
+}
+
+func (x *Event_ClusterInfo) GetBusy() uint32 {
+	if x != nil && x.Busy != nil {
+		return *x.Busy
+	}
+	return 0
+}
+
+func (x *Event_ClusterInfo) GetQueued() uint32 {
+	if x != nil && x.Queued != nil {
+		return *x.Queued
+	}
+	return 0
+}
+
 // Throughput contains information about overall cluster throughput
 type Event_Throughput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2253,7 +3478,7 @@ type Event_Throughput struct {
 
 func (x *Event_Throughput) Reset() {
 	*x = Event_Throughput{}
-	mi := &file_proto_v1_messages_proto_msgTypes[36]
+	mi := &file_proto_v1_messages_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2265,7 +3490,7 @@ func (x *Event_Throughput) String() string {
 func (*Event_Throughput) ProtoMessage() {}
 
 func (x *Event_Throughput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[36]
+	mi := &file_proto_v1_messages_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2295,7 +3520,8 @@ func (x *Event_Throughput) GetYours() float32 {
 	return 0
 }
 
-// FileSystemUpdate notifies the Broker about changed files on the Provider.
+// FileSystemUpdate notifies the Broker about changed files on the Provider. The
+// Broker sends removed files to the Providers, so they can evict cached copies.
 type Event_FileSystemUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Added         []string               `protobuf:"bytes,1,rep,name=added" json:"added,omitempty"`
@@ -2306,7 +3532,7 @@ type Event_FileSystemUpdate struct {
 
 func (x *Event_FileSystemUpdate) Reset() {
 	*x = Event_FileSystemUpdate{}
-	mi := &file_proto_v1_messages_proto_msgTypes[37]
+	mi := &file_proto_v1_messages_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2318,7 +3544,7 @@ func (x *Event_FileSystemUpdate) String() string {
 func (*Event_FileSystemUpdate) ProtoMessage() {}
 
 func (x *Event_FileSystemUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_messages_proto_msgTypes[37]
+	mi := &file_proto_v1_messages_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2348,6 +3574,240 @@ func (x *Event_FileSystemUpdate) GetRemoved() []string {
 	return nil
 }
 
+// Drain announces that the Provider wants to leave gracefully. The Broker stops
+// scheduling new tasks to it but lets running tasks finish before closing.
+type Event_Drain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        *string                `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"` // freeform reason for logging
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_Drain) Reset() {
+	*x = Event_Drain{}
+	mi := &file_proto_v1_messages_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_Drain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_Drain) ProtoMessage() {}
+
+func (x *Event_Drain) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_Drain.ProtoReflect.Descriptor instead.
+func (*Event_Drain) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{4, 5}
+}
+
+func (x *Event_Drain) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+// Subscribe is sent by Clients to receive ClusterInfo and Throughput events at
+// a regular interval. The Broker may enforce a minimum interval.
+type Event_Subscribe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_Subscribe) Reset() {
+	*x = Event_Subscribe{}
+	mi := &file_proto_v1_messages_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_Subscribe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_Subscribe) ProtoMessage() {}
+
+func (x *Event_Subscribe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_Subscribe.ProtoReflect.Descriptor instead.
+func (*Event_Subscribe) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{4, 6}
+}
+
+// Session is sent by the Broker upon connection. A Provider can reconnect with the
+// token within the grace period to resume its session after a connection loss.
+type Event_Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`        // stable identifier of this Provider on the Broker
+	Token         *string                `protobuf:"bytes,2,opt,name=token" json:"token,omitempty"`  // secret to present as `?session=` query parameter
+	Grace         *uint32                `protobuf:"varint,3,opt,name=grace" json:"grace,omitempty"` // grace period in seconds, zero if resumption is disabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_Session) Reset() {
+	*x = Event_Session{}
+	mi := &file_proto_v1_messages_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_Session) ProtoMessage() {}
+
+func (x *Event_Session) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_Session.ProtoReflect.Descriptor instead.
+func (*Event_Session) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{4, 7}
+}
+
+func (x *Event_Session) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Event_Session) GetToken() string {
+	if x != nil && x.Token != nil {
+		return *x.Token
+	}
+	return ""
+}
+
+func (x *Event_Session) GetGrace() uint32 {
+	if x != nil && x.Grace != nil {
+		return *x.Grace
+	}
+	return 0
+}
+
+type Event_Subscribe_Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *uint32                `protobuf:"varint,1,opt,name=interval" json:"interval,omitempty"` // interval in milliseconds, zero unsubscribes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_Subscribe_Request) Reset() {
+	*x = Event_Subscribe_Request{}
+	mi := &file_proto_v1_messages_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_Subscribe_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_Subscribe_Request) ProtoMessage() {}
+
+func (x *Event_Subscribe_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_Subscribe_Request.ProtoReflect.Descriptor instead.
+func (*Event_Subscribe_Request) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{4, 6, 0}
+}
+
+func (x *Event_Subscribe_Request) GetInterval() uint32 {
+	if x != nil && x.Interval != nil {
+		return *x.Interval
+	}
+	return 0
+}
+
+type Event_Subscribe_Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *uint32                `protobuf:"varint,1,opt,name=interval" json:"interval,omitempty"` // effective interval in milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_Subscribe_Response) Reset() {
+	*x = Event_Subscribe_Response{}
+	mi := &file_proto_v1_messages_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_Subscribe_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_Subscribe_Response) ProtoMessage() {}
+
+func (x *Event_Subscribe_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_messages_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_Subscribe_Response.ProtoReflect.Descriptor instead.
+func (*Event_Subscribe_Response) Descriptor() ([]byte, []int) {
+	return file_proto_v1_messages_proto_rawDescGZIP(), []int{4, 6, 1}
+}
+
+func (x *Event_Subscribe_Response) GetInterval() uint32 {
+	if x != nil && x.Interval != nil {
+		return *x.Interval
+	}
+	return 0
+}
+
 var File_proto_v1_messages_proto protoreflect.FileDescriptor
 
 var file_proto_v1_messages_proto_rawDesc = string([]byte{
@@ -2371,8 +3831,8 @@ var file_proto_v1_messages_proto_rawDesc = string([]byte{
 	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
 	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10,
 	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x02, 0x12,
-	0x09, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x22, 0xa6, 0x15, 0x0a, 0x04, 0x54,
-	0x61, 0x73, 0x6b, 0x1a, 0xa1, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x09, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x22, 0xe1, 0x19, 0x0a, 0x04, 0x54,
+	0x61, 0x73, 0x6b, 0x1a, 0xbf, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
 	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1a,
@@ -2382,238 +3842,393 @@ var file_proto_v1_messages_proto_rawDesc = string([]byte{
 	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63,
 	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
 	0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65,
-	0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x1a, 0x77, 0x0a, 0x03, 0x51, 0x6f, 0x53, 0x12, 0x1a,
-	0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65,
-	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
-	0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65,
-	0x1a, 0x73, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x34, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
-	0x73, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0xac, 0x07, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x6e, 0x61, 0x6e, 0x6f,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x6e, 0x61, 0x6e, 0x6f,
-	0x12, 0x3c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x26, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
-	0x73, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0xa9, 0x06, 0x0a, 0x09, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x0b, 0x12,
-	0x1a, 0x0a, 0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x42,
-	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x14, 0x12, 0x1f, 0x0a, 0x1b,
-	0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x15, 0x12, 0x13, 0x0a,
-	0x0f, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x54, 0x61, 0x73, 0x6b,
-	0x10, 0x16, 0x12, 0x16, 0x0a, 0x12, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x10, 0x17, 0x12, 0x1e, 0x0a, 0x1a, 0x42, 0x72,
-	0x6f, 0x6b, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x10, 0x18, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x72,
-	0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x10, 0x19, 0x12, 0x20, 0x0a, 0x1c,
-	0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x1a, 0x12, 0x11,
-	0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10,
-	0x1e, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x61, 0x73,
-	0x6b, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x10, 0x1f, 0x12, 0x15, 0x0a, 0x11, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x10, 0x20, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x6f,
-	0x73, 0x74, 0x54, 0x6f, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x10, 0x21, 0x12, 0x19, 0x0a, 0x15,
-	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x50, 0x72,
-	0x65, 0x70, 0x61, 0x72, 0x65, 0x10, 0x22, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
-	0x10, 0x23, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x57, 0x6f,
-	0x72, 0x6b, 0x65, 0x72, 0x44, 0x6f, 0x6e, 0x65, 0x10, 0x24, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x10, 0x25, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63,
-	0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x65, 0x72, 0x10,
-	0x26, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x10, 0x27, 0x12, 0x1d, 0x0a, 0x19,
-	0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72,
-	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x10, 0x28, 0x12, 0x1f, 0x0a, 0x1b, 0x41,
-	0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x6e, 0x74, 0x65, 0x72, 0x10, 0x29, 0x12, 0x1f, 0x0a, 0x1b,
-	0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x10, 0x2a, 0x12, 0x1d, 0x0a,
-	0x19, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x57, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66,
-	0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x10, 0x2b, 0x12, 0x1f, 0x0a, 0x1b,
-	0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x57, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x44,
-	0x65, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x10, 0x2c, 0x12, 0x23, 0x0a,
-	0x1f, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x10, 0x2d, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68,
-	0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4f, 0x66,
-	0x66, 0x6c, 0x6f, 0x61, 0x64, 0x10, 0x2e, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x72, 0x74, 0x44, 0x65,
-	0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x10, 0x2f, 0x1a, 0x30, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0xf9, 0x04, 0x0a, 0x06, 0x57, 0x61, 0x73, 0x69, 0x70,
-	0x31, 0x1a, 0xba, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x06,
-	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77,
-	0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x65,
-	0x6e, 0x76, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x77, 0x0a, 0x03, 0x51, 0x6f, 0x53, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x1a, 0x73,
+	0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x1a, 0xac, 0x07, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x6e, 0x61, 0x6e, 0x6f, 0x12, 0x3c,
+	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0xa9, 0x06, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x0b, 0x12, 0x1a, 0x0a,
+	0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x14, 0x12, 0x1f, 0x0a, 0x1b, 0x42, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x15, 0x12, 0x13, 0x0a, 0x0f, 0x42,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x10, 0x16,
+	0x12, 0x16, 0x0a, 0x12, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x10, 0x17, 0x12, 0x1e, 0x0a, 0x1a, 0x42, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x10, 0x18, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x10, 0x19, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x1a, 0x12, 0x11, 0x0a, 0x0d,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x1e, 0x12,
+	0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x10, 0x1f, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x10, 0x20,
+	0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x74,
+	0x54, 0x6f, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x10, 0x21, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x50, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x10, 0x22, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x10, 0x23,
+	0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x44, 0x6f, 0x6e, 0x65, 0x10, 0x24, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x10, 0x25, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x65, 0x72, 0x10, 0x26, 0x12,
+	0x19, 0x0a, 0x15, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x10, 0x27, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x72,
+	0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x10, 0x28, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x72, 0x74,
+	0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x45, 0x6e, 0x74, 0x65, 0x72, 0x10, 0x29, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x72,
+	0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x10, 0x2a, 0x12, 0x1d, 0x0a, 0x19, 0x41,
+	0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x57, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x53, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x10, 0x2b, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x72,
+	0x74, 0x44, 0x65, 0x63, 0x6f, 0x57, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x44, 0x65, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x10, 0x2c, 0x12, 0x23, 0x0a, 0x1f, 0x41,
+	0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x10, 0x2d,
+	0x12, 0x23, 0x0a, 0x1f, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4f, 0x66, 0x66, 0x6c,
+	0x6f, 0x61, 0x64, 0x10, 0x2e, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x72, 0x74, 0x44, 0x65, 0x63, 0x6f,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x10, 0x2f, 0x1a, 0x30, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x53, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x1a, 0x23,
+	0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x1a, 0x24, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x1a, 0xa8, 0x01, 0x0a, 0x07, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x1a, 0x90, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3b, 0x0a, 0x06, 0x77, 0x61, 0x73, 0x69, 0x70, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x77, 0x61, 0x73, 0x69, 0x70, 0x31, 0x12, 0x3e,
+	0x0a, 0x07, 0x70, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
+	0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x42, 0x08,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0x0a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x66, 0x0a, 0x05, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2b, 0x0a,
+	0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x6f, 0x6e, 0x6c, 0x79, 0x1a, 0x85, 0x06, 0x0a,
+	0x06, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x1a, 0xc6, 0x02, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x65, 0x6e, 0x76, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x72,
+	0x6f, 0x6f, 0x74, 0x66, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61,
+	0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06,
+	0x72, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x2f, 0x0a,
+	0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x2e, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x30,
+	0x0a, 0x0a, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x46, 0x69, 0x6c, 0x65,
+	0x1a, 0x81, 0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x1a, 0x9b, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x27, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x2e, 0x51, 0x6f, 0x53, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x61, 0x73, 0x69,
+	0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73,
+	0x69, 0x70, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x8f, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12,
+	0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x2e, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x1a, 0xd7, 0x05, 0x0a, 0x07, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65,
+	0x1a, 0xfb, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x12, 0x18, 0x0a, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65,
+	0x6e, 0x76, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
 	0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66,
 	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x66, 0x73,
-	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x1a, 0x81,
-	0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x27,
+	0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x72, 0x75, 0x6e, 0x1a, 0x9b,
+	0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x63,
+	0x6b, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
 	0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
-	0x72, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e,
-	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
-	0x74, 0x73, 0x1a, 0x9b, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e,
-	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x09, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x1a, 0x9c, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x51, 0x6f, 0x53, 0x52, 0x03, 0x71, 0x6f,
+	0x73, 0x12, 0x38, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x90, 0x01, 0x0a, 0x08,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x32, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77,
 	0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x27,
-	0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x61,
-	0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x51,
-	0x6f, 0x53, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
-	0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73, 0x69, 0x70,
-	0x31, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x1a, 0x8f, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
-	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61,
-	0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x1a, 0xae, 0x05, 0x0a, 0x07, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x1a, 0xd2,
-	0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
-	0x18, 0x0a, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48,
-	0x00, 0x52, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x6e, 0x76,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74,
-	0x64, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76,
-	0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x42, 0x05, 0x0a, 0x03,
-	0x72, 0x75, 0x6e, 0x1a, 0x9b, 0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x70, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x2f, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76,
-	0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
-	0x73, 0x1a, 0x9c, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a,
-	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61,
-	0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a,
-	0x03, 0x71, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x61, 0x73,
-	0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x51, 0x6f,
-	0x53, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
-	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64,
-	0x65, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x1a, 0x90, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
-	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61,
-	0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00,
+	0x52, 0x02, 0x6f, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x42,
+	0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c,
+	0x6f, 0x62, 0x22, 0xfc, 0x0c, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x1a, 0x36, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x09, 0x0a, 0x07,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0xbb, 0x04, 0x0a, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x1a, 0x4f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x1a, 0x58, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x38, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x1a, 0x87, 0x03,
+	0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12,
+	0x36, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x04, 0x77, 0x61, 0x73, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x20, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x4f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0x42, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72,
-	0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x14, 0x0a,
-	0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x65,
-	0x64, 0x69, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0xde, 0x02, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65,
-	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x1a, 0x36, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e,
-	0x67, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x0a, 0x08,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x42,
-	0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x1a, 0x1d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02,
-	0x6f, 0x6b, 0x1a, 0x5c, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x34, 0x0a, 0x07,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
-	0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x75, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x1a, 0x1c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66,
-	0x1a, 0x76, 0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x1d, 0x0a, 0x07,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x61, 0x73, 0x6d, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x04, 0x77, 0x61, 0x73, 0x6d, 0x12, 0x35, 0x0a, 0x03, 0x7a, 0x69, 0x70,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x7a, 0x69, 0x70,
+	0x12, 0x35, 0x0a, 0x03, 0x74, 0x61, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x03, 0x74, 0x61, 0x72, 0x1a, 0xc8, 0x01, 0x0a, 0x08, 0x57, 0x61, 0x73, 0x6d,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x77, 0x61, 0x73, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x61, 0x73, 0x69,
+	0x12, 0x18, 0x0a, 0x07, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d,
+	0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x61,
+	0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d,
+	0x61, 0x78, 0x1a, 0x3f, 0x0a, 0x0b, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x75, 0x6e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x1a, 0x42, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x1a, 0x1d, 0x0a, 0x07,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x4b, 0x0a, 0x08, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x61, 0x73, 0x69,
-	0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x64, 0x6f,
-	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0xaf, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x1a, 0x2a, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x4b,
-	0x0a, 0x11, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x1a, 0x2b, 0x0a, 0x0b, 0x43,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3c, 0x0a, 0x0a, 0x54, 0x68, 0x72, 0x6f,
-	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c,
-	0x12, 0x14, 0x0a, 0x05, 0x79, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x05, 0x79, 0x6f, 0x75, 0x72, 0x73, 0x1a, 0x42, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64,
-	0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64,
-	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x06, 0x0a, 0x04, 0x50, 0x69,
-	0x6e, 0x67, 0x2a, 0x5c, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x21,
-	0x0a, 0x1d, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x5f, 0x76, 0x31, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x10,
-	0x01, 0x12, 0x1d, 0x0a, 0x19, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x5f, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x31, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x10, 0x02,
-	0x32, 0x8f, 0x02, 0x0a, 0x05, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x52, 0x0a, 0x09, 0x52, 0x75,
-	0x6e, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x12, 0x20, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
-	0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73, 0x69, 0x70,
-	0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x77, 0x61, 0x73, 0x69,
-	0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x73,
-	0x69, 0x70, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55,
-	0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x12, 0x21, 0x2e, 0x77,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x1a, 0x0a, 0x08, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x1a, 0x5c, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x1a, 0x34, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77,
+	0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x1c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x72, 0x65, 0x66, 0x1a, 0x76, 0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x1a, 0x1d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x1a, 0x4b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x72, 0x72, 0x1a, 0xd1, 0x03,
+	0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0xde, 0x01, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x1a, 0x9d, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x1a, 0x34, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x1a, 0xe6, 0x01, 0x0a, 0x08, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x4d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x1a, 0x8a, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x72, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x22, 0xb9, 0x04, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x2a, 0x0a, 0x0e, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x4b, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x1a, 0x71, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x75, 0x73, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x75, 0x73, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x1a, 0x3c, 0x0a, 0x0a, 0x54, 0x68, 0x72, 0x6f, 0x75,
+	0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x79, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
+	0x79, 0x6f, 0x75, 0x72, 0x73, 0x1a, 0x42, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x1a, 0x1f, 0x0a, 0x05, 0x44, 0x72, 0x61,
+	0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x5a, 0x0a, 0x09, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x1a, 0x25, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x1a, 0x26,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x1a, 0x45, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x72, 0x61, 0x63, 0x65, 0x22, 0x06, 0x0a,
+	0x04, 0x50, 0x69, 0x6e, 0x67, 0x2a, 0x5c, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x21, 0x0a, 0x1d, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x5f, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x31, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66,
+	0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x31, 0x5f, 0x6a, 0x73, 0x6f,
+	0x6e, 0x10, 0x02, 0x32, 0xcd, 0x04, 0x0a, 0x05, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x52, 0x0a,
+	0x09, 0x52, 0x75, 0x6e, 0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x12, 0x20, 0x2e, 0x77, 0x61, 0x73,
+	0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x57, 0x61,
+	0x73, 0x69, 0x70, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x77,
 	0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e,
-	0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x22, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
-	0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
-	0x26, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
-	0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x42, 0x1f, 0x5a, 0x1d, 0x77, 0x61, 0x73, 0x69, 0x2e, 0x74, 0x65, 0x61, 0x6d, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x3b, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66,
-	0x66, 0x76, 0x31, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0xe8, 0x07,
+	0x57, 0x61, 0x73, 0x69, 0x70, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x55, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x12,
+	0x21, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
+	0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x50, 0x79, 0x6f, 0x64, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x26, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x77, 0x61, 0x73,
+	0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6c, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x0d, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2e, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x77, 0x61, 0x73,
+	0x69, 0x6d, 0x6f, 0x66, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x1f, 0x5a, 0x1d, 0x77, 0x61, 0x73, 0x69, 0x2e, 0x74, 0x65, 0x61, 0x6d,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x3b, 0x77, 0x61, 0x73, 0x69, 0x6d, 0x6f,
+	0x66, 0x66, 0x76, 0x31, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0xe8,
+	0x07,
 })
 
 var (
@@ -2629,87 +4244,129 @@ func file_proto_v1_messages_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_v1_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_v1_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_proto_v1_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_proto_v1_messages_proto_goTypes = []any{
-	(Subprotocol)(0),                     // 0: wasimoff.v1.Subprotocol
-	(Envelope_MessageType)(0),            // 1: wasimoff.v1.Envelope.MessageType
-	(Task_TraceEvent_EventType)(0),       // 2: wasimoff.v1.Task.TraceEvent.EventType
-	(*Envelope)(nil),                     // 3: wasimoff.v1.Envelope
-	(*Task)(nil),                         // 4: wasimoff.v1.Task
-	(*File)(nil),                         // 5: wasimoff.v1.File
-	(*Filesystem)(nil),                   // 6: wasimoff.v1.Filesystem
-	(*Event)(nil),                        // 7: wasimoff.v1.Event
-	(*Ping)(nil),                         // 8: wasimoff.v1.Ping
-	(*Task_Metadata)(nil),                // 9: wasimoff.v1.Task.Metadata
-	(*Task_QoS)(nil),                     // 10: wasimoff.v1.Task.QoS
-	(*Task_Trace)(nil),                   // 11: wasimoff.v1.Task.Trace
-	(*Task_TraceEvent)(nil),              // 12: wasimoff.v1.Task.TraceEvent
-	(*Task_Cancel)(nil),                  // 13: wasimoff.v1.Task.Cancel
-	(*Task_Wasip1)(nil),                  // 14: wasimoff.v1.Task.Wasip1
-	(*Task_Pyodide)(nil),                 // 15: wasimoff.v1.Task.Pyodide
-	(*Task_Wasip1_Params)(nil),           // 16: wasimoff.v1.Task.Wasip1.Params
-	(*Task_Wasip1_Output)(nil),           // 17: wasimoff.v1.Task.Wasip1.Output
-	(*Task_Wasip1_Request)(nil),          // 18: wasimoff.v1.Task.Wasip1.Request
-	(*Task_Wasip1_Response)(nil),         // 19: wasimoff.v1.Task.Wasip1.Response
-	(*Task_Pyodide_Params)(nil),          // 20: wasimoff.v1.Task.Pyodide.Params
-	(*Task_Pyodide_Output)(nil),          // 21: wasimoff.v1.Task.Pyodide.Output
-	(*Task_Pyodide_Request)(nil),         // 22: wasimoff.v1.Task.Pyodide.Request
-	(*Task_Pyodide_Response)(nil),        // 23: wasimoff.v1.Task.Pyodide.Response
-	(*Filesystem_Listing)(nil),           // 24: wasimoff.v1.Filesystem.Listing
-	(*Filesystem_Probe)(nil),             // 25: wasimoff.v1.Filesystem.Probe
-	(*Filesystem_Upload)(nil),            // 26: wasimoff.v1.Filesystem.Upload
-	(*Filesystem_Download)(nil),          // 27: wasimoff.v1.Filesystem.Download
-	(*Filesystem_Listing_Request)(nil),   // 28: wasimoff.v1.Filesystem.Listing.Request
-	(*Filesystem_Listing_Response)(nil),  // 29: wasimoff.v1.Filesystem.Listing.Response
-	(*Filesystem_Probe_Request)(nil),     // 30: wasimoff.v1.Filesystem.Probe.Request
-	(*Filesystem_Probe_Response)(nil),    // 31: wasimoff.v1.Filesystem.Probe.Response
-	(*Filesystem_Upload_Request)(nil),    // 32: wasimoff.v1.Filesystem.Upload.Request
-	(*Filesystem_Upload_Response)(nil),   // 33: wasimoff.v1.Filesystem.Upload.Response
-	(*Filesystem_Download_Request)(nil),  // 34: wasimoff.v1.Filesystem.Download.Request
-	(*Filesystem_Download_Response)(nil), // 35: wasimoff.v1.Filesystem.Download.Response
-	(*Event_GenericMessage)(nil),         // 36: wasimoff.v1.Event.GenericMessage
-	(*Event_ProviderResources)(nil),      // 37: wasimoff.v1.Event.ProviderResources
-	(*Event_ClusterInfo)(nil),            // 38: wasimoff.v1.Event.ClusterInfo
-	(*Event_Throughput)(nil),             // 39: wasimoff.v1.Event.Throughput
-	(*Event_FileSystemUpdate)(nil),       // 40: wasimoff.v1.Event.FileSystemUpdate
-	(*anypb.Any)(nil),                    // 41: google.protobuf.Any
-	(*timestamppb.Timestamp)(nil),        // 42: google.protobuf.Timestamp
+	(Subprotocol)(0),                           // 0: wasimoff.v1.Subprotocol
+	(Envelope_MessageType)(0),                  // 1: wasimoff.v1.Envelope.MessageType
+	(Task_TraceEvent_EventType)(0),             // 2: wasimoff.v1.Task.TraceEvent.EventType
+	(*Envelope)(nil),                           // 3: wasimoff.v1.Envelope
+	(*Task)(nil),                               // 4: wasimoff.v1.Task
+	(*File)(nil),                               // 5: wasimoff.v1.File
+	(*Filesystem)(nil),                         // 6: wasimoff.v1.Filesystem
+	(*Event)(nil),                              // 7: wasimoff.v1.Event
+	(*Ping)(nil),                               // 8: wasimoff.v1.Ping
+	(*Task_Metadata)(nil),                      // 9: wasimoff.v1.Task.Metadata
+	(*Task_QoS)(nil),                           // 10: wasimoff.v1.Task.QoS
+	(*Task_Trace)(nil),                         // 11: wasimoff.v1.Task.Trace
+	(*Task_TraceEvent)(nil),                    // 12: wasimoff.v1.Task.TraceEvent
+	(*Task_Cancel)(nil),                        // 13: wasimoff.v1.Task.Cancel
+	(*Task_Resume)(nil),                        // 14: wasimoff.v1.Task.Resume
+	(*Task_Deliver)(nil),                       // 15: wasimoff.v1.Task.Deliver
+	(*Task_Layer)(nil),                         // 16: wasimoff.v1.Task.Layer
+	(*Task_Wasip1)(nil),                        // 17: wasimoff.v1.Task.Wasip1
+	(*Task_Pyodide)(nil),                       // 18: wasimoff.v1.Task.Pyodide
+	(*Task_Resume_Request)(nil),                // 19: wasimoff.v1.Task.Resume.Request
+	(*Task_Resume_Response)(nil),               // 20: wasimoff.v1.Task.Resume.Response
+	(*Task_Deliver_Request)(nil),               // 21: wasimoff.v1.Task.Deliver.Request
+	(*Task_Deliver_Response)(nil),              // 22: wasimoff.v1.Task.Deliver.Response
+	(*Task_Wasip1_Params)(nil),                 // 23: wasimoff.v1.Task.Wasip1.Params
+	(*Task_Wasip1_Output)(nil),                 // 24: wasimoff.v1.Task.Wasip1.Output
+	(*Task_Wasip1_Request)(nil),                // 25: wasimoff.v1.Task.Wasip1.Request
+	(*Task_Wasip1_Response)(nil),               // 26: wasimoff.v1.Task.Wasip1.Response
+	(*Task_Pyodide_Params)(nil),                // 27: wasimoff.v1.Task.Pyodide.Params
+	(*Task_Pyodide_Output)(nil),                // 28: wasimoff.v1.Task.Pyodide.Output
+	(*Task_Pyodide_Request)(nil),               // 29: wasimoff.v1.Task.Pyodide.Request
+	(*Task_Pyodide_Response)(nil),              // 30: wasimoff.v1.Task.Pyodide.Response
+	(*Filesystem_Listing)(nil),                 // 31: wasimoff.v1.Filesystem.Listing
+	(*Filesystem_List)(nil),                    // 32: wasimoff.v1.Filesystem.List
+	(*Filesystem_WasmInfo)(nil),                // 33: wasimoff.v1.Filesystem.WasmInfo
+	(*Filesystem_ArchiveInfo)(nil),             // 34: wasimoff.v1.Filesystem.ArchiveInfo
+	(*Filesystem_Probe)(nil),                   // 35: wasimoff.v1.Filesystem.Probe
+	(*Filesystem_Upload)(nil),                  // 36: wasimoff.v1.Filesystem.Upload
+	(*Filesystem_Download)(nil),                // 37: wasimoff.v1.Filesystem.Download
+	(*Filesystem_Chunk)(nil),                   // 38: wasimoff.v1.Filesystem.Chunk
+	(*Filesystem_Listing_Request)(nil),         // 39: wasimoff.v1.Filesystem.Listing.Request
+	(*Filesystem_Listing_Response)(nil),        // 40: wasimoff.v1.Filesystem.Listing.Response
+	(*Filesystem_List_Request)(nil),            // 41: wasimoff.v1.Filesystem.List.Request
+	(*Filesystem_List_Response)(nil),           // 42: wasimoff.v1.Filesystem.List.Response
+	(*Filesystem_List_Entry)(nil),              // 43: wasimoff.v1.Filesystem.List.Entry
+	(*Filesystem_Probe_Request)(nil),           // 44: wasimoff.v1.Filesystem.Probe.Request
+	(*Filesystem_Probe_Response)(nil),          // 45: wasimoff.v1.Filesystem.Probe.Response
+	(*Filesystem_Upload_Request)(nil),          // 46: wasimoff.v1.Filesystem.Upload.Request
+	(*Filesystem_Upload_Response)(nil),         // 47: wasimoff.v1.Filesystem.Upload.Response
+	(*Filesystem_Download_Request)(nil),        // 48: wasimoff.v1.Filesystem.Download.Request
+	(*Filesystem_Download_Response)(nil),       // 49: wasimoff.v1.Filesystem.Download.Response
+	(*Filesystem_Chunk_Upload)(nil),            // 50: wasimoff.v1.Filesystem.Chunk.Upload
+	(*Filesystem_Chunk_Download)(nil),          // 51: wasimoff.v1.Filesystem.Chunk.Download
+	(*Filesystem_Chunk_Upload_Request)(nil),    // 52: wasimoff.v1.Filesystem.Chunk.Upload.Request
+	(*Filesystem_Chunk_Upload_Response)(nil),   // 53: wasimoff.v1.Filesystem.Chunk.Upload.Response
+	(*Filesystem_Chunk_Download_Request)(nil),  // 54: wasimoff.v1.Filesystem.Chunk.Download.Request
+	(*Filesystem_Chunk_Download_Response)(nil), // 55: wasimoff.v1.Filesystem.Chunk.Download.Response
+	(*Event_GenericMessage)(nil),               // 56: wasimoff.v1.Event.GenericMessage
+	(*Event_ProviderResources)(nil),            // 57: wasimoff.v1.Event.ProviderResources
+	(*Event_ClusterInfo)(nil),                  // 58: wasimoff.v1.Event.ClusterInfo
+	(*Event_Throughput)(nil),                   // 59: wasimoff.v1.Event.Throughput
+	(*Event_FileSystemUpdate)(nil),             // 60: wasimoff.v1.Event.FileSystemUpdate
+	(*Event_Drain)(nil),                        // 61: wasimoff.v1.Event.Drain
+	(*Event_Subscribe)(nil),                    // 62: wasimoff.v1.Event.Subscribe
+	(*Event_Session)(nil),                      // 63: wasimoff.v1.Event.Session
+	(*Event_Subscribe_Request)(nil),            // 64: wasimoff.v1.Event.Subscribe.Request
+	(*Event_Subscribe_Response)(nil),           // 65: wasimoff.v1.Event.Subscribe.Response
+	(*anypb.Any)(nil),                          // 66: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil),              // 67: google.protobuf.Timestamp
 }
 var file_proto_v1_messages_proto_depIdxs = []int32{
 	1,  // 0: wasimoff.v1.Envelope.type:type_name -> wasimoff.v1.Envelope.MessageType
-	41, // 1: wasimoff.v1.Envelope.payload:type_name -> google.protobuf.Any
+	66, // 1: wasimoff.v1.Envelope.payload:type_name -> google.protobuf.Any
 	11, // 2: wasimoff.v1.Task.Metadata.trace:type_name -> wasimoff.v1.Task.Trace
-	42, // 3: wasimoff.v1.Task.QoS.deadline:type_name -> google.protobuf.Timestamp
+	67, // 3: wasimoff.v1.Task.QoS.deadline:type_name -> google.protobuf.Timestamp
 	12, // 4: wasimoff.v1.Task.Trace.events:type_name -> wasimoff.v1.Task.TraceEvent
 	2,  // 5: wasimoff.v1.Task.TraceEvent.event:type_name -> wasimoff.v1.Task.TraceEvent.EventType
-	5,  // 6: wasimoff.v1.Task.Wasip1.Params.binary:type_name -> wasimoff.v1.File
-	5,  // 7: wasimoff.v1.Task.Wasip1.Params.rootfs:type_name -> wasimoff.v1.File
-	5,  // 8: wasimoff.v1.Task.Wasip1.Output.artifacts:type_name -> wasimoff.v1.File
-	9,  // 9: wasimoff.v1.Task.Wasip1.Request.info:type_name -> wasimoff.v1.Task.Metadata
-	10, // 10: wasimoff.v1.Task.Wasip1.Request.qos:type_name -> wasimoff.v1.Task.QoS
-	16, // 11: wasimoff.v1.Task.Wasip1.Request.params:type_name -> wasimoff.v1.Task.Wasip1.Params
-	9,  // 12: wasimoff.v1.Task.Wasip1.Response.info:type_name -> wasimoff.v1.Task.Metadata
-	17, // 13: wasimoff.v1.Task.Wasip1.Response.ok:type_name -> wasimoff.v1.Task.Wasip1.Output
-	5,  // 14: wasimoff.v1.Task.Pyodide.Params.rootfs:type_name -> wasimoff.v1.File
-	5,  // 15: wasimoff.v1.Task.Pyodide.Output.artifacts:type_name -> wasimoff.v1.File
-	9,  // 16: wasimoff.v1.Task.Pyodide.Request.info:type_name -> wasimoff.v1.Task.Metadata
-	10, // 17: wasimoff.v1.Task.Pyodide.Request.qos:type_name -> wasimoff.v1.Task.QoS
-	20, // 18: wasimoff.v1.Task.Pyodide.Request.params:type_name -> wasimoff.v1.Task.Pyodide.Params
-	9,  // 19: wasimoff.v1.Task.Pyodide.Response.info:type_name -> wasimoff.v1.Task.Metadata
-	21, // 20: wasimoff.v1.Task.Pyodide.Response.ok:type_name -> wasimoff.v1.Task.Pyodide.Output
-	5,  // 21: wasimoff.v1.Filesystem.Upload.Request.upload:type_name -> wasimoff.v1.File
-	5,  // 22: wasimoff.v1.Filesystem.Download.Response.download:type_name -> wasimoff.v1.File
-	18, // 23: wasimoff.v1.Tasks.RunWasip1:input_type -> wasimoff.v1.Task.Wasip1.Request
-	22, // 24: wasimoff.v1.Tasks.RunPyodide:input_type -> wasimoff.v1.Task.Pyodide.Request
-	32, // 25: wasimoff.v1.Tasks.Upload:input_type -> wasimoff.v1.Filesystem.Upload.Request
-	19, // 26: wasimoff.v1.Tasks.RunWasip1:output_type -> wasimoff.v1.Task.Wasip1.Response
-	23, // 27: wasimoff.v1.Tasks.RunPyodide:output_type -> wasimoff.v1.Task.Pyodide.Response
-	33, // 28: wasimoff.v1.Tasks.Upload:output_type -> wasimoff.v1.Filesystem.Upload.Response
-	26, // [26:29] is the sub-list for method output_type
-	23, // [23:26] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	5,  // 6: wasimoff.v1.Task.Layer.archive:type_name -> wasimoff.v1.File
+	26, // 7: wasimoff.v1.Task.Deliver.Request.wasip1:type_name -> wasimoff.v1.Task.Wasip1.Response
+	30, // 8: wasimoff.v1.Task.Deliver.Request.pyodide:type_name -> wasimoff.v1.Task.Pyodide.Response
+	5,  // 9: wasimoff.v1.Task.Wasip1.Params.binary:type_name -> wasimoff.v1.File
+	5,  // 10: wasimoff.v1.Task.Wasip1.Params.rootfs:type_name -> wasimoff.v1.File
+	16, // 11: wasimoff.v1.Task.Wasip1.Params.layers:type_name -> wasimoff.v1.Task.Layer
+	5,  // 12: wasimoff.v1.Task.Wasip1.Params.stdin_file:type_name -> wasimoff.v1.File
+	5,  // 13: wasimoff.v1.Task.Wasip1.Output.artifacts:type_name -> wasimoff.v1.File
+	9,  // 14: wasimoff.v1.Task.Wasip1.Request.info:type_name -> wasimoff.v1.Task.Metadata
+	10, // 15: wasimoff.v1.Task.Wasip1.Request.qos:type_name -> wasimoff.v1.Task.QoS
+	23, // 16: wasimoff.v1.Task.Wasip1.Request.params:type_name -> wasimoff.v1.Task.Wasip1.Params
+	9,  // 17: wasimoff.v1.Task.Wasip1.Response.info:type_name -> wasimoff.v1.Task.Metadata
+	24, // 18: wasimoff.v1.Task.Wasip1.Response.ok:type_name -> wasimoff.v1.Task.Wasip1.Output
+	5,  // 19: wasimoff.v1.Task.Pyodide.Params.rootfs:type_name -> wasimoff.v1.File
+	5,  // 20: wasimoff.v1.Task.Pyodide.Output.artifacts:type_name -> wasimoff.v1.File
+	9,  // 21: wasimoff.v1.Task.Pyodide.Request.info:type_name -> wasimoff.v1.Task.Metadata
+	10, // 22: wasimoff.v1.Task.Pyodide.Request.qos:type_name -> wasimoff.v1.Task.QoS
+	27, // 23: wasimoff.v1.Task.Pyodide.Request.params:type_name -> wasimoff.v1.Task.Pyodide.Params
+	9,  // 24: wasimoff.v1.Task.Pyodide.Response.info:type_name -> wasimoff.v1.Task.Metadata
+	28, // 25: wasimoff.v1.Task.Pyodide.Response.ok:type_name -> wasimoff.v1.Task.Pyodide.Output
+	43, // 26: wasimoff.v1.Filesystem.List.Response.files:type_name -> wasimoff.v1.Filesystem.List.Entry
+	67, // 27: wasimoff.v1.Filesystem.List.Entry.uploaded:type_name -> google.protobuf.Timestamp
+	67, // 28: wasimoff.v1.Filesystem.List.Entry.accessed:type_name -> google.protobuf.Timestamp
+	33, // 29: wasimoff.v1.Filesystem.List.Entry.wasm:type_name -> wasimoff.v1.Filesystem.WasmInfo
+	34, // 30: wasimoff.v1.Filesystem.List.Entry.zip:type_name -> wasimoff.v1.Filesystem.ArchiveInfo
+	34, // 31: wasimoff.v1.Filesystem.List.Entry.tar:type_name -> wasimoff.v1.Filesystem.ArchiveInfo
+	5,  // 32: wasimoff.v1.Filesystem.Upload.Request.upload:type_name -> wasimoff.v1.File
+	5,  // 33: wasimoff.v1.Filesystem.Download.Response.download:type_name -> wasimoff.v1.File
+	25, // 34: wasimoff.v1.Tasks.RunWasip1:input_type -> wasimoff.v1.Task.Wasip1.Request
+	29, // 35: wasimoff.v1.Tasks.RunPyodide:input_type -> wasimoff.v1.Task.Pyodide.Request
+	46, // 36: wasimoff.v1.Tasks.Upload:input_type -> wasimoff.v1.Filesystem.Upload.Request
+	52, // 37: wasimoff.v1.Tasks.UploadChunk:input_type -> wasimoff.v1.Filesystem.Chunk.Upload.Request
+	54, // 38: wasimoff.v1.Tasks.DownloadChunk:input_type -> wasimoff.v1.Filesystem.Chunk.Download.Request
+	41, // 39: wasimoff.v1.Tasks.ListFiles:input_type -> wasimoff.v1.Filesystem.List.Request
+	26, // 40: wasimoff.v1.Tasks.RunWasip1:output_type -> wasimoff.v1.Task.Wasip1.Response
+	30, // 41: wasimoff.v1.Tasks.RunPyodide:output_type -> wasimoff.v1.Task.Pyodide.Response
+	47, // 42: wasimoff.v1.Tasks.Upload:output_type -> wasimoff.v1.Filesystem.Upload.Response
+	53, // 43: wasimoff.v1.Tasks.UploadChunk:output_type -> wasimoff.v1.Filesystem.Chunk.Upload.Response
+	55, // 44: wasimoff.v1.Tasks.DownloadChunk:output_type -> wasimoff.v1.Filesystem.Chunk.Download.Response
+	42, // 45: wasimoff.v1.Tasks.ListFiles:output_type -> wasimoff.v1.Filesystem.List.Response
+	40, // [40:46] is the sub-list for method output_type
+	34, // [34:40] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_messages_proto_init() }
@@ -2717,15 +4374,19 @@ func file_proto_v1_messages_proto_init() {
 	if File_proto_v1_messages_proto != nil {
 		return
 	}
-	file_proto_v1_messages_proto_msgTypes[16].OneofWrappers = []any{
+	file_proto_v1_messages_proto_msgTypes[18].OneofWrappers = []any{
+		(*Task_Deliver_Request_Wasip1)(nil),
+		(*Task_Deliver_Request_Pyodide)(nil),
+	}
+	file_proto_v1_messages_proto_msgTypes[23].OneofWrappers = []any{
 		(*Task_Wasip1_Response_Error)(nil),
 		(*Task_Wasip1_Response_Ok)(nil),
 	}
-	file_proto_v1_messages_proto_msgTypes[17].OneofWrappers = []any{
+	file_proto_v1_messages_proto_msgTypes[24].OneofWrappers = []any{
 		(*Task_Pyodide_Params_Script)(nil),
 		(*Task_Pyodide_Params_Pickle)(nil),
 	}
-	file_proto_v1_messages_proto_msgTypes[20].OneofWrappers = []any{
+	file_proto_v1_messages_proto_msgTypes[27].OneofWrappers = []any{
 		(*Task_Pyodide_Response_Error)(nil),
 		(*Task_Pyodide_Response_Ok)(nil),
 	}
@@ -2735,7 +4396,7 @@ func file_proto_v1_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_messages_proto_rawDesc), len(file_proto_v1_messages_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   38,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,7 +26,7 @@ from google.protobuf import any_pb2 as google_dot_protobuf_dot_any__pb2
 from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x17proto/v1/messages.proto\x12\x0bwasimoff.v1\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x01\n\x08\x45nvelope\x12\x1a\n\x08sequence\x18\x01 \x01(\x04R\x08sequence\x12\x35\n\x04type\x18\x02 \x01(\x0e\x32!.wasimoff.v1.Envelope.MessageTypeR\x04type\x12\x14\n\x05\x65rror\x18\x03 \x01(\tR\x05\x65rror\x12.\n\x07payload\x18\x04 \x01(\x0b\x32\x14.google.protobuf.AnyR\x07payload\"@\n\x0bMessageType\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0b\n\x07Request\x10\x01\x12\x0c\n\x08Response\x10\x02\x12\t\n\x05\x45vent\x10\x03\"\xa6\x15\n\x04Task\x1a\xa1\x01\n\x08Metadata\x12\x0e\n\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n\trequester\x18\x02 \x01(\tR\trequester\x12\x1a\n\x08provider\x18\x03 \x01(\tR\x08provider\x12\x1c\n\treference\x18\x04 \x01(\tR\treference\x12-\n\x05trace\x18\x05 \x01(\x0b\x32\x17.wasimoff.v1.Task.TraceR\x05trace\x1aw\n\x03QoS\x12\x1a\n\x08priority\x18\x01 \x01(\x08R\x08priority\x12\x36\n\x08\x64\x65\x61\x64line\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\x08\x64\x65\x61\x64line\x12\x1c\n\timmediate\x18\x03 \x01(\x08R\timmediate\x1as\n\x05Trace\x12\x18\n\x07\x63reated\x18\x01 \x01(\x03R\x07\x63reated\x12\x1a\n\x08\x64uration\x18\x02 \x01(\x04R\x08\x64uration\x12\x34\n\x06\x65vents\x18\x03 \x03(\x0b\x32\x1c.wasimoff.v1.Task.TraceEventR\x06\x65vents\x1a\xac\x07\n\nTraceEvent\x12\x1a\n\x08unixnano\x18\x01 \x01(\x03R\x08unixnano\x12<\n\x05\x65vent\x18\x02 \x01(\x0e\x32&.wasimoff.v1.Task.TraceEvent.EventTypeR\x05\x65vent\x12\x18\n\x07\x64\x65tails\x18\x03 \x01(\tR\x07\x64\x65tails\"\xa9\x06\n\tEventType\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0f\n\x0b\x43lientError\x10\n\x12\x19\n\x15\x43lientTransmitRequest\x10\x0b\x12\x1a\n\x16\x43lientReceivedResponse\x10\x0c\x12\x0f\n\x0b\x42rokerError\x10\x14\x12\x1f\n\x1b\x42rokerReceivedClientRequest\x10\x15\x12\x13\n\x0f\x42rokerQueueTask\x10\x16\x12\x16\n\x12\x42rokerScheduleTask\x10\x17\x12\x1e\n\x1a\x42rokerTransmitProviderTask\x10\x18\x12 \n\x1c\x42rokerReceivedProviderResult\x10\x19\x12 \n\x1c\x42rokerTransmitClientResponse\x10\x1a\x12\x11\n\rProviderError\x10\x1e\x12\x18\n\x14ProviderTaskReceived\x10\x1f\x12\x15\n\x11ProviderGetWorker\x10 \x12\x18\n\x14ProviderPostToWorker\x10!\x12\x19\n\x15ProviderWorkerPrepare\x10\"\x12\x19\n\x15ProviderWorkerExecute\x10#\x12\x16\n\x12ProviderWorkerDone\x10$\x12\x1a\n\x16ProviderTransmitResult\x10%\x12\x19\n\x15\x41rtDecoSchedulerEnter\x10&\x12\x19\n\x15\x41rtDecoSchedulerLeave\x10\'\x12\x1d\n\x19\x41rtDecoSchedulerScheduled\x10(\x12\x1f\n\x1b\x41rtDecoSchedulerResultEnter\x10)\x12\x1f\n\x1b\x41rtDecoSchedulerResultLeave\x10*\x12\x1d\n\x19\x41rtDecoWasimoffSerialized\x10+\x12\x1f\n\x1b\x41rtDecoWasimoffDeserialized\x10,\x12#\n\x1f\x41rtDecoSchedulerProviderConnect\x10-\x12#\n\x1f\x41rtDecoSchedulerProviderOffload\x10.\x12\x1b\n\x17\x41rtDecoSchedulerRequeue\x10/\x1a\x30\n\x06\x43\x61ncel\x12\x0e\n\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n\x06reason\x18\x02 \x01(\tR\x06reason\x1a\xf9\x04\n\x06Wasip1\x1a\xba\x01\n\x06Params\x12)\n\x06\x62inary\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06\x62inary\x12\x12\n\x04\x61rgs\x18\x02 \x03(\tR\x04\x61rgs\x12\x12\n\x04\x65nvs\x18\x03 \x03(\tR\x04\x65nvs\x12\x14\n\x05stdin\x18\x04 \x01(\x0cR\x05stdin\x12)\n\x06rootfs\x18\x05 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06rootfs\x12\x1c\n\tartifacts\x18\x06 \x03(\tR\tartifacts\x1a\x81\x01\n\x06Output\x12\x16\n\x06status\x18\x01 \x01(\x05R\x06status\x12\x16\n\x06stdout\x18\x02 \x01(\x0cR\x06stdout\x12\x16\n\x06stderr\x18\x03 \x01(\x0cR\x06stderr\x12/\n\tartifacts\x18\x04 \x01(\x0b\x32\x11.wasimoff.v1.FileR\tartifacts\x1a\x9b\x01\n\x07Request\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\'\n\x03qos\x18\x02 \x01(\x0b\x32\x15.wasimoff.v1.Task.QoSR\x03qos\x12\x37\n\x06params\x18\x03 \x01(\x0b\x32\x1f.wasimoff.v1.Task.Wasip1.ParamsR\x06params\x1a\x8f\x01\n\x08Response\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\x16\n\x05\x65rror\x18\x02 \x01(\tH\x00R\x05\x65rror\x12\x31\n\x02ok\x18\x03 \x01(\x0b\x32\x1f.wasimoff.v1.Task.Wasip1.OutputH\x00R\x02okB\x08\n\x06result\x1a\xae\x05\n\x07Pyodide\x1a\xd2\x01\n\x06Params\x12\x1a\n\x08packages\x18\x01 \x03(\tR\x08packages\x12\x18\n\x06script\x18\x02 \x01(\tH\x00R\x06script\x12\x18\n\x06pickle\x18\x03 \x01(\x0cH\x00R\x06pickle\x12\x12\n\x04\x65nvs\x18\x04 \x03(\tR\x04\x65nvs\x12\x14\n\x05stdin\x18\x05 \x01(\x0cR\x05stdin\x12)\n\x06rootfs\x18\x06 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06rootfs\x12\x1c\n\tartifacts\x18\x07 \x03(\tR\tartifactsB\x05\n\x03run\x1a\x9b\x01\n\x06Output\x12\x16\n\x06pickle\x18\x01 \x01(\x0cR\x06pickle\x12\x16\n\x06stdout\x18\x02 \x01(\x0cR\x06stdout\x12\x16\n\x06stderr\x18\x03 \x01(\x0cR\x06stderr\x12\x18\n\x07version\x18\x04 \x01(\tR\x07version\x12/\n\tartifacts\x18\x05 \x01(\x0b\x32\x11.wasimoff.v1.FileR\tartifacts\x1a\x9c\x01\n\x07Request\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\'\n\x03qos\x18\x02 \x01(\x0b\x32\x15.wasimoff.v1.Task.QoSR\x03qos\x12\x38\n\x06params\x18\x03 \x01(\x0b\x32 .wasimoff.v1.Task.Pyodide.ParamsR\x06params\x1a\x90\x01\n\x08Response\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\x16\n\x05\x65rror\x18\x02 \x01(\tH\x00R\x05\x65rror\x12\x32\n\x02ok\x18\x03 \x01(\x0b\x32 .wasimoff.v1.Task.Pyodide.OutputH\x00R\x02okB\x08\n\x06result\"B\n\x04\x46ile\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x12\x14\n\x05media\x18\x02 \x01(\tR\x05media\x12\x12\n\x04\x62lob\x18\x03 \x01(\x0cR\x04\x62lob\"\xde\x02\n\nFilesystem\x1a\x36\n\x07Listing\x1a\t\n\x07Request\x1a \n\x08Response\x12\x14\n\x05\x66iles\x18\x01 \x03(\tR\x05\x66iles\x1a\x42\n\x05Probe\x1a\x1d\n\x07Request\x12\x12\n\x04\x66ile\x18\x01 \x01(\tR\x04\x66ile\x1a\x1a\n\x08Response\x12\x0e\n\x02ok\x18\x01 \x01(\x08R\x02ok\x1a\\\n\x06Upload\x1a\x34\n\x07Request\x12)\n\x06upload\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06upload\x1a\x1c\n\x08Response\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x1av\n\x08\x44ownload\x1a\x1d\n\x07Request\x12\x12\n\x04\x66ile\x18\x01 \x01(\tR\x04\x66ile\x1aK\n\x08Response\x12-\n\x08\x64ownload\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x08\x64ownload\x12\x10\n\x03\x65rr\x18\x02 \x01(\tR\x03\x65rr\"\xaf\x02\n\x05\x45vent\x1a*\n\x0eGenericMessage\x12\x18\n\x07message\x18\x01 \x01(\tR\x07message\x1aK\n\x11ProviderResources\x12 \n\x0b\x63oncurrency\x18\x01 \x01(\rR\x0b\x63oncurrency\x12\x14\n\x05tasks\x18\x02 \x01(\rR\x05tasks\x1a+\n\x0b\x43lusterInfo\x12\x1c\n\tproviders\x18\x01 \x01(\rR\tproviders\x1a<\n\nThroughput\x12\x18\n\x07overall\x18\x01 \x01(\x02R\x07overall\x12\x14\n\x05yours\x18\x02 \x01(\x02R\x05yours\x1a\x42\n\x10\x46ileSystemUpdate\x12\x14\n\x05\x61\x64\x64\x65\x64\x18\x01 \x03(\tR\x05\x61\x64\x64\x65\x64\x12\x18\n\x07removed\x18\x02 \x03(\tR\x07removed\"\x06\n\x04Ping*\\\n\x0bSubprotocol\x12\x0b\n\x07UNKNOWN\x10\x00\x12!\n\x1dwasimoff_provider_v1_protobuf\x10\x01\x12\x1d\n\x19wasimoff_provider_v1_json\x10\x02\x32\x8f\x02\n\x05Tasks\x12R\n\tRunWasip1\x12 .wasimoff.v1.Task.Wasip1.Request\x1a!.wasimoff.v1.Task.Wasip1.Response\"\x00\x12U\n\nRunPyodide\x12!.wasimoff.v1.Task.Pyodide.Request\x1a\".wasimoff.v1.Task.Pyodide.Response\"\x00\x12[\n\x06Upload\x12&.wasimoff.v1.Filesystem.Upload.Request\x1a\'.wasimoff.v1.Filesystem.Upload.Response\"\x00\x42\x1fZ\x1dwasi.team/proto/v1;wasimoffv1b\x08\x65\x64itionsp\xe8\x07')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x17proto/v1/messages.proto\x12\x0bwasimoff.v1\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x01\n\x08\x45nvelope\x12\x1a\n\x08sequence\x18\x01 \x01(\x04R\x08sequence\x12\x35\n\x04type\x18\x02 \x01(\x0e\x32!.wasimoff.v1.Envelope.MessageTypeR\x04type\x12\x14\n\x05\x65rror\x18\x03 \x01(\tR\x05\x65rror\x12.\n\x07payload\x18\x04 \x01(\x0b\x32\x14.google.protobuf.AnyR\x07payload\"@\n\x0bMessageType\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0b\n\x07Request\x10\x01\x12\x0c\n\x08Response\x10\x02\x12\t\n\x05\x45vent\x10\x03\"\xe1\x19\n\x04Task\x1a\xbf\x01\n\x08Metadata\x12\x0e\n\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n\trequester\x18\x02 \x01(\tR\trequester\x12\x1a\n\x08provider\x18\x03 \x01(\tR\x08provider\x12\x1c\n\treference\x18\x04 \x01(\tR\treference\x12-\n\x05trace\x18\x05 \x01(\x0b\x32\x17.wasimoff.v1.Task.TraceR\x05trace\x12\x1c\n\tnamespace\x18\x06 \x01(\tR\tnamespace\x1aw\n\x03QoS\x12\x1a\n\x08priority\x18\x01 \x01(\x08R\x08priority\x12\x36\n\x08\x64\x65\x61\x64line\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\x08\x64\x65\x61\x64line\x12\x1c\n\timmediate\x18\x03 \x01(\x08R\timmediate\x1as\n\x05Trace\x12\x18\n\x07\x63reated\x18\x01 \x01(\x03R\x07\x63reated\x12\x1a\n\x08\x64uration\x18\x02 \x01(\x04R\x08\x64uration\x12\x34\n\x06\x65vents\x18\x03 \x03(\x0b\x32\x1c.wasimoff.v1.Task.TraceEventR\x06\x65vents\x1a\xac\x07\n\nTraceEvent\x12\x1a\n\x08unixnano\x18\x01 \x01(\x03R\x08unixnano\x12<\n\x05\x65vent\x18\x02 \x01(\x0e\x32&.wasimoff.v1.Task.TraceEvent.EventTypeR\x05\x65vent\x12\x18\n\x07\x64\x65tails\x18\x03 \x01(\tR\x07\x64\x65tails\"\xa9\x06\n\tEventType\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0f\n\x0b\x43lientError\x10\n\x12\x19\n\x15\x43lientTransmitRequest\x10\x0b\x12\x1a\n\x16\x43lientReceivedResponse\x10\x0c\x12\x0f\n\x0b\x42rokerError\x10\x14\x12\x1f\n\x1b\x42rokerReceivedClientRequest\x10\x15\x12\x13\n\x0f\x42rokerQueueTask\x10\x16\x12\x16\n\x12\x42rokerScheduleTask\x10\x17\x12\x1e\n\x1a\x42rokerTransmitProviderTask\x10\x18\x12 \n\x1c\x42rokerReceivedProviderResult\x10\x19\x12 \n\x1c\x42rokerTransmitClientResponse\x10\x1a\x12\x11\n\rProviderError\x10\x1e\x12\x18\n\x14ProviderTaskReceived\x10\x1f\x12\x15\n\x11ProviderGetWorker\x10 \x12\x18\n\x14ProviderPostToWorker\x10!\x12\x19\n\x15ProviderWorkerPrepare\x10\"\x12\x19\n\x15ProviderWorkerExecute\x10#\x12\x16\n\x12ProviderWorkerDone\x10$\x12\x1a\n\x16ProviderTransmitResult\x10%\x12\x19\n\x15\x41rtDecoSchedulerEnter\x10&\x12\x19\n\x15\x41rtDecoSchedulerLeave\x10\'\x12\x1d\n\x19\x41rtDecoSchedulerScheduled\x10(\x12\x1f\n\x1b\x41rtDecoSchedulerResultEnter\x10)\x12\x1f\n\x1b\x41rtDecoSchedulerResultLeave\x10*\x12\x1d\n\x19\x41rtDecoWasimoffSerialized\x10+\x12\x1f\n\x1b\x41rtDecoWasimoffDeserialized\x10,\x12#\n\x1f\x41rtDecoSchedulerProviderConnect\x10-\x12#\n\x1f\x41rtDecoSchedulerProviderOffload\x10.\x12\x1b\n\x17\x41rtDecoSchedulerRequeue\x10/\x1a\x30\n\x06\x43\x61ncel\x12\x0e\n\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n\x06reason\x18\x02 \x01(\tR\x06reason\x1aS\n\x06Resume\x1a#\n\x07Request\x12\x18\n\x07pending\x18\x01 \x03(\tR\x07pending\x1a$\n\x08Response\x12\x18\n\x07unknown\x18\x01 \x03(\tR\x07unknown\x1a\xa8\x01\n\x07\x44\x65liver\x1a\x90\x01\n\x07Request\x12;\n\x06wasip1\x18\x01 \x01(\x0b\x32!.wasimoff.v1.Task.Wasip1.ResponseH\x00R\x06wasip1\x12>\n\x07pyodide\x18\x02 \x01(\x0b\x32\".wasimoff.v1.Task.Pyodide.ResponseH\x00R\x07pyodideB\x08\n\x06result\x1a\n\n\x08Response\x1a\x66\n\x05Layer\x12+\n\x07\x61rchive\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x07\x61rchive\x12\x14\n\x05mount\x18\x02 \x01(\tR\x05mount\x12\x1a\n\x08readonly\x18\x03 \x01(\x08R\x08readonly\x1a\x85\x06\n\x06Wasip1\x1a\xc6\x02\n\x06Params\x12)\n\x06\x62inary\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06\x62inary\x12\x12\n\x04\x61rgs\x18\x02 \x03(\tR\x04\x61rgs\x12\x12\n\x04\x65nvs\x18\x03 \x03(\tR\x04\x65nvs\x12\x14\n\x05stdin\x18\x04 \x01(\x0cR\x05stdin\x12)\n\x06rootfs\x18\x05 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06rootfs\x12\x1c\n\tartifacts\x18\x06 \x03(\tR\tartifacts\x12\'\n\x0fstore_artifacts\x18\x07 \x01(\x08R\x0estoreArtifacts\x12/\n\x06layers\x18\x08 \x03(\x0b\x32\x17.wasimoff.v1.Task.LayerR\x06layers\x12\x30\n\nstdin_file\x18\t \x01(\x0b\x32\x11.wasimoff.v1.FileR\tstdinFile\x1a\x81\x01\n\x06Output\x12\x16\n\x06status\x18\x01 \x01(\x05R\x06status\x12\x16\n\x06stdout\x18\x02 \x01(\x0cR\x06stdout\x12\x16\n\x06stderr\x18\x03 \x01(\x0cR\x06stderr\x12/\n\tartifacts\x18\x04 \x01(\x0b\x32\x11.wasimoff.v1.FileR\tartifacts\x1a\x9b\x01\n\x07Request\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\'\n\x03qos\x18\x02 \x01(\x0b\x32\x15.wasimoff.v1.Task.QoSR\x03qos\x12\x37\n\x06params\x18\x03 \x01(\x0b\x32\x1f.wasimoff.v1.Task.Wasip1.ParamsR\x06params\x1a\x8f\x01\n\x08Response\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\x16\n\x05\x65rror\x18\x02 \x01(\tH\x00R\x05\x65rror\x12\x31\n\x02ok\x18\x03 \x01(\x0b\x32\x1f.wasimoff.v1.Task.Wasip1.OutputH\x00R\x02okB\x08\n\x06result\x1a\xd7\x05\n\x07Pyodide\x1a\xfb\x01\n\x06Params\x12\x1a\n\x08packages\x18\x01 \x03(\tR\x08packages\x12\x18\n\x06script\x18\x02 \x01(\tH\x00R\x06script\x12\x18\n\x06pickle\x18\x03 \x01(\x0cH\x00R\x06pickle\x12\x12\n\x04\x65nvs\x18\x04 \x03(\tR\x04\x65nvs\x12\x14\n\x05stdin\x18\x05 \x01(\x0cR\x05stdin\x12)\n\x06rootfs\x18\x06 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06rootfs\x12\x1c\n\tartifacts\x18\x07 \x03(\tR\tartifacts\x12\'\n\x0fstore_artifacts\x18\x08 \x01(\x08R\x0estoreArtifactsB\x05\n\x03run\x1a\x9b\x01\n\x06Output\x12\x16\n\x06pickle\x18\x01 \x01(\x0cR\x06pickle\x12\x16\n\x06stdout\x18\x02 \x01(\x0cR\x06stdout\x12\x16\n\x06stderr\x18\x03 \x01(\x0cR\x06stderr\x12\x18\n\x07version\x18\x04 \x01(\tR\x07version\x12/\n\tartifacts\x18\x05 \x01(\x0b\x32\x11.wasimoff.v1.FileR\tartifacts\x1a\x9c\x01\n\x07Request\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\'\n\x03qos\x18\x02 \x01(\x0b\x32\x15.wasimoff.v1.Task.QoSR\x03qos\x12\x38\n\x06params\x18\x03 \x01(\x0b\x32 .wasimoff.v1.Task.Pyodide.ParamsR\x06params\x1a\x90\x01\n\x08Response\x12.\n\x04info\x18\x01 \x01(\x0b\x32\x1a.wasimoff.v1.Task.MetadataR\x04info\x12\x16\n\x05\x65rror\x18\x02 \x01(\tH\x00R\x05\x65rror\x12\x32\n\x02ok\x18\x03 \x01(\x0b\x32 .wasimoff.v1.Task.Pyodide.OutputH\x00R\x02okB\x08\n\x06result\"B\n\x04\x46ile\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x12\x14\n\x05media\x18\x02 \x01(\tR\x05media\x12\x12\n\x04\x62lob\x18\x03 \x01(\x0cR\x04\x62lob\"\xfc\x0c\n\nFilesystem\x1a\x36\n\x07Listing\x1a\t\n\x07Request\x1a \n\x08Response\x12\x14\n\x05\x66iles\x18\x01 \x03(\tR\x05\x66iles\x1a\xbb\x04\n\x04List\x1aO\n\x07Request\x12\x16\n\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n\x05limit\x18\x02 \x01(\rR\x05limit\x12\x16\n\x06\x63ursor\x18\x03 \x01(\tR\x06\x63ursor\x1aX\n\x08Response\x12\x38\n\x05\x66iles\x18\x01 \x03(\x0b\x32\".wasimoff.v1.Filesystem.List.EntryR\x05\x66iles\x12\x12\n\x04next\x18\x02 \x01(\tR\x04next\x1a\x87\x03\n\x05\x45ntry\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n\x03ref\x18\x02 \x01(\tR\x03ref\x12\x14\n\x05media\x18\x03 \x01(\tR\x05media\x12\x12\n\x04size\x18\x04 \x01(\x04R\x04size\x12\x36\n\x08uploaded\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\x08uploaded\x12\x36\n\x08\x61\x63\x63\x65ssed\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\x08\x61\x63\x63\x65ssed\x12\x1a\n\x08uploader\x18\x07 \x01(\tR\x08uploader\x12\x34\n\x04wasm\x18\x08 \x01(\x0b\x32 .wasimoff.v1.Filesystem.WasmInfoR\x04wasm\x12\x35\n\x03zip\x18\t \x01(\x0b\x32#.wasimoff.v1.Filesystem.ArchiveInfoR\x03zip\x12\x35\n\x03tar\x18\n \x01(\x0b\x32#.wasimoff.v1.Filesystem.ArchiveInfoR\x03tar\x1a\xc8\x01\n\x08WasmInfo\x12\x1c\n\tcomponent\x18\x01 \x01(\x08R\tcomponent\x12\x18\n\x07version\x18\x02 \x01(\rR\x07version\x12\x12\n\x04wasi\x18\x03 \x01(\tR\x04wasi\x12\x18\n\x07imports\x18\x04 \x03(\tR\x07imports\x12\x18\n\x07\x65xports\x18\x05 \x03(\tR\x07\x65xports\x12\x1d\n\nmemory_min\x18\x06 \x01(\x04R\tmemoryMin\x12\x1d\n\nmemory_max\x18\x07 \x01(\x04R\tmemoryMax\x1a?\n\x0b\x41rchiveInfo\x12\x14\n\x05\x66iles\x18\x01 \x01(\rR\x05\x66iles\x12\x1a\n\x08unpacked\x18\x02 \x01(\x04R\x08unpacked\x1a\x42\n\x05Probe\x1a\x1d\n\x07Request\x12\x12\n\x04\x66ile\x18\x01 \x01(\tR\x04\x66ile\x1a\x1a\n\x08Response\x12\x0e\n\x02ok\x18\x01 \x01(\x08R\x02ok\x1a\\\n\x06Upload\x1a\x34\n\x07Request\x12)\n\x06upload\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x06upload\x1a\x1c\n\x08Response\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x1av\n\x08\x44ownload\x1a\x1d\n\x07Request\x12\x12\n\x04\x66ile\x18\x01 \x01(\tR\x04\x66ile\x1aK\n\x08Response\x12-\n\x08\x64ownload\x18\x01 \x01(\x0b\x32\x11.wasimoff.v1.FileR\x08\x64ownload\x12\x10\n\x03\x65rr\x18\x02 \x01(\tR\x03\x65rr\x1a\xd1\x03\n\x05\x43hunk\x1a\xde\x01\n\x06Upload\x1a\x9d\x01\n\x07Request\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x12\x12\n\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n\x05media\x18\x03 \x01(\tR\x05media\x12\x12\n\x04size\x18\x04 \x01(\x04R\x04size\x12\x16\n\x06offset\x18\x05 \x01(\x04R\x06offset\x12\x12\n\x04\x64\x61ta\x18\x06 \x01(\x0cR\x04\x64\x61ta\x12\x16\n\x06\x64igest\x18\x07 \x01(\tR\x06\x64igest\x1a\x34\n\x08Response\x12\x16\n\x06offset\x18\x01 \x01(\x04R\x06offset\x12\x10\n\x03ref\x18\x02 \x01(\tR\x03ref\x1a\xe6\x01\n\x08\x44ownload\x1aM\n\x07Request\x12\x12\n\x04\x66ile\x18\x01 \x01(\tR\x04\x66ile\x12\x16\n\x06offset\x18\x02 \x01(\x04R\x06offset\x12\x16\n\x06length\x18\x03 \x01(\rR\x06length\x1a\x8a\x01\n\x08Response\x12\x10\n\x03ref\x18\x01 \x01(\tR\x03ref\x12\x14\n\x05media\x18\x02 \x01(\tR\x05media\x12\x12\n\x04size\x18\x03 \x01(\x04R\x04size\x12\x16\n\x06offset\x18\x04 \x01(\x04R\x06offset\x12\x12\n\x04\x64\x61ta\x18\x05 \x01(\x0cR\x04\x64\x61ta\x12\x16\n\x06\x64igest\x18\x06 \x01(\tR\x06\x64igest\"\xb9\x04\n\x05\x45vent\x1a*\n\x0eGenericMessage\x12\x18\n\x07message\x18\x01 \x01(\tR\x07message\x1aK\n\x11ProviderResources\x12 \n\x0b\x63oncurrency\x18\x01 \x01(\rR\x0b\x63oncurrency\x12\x14\n\x05tasks\x18\x02 \x01(\rR\x05tasks\x1aq\n\x0b\x43lusterInfo\x12\x1c\n\tproviders\x18\x01 \x01(\rR\tproviders\x12\x18\n\x07workers\x18\x02 \x01(\rR\x07workers\x12\x12\n\x04\x62usy\x18\x03 \x01(\rR\x04\x62usy\x12\x16\n\x06queued\x18\x04 \x01(\rR\x06queued\x1a<\n\nThroughput\x12\x18\n\x07overall\x18\x01 \x01(\x02R\x07overall\x12\x14\n\x05yours\x18\x02 \x01(\x02R\x05yours\x1a\x42\n\x10\x46ileSystemUpdate\x12\x14\n\x05\x61\x64\x64\x65\x64\x18\x01 \x03(\tR\x05\x61\x64\x64\x65\x64\x12\x18\n\x07removed\x18\x02 \x03(\tR\x07removed\x1a\x1f\n\x05\x44rain\x12\x16\n\x06reason\x18\x01 \x01(\tR\x06reason\x1aZ\n\tSubscribe\x1a%\n\x07Request\x12\x1a\n\x08interval\x18\x01 \x01(\rR\x08interval\x1a&\n\x08Response\x12\x1a\n\x08interval\x18\x01 \x01(\rR\x08interval\x1a\x45\n\x07Session\x12\x0e\n\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n\x05token\x18\x02 \x01(\tR\x05token\x12\x14\n\x05grace\x18\x03 \x01(\rR\x05grace\"\x06\n\x04Ping*\\\n\x0bSubprotocol\x12\x0b\n\x07UNKNOWN\x10\x00\x12!\n\x1dwasimoff_provider_v1_protobuf\x10\x01\x12\x1d\n\x19wasimoff_provider_v1_json\x10\x02\x32\xcd\x04\n\x05Tasks\x12R\n\tRunWasip1\x12 .wasimoff.v1.Task.Wasip1.Request\x1a!.wasimoff.v1.Task.Wasip1.Response\"\x00\x12U\n\nRunPyodide\x12!.wasimoff.v1.Task.Pyodide.Request\x1a\".wasimoff.v1.Task.Pyodide.Response\"\x00\x12[\n\x06Upload\x12&.wasimoff.v1.Filesystem.Upload.Request\x1a\'.wasimoff.v1.Filesystem.Upload.Response\"\x00\x12l\n\x0bUploadChunk\x12,.wasimoff.v1.Filesystem.Chunk.Upload.Request\x1a-.wasimoff.v1.Filesystem.Chunk.Upload.Response\"\x00\x12r\n\rDownloadChunk\x12..wasimoff.v1.Filesystem.Chunk.Download.Request\x1a/.wasimoff.v1.Filesystem.Chunk.Download.Response\"\x00\x12Z\n\tListFiles\x12$.wasimoff.v1.Filesystem.List.Request\x1a%.wasimoff.v1.Filesystem.List.Response\"\x00\x42\x1fZ\x1dwasi.team/proto/v1;wasimoffv1b\x08\x65\x64itionsp\xe8\x07')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -34,88 +34,138 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'proto.v1.messages_pb2', _gl
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z\035wasi.team/proto/v1;wasimoffv1'
-  _globals['_SUBPROTOCOL']._serialized_start=3796
-  _globals['_SUBPROTOCOL']._serialized_end=3888
+  _globals['_SUBPROTOCOL']._serialized_start=5943
+  _globals['_SUBPROTOCOL']._serialized_end=6035
   _globals['_ENVELOPE']._serialized_start=101
   _globals['_ENVELOPE']._serialized_end=330
   _globals['_ENVELOPE_MESSAGETYPE']._serialized_start=266
   _globals['_ENVELOPE_MESSAGETYPE']._serialized_end=330
   _globals['_TASK']._serialized_start=333
-  _globals['_TASK']._serialized_end=3059
+  _globals['_TASK']._serialized_end=3630
   _globals['_TASK_METADATA']._serialized_start=342
-  _globals['_TASK_METADATA']._serialized_end=503
-  _globals['_TASK_QOS']._serialized_start=505
-  _globals['_TASK_QOS']._serialized_end=624
-  _globals['_TASK_TRACE']._serialized_start=626
-  _globals['_TASK_TRACE']._serialized_end=741
-  _globals['_TASK_TRACEEVENT']._serialized_start=744
-  _globals['_TASK_TRACEEVENT']._serialized_end=1684
-  _globals['_TASK_TRACEEVENT_EVENTTYPE']._serialized_start=875
-  _globals['_TASK_TRACEEVENT_EVENTTYPE']._serialized_end=1684
-  _globals['_TASK_CANCEL']._serialized_start=1686
-  _globals['_TASK_CANCEL']._serialized_end=1734
-  _globals['_TASK_WASIP1']._serialized_start=1737
-  _globals['_TASK_WASIP1']._serialized_end=2370
-  _globals['_TASK_WASIP1_PARAMS']._serialized_start=1748
-  _globals['_TASK_WASIP1_PARAMS']._serialized_end=1934
-  _globals['_TASK_WASIP1_OUTPUT']._serialized_start=1937
-  _globals['_TASK_WASIP1_OUTPUT']._serialized_end=2066
-  _globals['_TASK_WASIP1_REQUEST']._serialized_start=2069
-  _globals['_TASK_WASIP1_REQUEST']._serialized_end=2224
-  _globals['_TASK_WASIP1_RESPONSE']._serialized_start=2227
-  _globals['_TASK_WASIP1_RESPONSE']._serialized_end=2370
-  _globals['_TASK_PYODIDE']._serialized_start=2373
-  _globals['_TASK_PYODIDE']._serialized_end=3059
-  _globals['_TASK_PYODIDE_PARAMS']._serialized_start=2385
-  _globals['_TASK_PYODIDE_PARAMS']._serialized_end=2595
-  _globals['_TASK_PYODIDE_OUTPUT']._serialized_start=2598
-  _globals['_TASK_PYODIDE_OUTPUT']._serialized_end=2753
-  _globals['_TASK_PYODIDE_REQUEST']._serialized_start=2756
-  _globals['_TASK_PYODIDE_REQUEST']._serialized_end=2912
-  _globals['_TASK_PYODIDE_RESPONSE']._serialized_start=2915
-  _globals['_TASK_PYODIDE_RESPONSE']._serialized_end=3059
-  _globals['_FILE']._serialized_start=3061
-  _globals['_FILE']._serialized_end=3127
-  _globals['_FILESYSTEM']._serialized_start=3130
-  _globals['_FILESYSTEM']._serialized_end=3480
-  _globals['_FILESYSTEM_LISTING']._serialized_start=3144
-  _globals['_FILESYSTEM_LISTING']._serialized_end=3198
+  _globals['_TASK_METADATA']._serialized_end=533
+  _globals['_TASK_QOS']._serialized_start=535
+  _globals['_TASK_QOS']._serialized_end=654
+  _globals['_TASK_TRACE']._serialized_start=656
+  _globals['_TASK_TRACE']._serialized_end=771
+  _globals['_TASK_TRACEEVENT']._serialized_start=774
+  _globals['_TASK_TRACEEVENT']._serialized_end=1714
+  _globals['_TASK_TRACEEVENT_EVENTTYPE']._serialized_start=905
+  _globals['_TASK_TRACEEVENT_EVENTTYPE']._serialized_end=1714
+  _globals['_TASK_CANCEL']._serialized_start=1716
+  _globals['_TASK_CANCEL']._serialized_end=1764
+  _globals['_TASK_RESUME']._serialized_start=1766
+  _globals['_TASK_RESUME']._serialized_end=1849
+  _globals['_TASK_RESUME_REQUEST']._serialized_start=1776
+  _globals['_TASK_RESUME_REQUEST']._serialized_end=1811
+  _globals['_TASK_RESUME_RESPONSE']._serialized_start=1813
+  _globals['_TASK_RESUME_RESPONSE']._serialized_end=1849
+  _globals['_TASK_DELIVER']._serialized_start=1852
+  _globals['_TASK_DELIVER']._serialized_end=2020
+  _globals['_TASK_DELIVER_REQUEST']._serialized_start=1864
+  _globals['_TASK_DELIVER_REQUEST']._serialized_end=2008
+  _globals['_TASK_DELIVER_RESPONSE']._serialized_start=307
+  _globals['_TASK_DELIVER_RESPONSE']._serialized_end=317
+  _globals['_TASK_LAYER']._serialized_start=2022
+  _globals['_TASK_LAYER']._serialized_end=2124
+  _globals['_TASK_WASIP1']._serialized_start=2127
+  _globals['_TASK_WASIP1']._serialized_end=2900
+  _globals['_TASK_WASIP1_PARAMS']._serialized_start=2138
+  _globals['_TASK_WASIP1_PARAMS']._serialized_end=2464
+  _globals['_TASK_WASIP1_OUTPUT']._serialized_start=2467
+  _globals['_TASK_WASIP1_OUTPUT']._serialized_end=2596
+  _globals['_TASK_WASIP1_REQUEST']._serialized_start=2599
+  _globals['_TASK_WASIP1_REQUEST']._serialized_end=2754
+  _globals['_TASK_WASIP1_RESPONSE']._serialized_start=2757
+  _globals['_TASK_WASIP1_RESPONSE']._serialized_end=2900
+  _globals['_TASK_PYODIDE']._serialized_start=2903
+  _globals['_TASK_PYODIDE']._serialized_end=3630
+  _globals['_TASK_PYODIDE_PARAMS']._serialized_start=2915
+  _globals['_TASK_PYODIDE_PARAMS']._serialized_end=3166
+  _globals['_TASK_PYODIDE_OUTPUT']._serialized_start=3169
+  _globals['_TASK_PYODIDE_OUTPUT']._serialized_end=3324
+  _globals['_TASK_PYODIDE_REQUEST']._serialized_start=3327
+  _globals['_TASK_PYODIDE_REQUEST']._serialized_end=3483
+  _globals['_TASK_PYODIDE_RESPONSE']._serialized_start=3486
+  _globals['_TASK_PYODIDE_RESPONSE']._serialized_end=3630
+  _globals['_FILE']._serialized_start=3632
+  _globals['_FILE']._serialized_end=3698
+  _globals['_FILESYSTEM']._serialized_start=3701
+  _globals['_FILESYSTEM']._serialized_end=5361
+  _globals['_FILESYSTEM_LISTING']._serialized_start=3715
+  _globals['_FILESYSTEM_LISTING']._serialized_end=3769
   _globals['_FILESYSTEM_LISTING_REQUEST']._serialized_start=294
   _globals['_FILESYSTEM_LISTING_REQUEST']._serialized_end=303
-  _globals['_FILESYSTEM_LISTING_RESPONSE']._serialized_start=3166
-  _globals['_FILESYSTEM_LISTING_RESPONSE']._serialized_end=3198
-  _globals['_FILESYSTEM_PROBE']._serialized_start=3200
-  _globals['_FILESYSTEM_PROBE']._serialized_end=3266
-  _globals['_FILESYSTEM_PROBE_REQUEST']._serialized_start=3209
-  _globals['_FILESYSTEM_PROBE_REQUEST']._serialized_end=3238
-  _globals['_FILESYSTEM_PROBE_RESPONSE']._serialized_start=3240
-  _globals['_FILESYSTEM_PROBE_RESPONSE']._serialized_end=3266
-  _globals['_FILESYSTEM_UPLOAD']._serialized_start=3268
-  _globals['_FILESYSTEM_UPLOAD']._serialized_end=3360
-  _globals['_FILESYSTEM_UPLOAD_REQUEST']._serialized_start=3278
-  _globals['_FILESYSTEM_UPLOAD_REQUEST']._serialized_end=3330
-  _globals['_FILESYSTEM_UPLOAD_RESPONSE']._serialized_start=3332
-  _globals['_FILESYSTEM_UPLOAD_RESPONSE']._serialized_end=3360
-  _globals['_FILESYSTEM_DOWNLOAD']._serialized_start=3362
-  _globals['_FILESYSTEM_DOWNLOAD']._serialized_end=3480
-  _globals['_FILESYSTEM_DOWNLOAD_REQUEST']._serialized_start=3209
-  _globals['_FILESYSTEM_DOWNLOAD_REQUEST']._serialized_end=3238
-  _globals['_FILESYSTEM_DOWNLOAD_RESPONSE']._serialized_start=3405
-  _globals['_FILESYSTEM_DOWNLOAD_RESPONSE']._serialized_end=3480
-  _globals['_EVENT']._serialized_start=3483
-  _globals['_EVENT']._serialized_end=3786
-  _globals['_EVENT_GENERICMESSAGE']._serialized_start=3492
-  _globals['_EVENT_GENERICMESSAGE']._serialized_end=3534
-  _globals['_EVENT_PROVIDERRESOURCES']._serialized_start=3536
-  _globals['_EVENT_PROVIDERRESOURCES']._serialized_end=3611
-  _globals['_EVENT_CLUSTERINFO']._serialized_start=3613
-  _globals['_EVENT_CLUSTERINFO']._serialized_end=3656
-  _globals['_EVENT_THROUGHPUT']._serialized_start=3658
-  _globals['_EVENT_THROUGHPUT']._serialized_end=3718
-  _globals['_EVENT_FILESYSTEMUPDATE']._serialized_start=3720
-  _globals['_EVENT_FILESYSTEMUPDATE']._serialized_end=3786
-  _globals['_PING']._serialized_start=3788
-  _globals['_PING']._serialized_end=3794
-  _globals['_TASKS']._serialized_start=3891
-  _globals['_TASKS']._serialized_end=4162
+  _globals['_FILESYSTEM_LISTING_RESPONSE']._serialized_start=3737
+  _globals['_FILESYSTEM_LISTING_RESPONSE']._serialized_end=3769
+  _globals['_FILESYSTEM_LIST']._serialized_start=3772
+  _globals['_FILESYSTEM_LIST']._serialized_end=4343
+  _globals['_FILESYSTEM_LIST_REQUEST']._serialized_start=3780
+  _globals['_FILESYSTEM_LIST_REQUEST']._serialized_end=3859
+  _globals['_FILESYSTEM_LIST_RESPONSE']._serialized_start=3861
+  _globals['_FILESYSTEM_LIST_RESPONSE']._serialized_end=3949
+  _globals['_FILESYSTEM_LIST_ENTRY']._serialized_start=3952
+  _globals['_FILESYSTEM_LIST_ENTRY']._serialized_end=4343
+  _globals['_FILESYSTEM_WASMINFO']._serialized_start=4346
+  _globals['_FILESYSTEM_WASMINFO']._serialized_end=4546
+  _globals['_FILESYSTEM_ARCHIVEINFO']._serialized_start=4548
+  _globals['_FILESYSTEM_ARCHIVEINFO']._serialized_end=4611
+  _globals['_FILESYSTEM_PROBE']._serialized_start=4613
+  _globals['_FILESYSTEM_PROBE']._serialized_end=4679
+  _globals['_FILESYSTEM_PROBE_REQUEST']._serialized_start=4622
+  _globals['_FILESYSTEM_PROBE_REQUEST']._serialized_end=4651
+  _globals['_FILESYSTEM_PROBE_RESPONSE']._serialized_start=4653
+  _globals['_FILESYSTEM_PROBE_RESPONSE']._serialized_end=4679
+  _globals['_FILESYSTEM_UPLOAD']._serialized_start=4681
+  _globals['_FILESYSTEM_UPLOAD']._serialized_end=4773
+  _globals['_FILESYSTEM_UPLOAD_REQUEST']._serialized_start=4691
+  _globals['_FILESYSTEM_UPLOAD_REQUEST']._serialized_end=4743
+  _globals['_FILESYSTEM_UPLOAD_RESPONSE']._serialized_start=4745
+  _globals['_FILESYSTEM_UPLOAD_RESPONSE']._serialized_end=4773
+  _globals['_FILESYSTEM_DOWNLOAD']._serialized_start=4775
+  _globals['_FILESYSTEM_DOWNLOAD']._serialized_end=4893
+  _globals['_FILESYSTEM_DOWNLOAD_REQUEST']._serialized_start=4622
+  _globals['_FILESYSTEM_DOWNLOAD_REQUEST']._serialized_end=4651
+  _globals['_FILESYSTEM_DOWNLOAD_RESPONSE']._serialized_start=4818
+  _globals['_FILESYSTEM_DOWNLOAD_RESPONSE']._serialized_end=4893
+  _globals['_FILESYSTEM_CHUNK']._serialized_start=4896
+  _globals['_FILESYSTEM_CHUNK']._serialized_end=5361
+  _globals['_FILESYSTEM_CHUNK_UPLOAD']._serialized_start=4906
+  _globals['_FILESYSTEM_CHUNK_UPLOAD']._serialized_end=5128
+  _globals['_FILESYSTEM_CHUNK_UPLOAD_REQUEST']._serialized_start=4917
+  _globals['_FILESYSTEM_CHUNK_UPLOAD_REQUEST']._serialized_end=5074
+  _globals['_FILESYSTEM_CHUNK_UPLOAD_RESPONSE']._serialized_start=5076
+  _globals['_FILESYSTEM_CHUNK_UPLOAD_RESPONSE']._serialized_end=5128
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD']._serialized_start=5131
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD']._serialized_end=5361
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD_REQUEST']._serialized_start=5143
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD_REQUEST']._serialized_end=5220
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD_RESPONSE']._serialized_start=5223
+  _globals['_FILESYSTEM_CHUNK_DOWNLOAD_RESPONSE']._serialized_end=5361
+  _globals['_EVENT']._serialized_start=5364
+  _globals['_EVENT']._serialized_end=5933
+  _globals['_EVENT_GENERICMESSAGE']._serialized_start=5373
+  _globals['_EVENT_GENERICMESSAGE']._serialized_end=5415
+  _globals['_EVENT_PROVIDERRESOURCES']._serialized_start=5417
+  _globals['_EVENT_PROVIDERRESOURCES']._serialized_end=5492
+  _globals['_EVENT_CLUSTERINFO']._serialized_start=5494
+  _globals['_EVENT_CLUSTERINFO']._serialized_end=5607
+  _globals['_EVENT_THROUGHPUT']._serialized_start=5609
+  _globals['_EVENT_THROUGHPUT']._serialized_end=5669
+  _globals['_EVENT_FILESYSTEMUPDATE']._serialized_start=5671
+  _globals['_EVENT_FILESYSTEMUPDATE']._serialized_end=5737
+  _globals['_EVENT_DRAIN']._serialized_start=5739
+  _globals['_EVENT_DRAIN']._serialized_end=5770
+  _globals['_EVENT_SUBSCRIBE']._serialized_start=5772
+  _globals['_EVENT_SUBSCRIBE']._serialized_end=5862
+  _globals['_EVENT_SUBSCRIBE_REQUEST']._serialized_start=5785
+  _globals['_EVENT_SUBSCRIBE_REQUEST']._serialized_end=5822
+  _globals['_EVENT_SUBSCRIBE_RESPONSE']._serialized_start=5824
+  _globals['_EVENT_SUBSCRIBE_RESPONSE']._serialized_end=5862
+  _globals['_EVENT_SESSION']._serialized_start=5864
+  _globals['_EVENT_SESSION']._serialized_end=5933
+  _globals['_PING']._serialized_start=5935
+  _globals['_PING']._serialized_end=5941
+  _globals['_TASKS']._serialized_start=6038
+  _globals['_TASKS']._serialized_end=6627
 # @@protoc_insertion_point(module_scope)
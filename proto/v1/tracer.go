@@ -3,6 +3,7 @@ package wasimoffv1
 // Additional tracing helper on task metadata.
 
 import (
+	"errors"
 	"time"
 
 	"google.golang.org/protobuf/proto"
@@ -10,17 +11,36 @@ import (
 
 // Add a traced event to task metadata.
 func (t *Task_Metadata) TraceEvent(ev Task_TraceEvent_EventType) {
+	t.TraceEventDetails(ev, "")
+}
+
+// Add a traced event with freetext details to task metadata.
+func (t *Task_Metadata) TraceEventDetails(ev Task_TraceEvent_EventType, details string) {
 	// only append if metadata contains a trace message
 	if t != nil && t.Trace != nil {
 		// prepare list with some capacity when empty
 		if t.Trace.Events == nil {
 			t.Trace.Events = make([]*Task_TraceEvent, 0, 20)
 		}
-		t.Trace.Events = append(t.Trace.Events, &Task_TraceEvent{
+		event := &Task_TraceEvent{
 			Unixnano: proto.Int64(time.Now().UnixNano()),
 			Event:    ev.Enum(),
-		})
+		}
+		if details != "" {
+			event.Details = proto.String(details)
+		}
+		t.Trace.Events = append(t.Trace.Events, event)
+	}
+}
+
+// Return the last traced event of a type or nil.
+func (t *Task_Trace) Last(ev Task_TraceEvent_EventType) *Task_TraceEvent {
+	for i := len(t.GetEvents()) - 1; i >= 0; i-- {
+		if t.Events[i].GetEvent() == ev {
+			return t.Events[i]
+		}
 	}
+	return nil
 }
 
 // Return the component of a logged event based on its enum range
@@ -51,35 +71,35 @@ const (
 // this tries to correct skew by "centering" child spans within their parents (like the Provider events
 // between Broker transmit and receive). It assumes equal latency front and back, which isn't quite correct
 // because it also includes en/decoding steps. Could probably be amended with actual latency measurements,
-// once known.
-func (t *Task_Trace) ClockSkewCorrection() {
+// once known. Returns an error if the events are in an unexpected order; the spans corrected
+// until then are kept.
+func (t *Task_Trace) ClockSkewCorrection() error {
 	events := t.Events
 
 	// need at least three events
 	if len(events) < 3 {
-		return
+		return nil
 	}
 
 	// center brokers within client
-	centerSpans(events,
+	if err := centerSpans(events,
 		Task_TraceEvent_ClientTransmitRequest,
 		Task_TraceEvent_ClientReceivedResponse,
 		Task_TraceEvent_Component_Client,
-	)
+	); err != nil {
+		return err
+	}
 
 	// center providers within brokers
-	centerSpans(events,
+	return centerSpans(events,
 		Task_TraceEvent_BrokerTransmitProviderTask,
 		Task_TraceEvent_BrokerReceivedProviderResult,
 		Task_TraceEvent_Component_Broker,
 	)
 
-	// store result
-	t.Events = events
-
 }
 
-func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType, parent Task_TraceEvent_Component) {
+func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType, parent Task_TraceEvent_Component) error {
 	// hold parent/child start/end instants
 	var (
 		parentStart int64 = 0
@@ -107,7 +127,7 @@ func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType
 		// parent end found
 		if *e.Event == end {
 			if parentStart == 0 {
-				panic("parent end without parent start")
+				return errors.New("parent end without parent start")
 			}
 			parentEnd = *e.Unixnano
 			if len(adjustEvents) > 0 {
@@ -116,7 +136,7 @@ func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType
 				parentDuration := parentEnd - parentStart
 				latency := (parentDuration - childDuration) / 2
 				if latency < 0 {
-					panic("negative latency, cannot center child span")
+					return errors.New("negative latency, cannot center child span")
 				}
 
 				var previous int64
@@ -148,7 +168,7 @@ func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType
 					parentStart = 0
 					continue
 				}
-				panic("found unexpected parent event between start and the next end")
+				return errors.New("found unexpected parent event between start and the next end")
 			}
 
 			adjustEvents = append(adjustEvents, e)
@@ -159,4 +179,46 @@ func centerSpans(events []*Task_TraceEvent, start, end Task_TraceEvent_EventType
 		}
 
 	}
+	return nil
+}
+
+// TracePhase is a named interval between the last occurrence of a start event and
+// the following end event, so that retried schedules yield the successful attempt.
+// Phases are nested within their parent phase, the outermost within the "task".
+type TracePhase struct {
+	Name       string
+	Parent     string
+	Start, End Task_TraceEvent_EventType
+}
+
+// The phases of a task in the Client, the Broker and the Provider.
+var TracePhases = []TracePhase{
+	{"client.request", "task", Task_TraceEvent_ClientTransmitRequest, Task_TraceEvent_BrokerReceivedClientRequest},
+	{"broker", "task", Task_TraceEvent_BrokerReceivedClientRequest, Task_TraceEvent_BrokerTransmitClientResponse},
+	{"broker.queue", "broker", Task_TraceEvent_BrokerQueueTask, Task_TraceEvent_BrokerScheduleTask},
+	{"broker.schedule", "broker", Task_TraceEvent_BrokerScheduleTask, Task_TraceEvent_BrokerTransmitProviderTask},
+	{"broker.transmit", "broker", Task_TraceEvent_BrokerTransmitProviderTask, Task_TraceEvent_ProviderTaskReceived},
+	{"provider", "broker", Task_TraceEvent_ProviderTaskReceived, Task_TraceEvent_ProviderTransmitResult},
+	{"provider.worker", "provider", Task_TraceEvent_ProviderGetWorker, Task_TraceEvent_ProviderPostToWorker},
+	{"provider.post", "provider", Task_TraceEvent_ProviderPostToWorker, Task_TraceEvent_ProviderWorkerPrepare},
+	{"worker.prepare", "provider", Task_TraceEvent_ProviderWorkerPrepare, Task_TraceEvent_ProviderWorkerExecute},
+	{"worker.execute", "provider", Task_TraceEvent_ProviderWorkerExecute, Task_TraceEvent_ProviderWorkerDone},
+	{"provider.result", "broker", Task_TraceEvent_ProviderTransmitResult, Task_TraceEvent_BrokerReceivedProviderResult},
+	{"client.response", "task", Task_TraceEvent_BrokerTransmitClientResponse, Task_TraceEvent_ClientReceivedResponse},
+}
+
+// Phase returns the instants of a phase in the trace, if both events were traced.
+func (t *Task_Trace) Phase(ph TracePhase) (start, end int64, ok bool) {
+	found := false
+	for _, event := range t.GetEvents() {
+		switch event.GetEvent() {
+		case ph.Start:
+			start, found, ok = event.GetUnixnano(), true, false
+		case ph.End:
+			if found && !ok {
+				end, ok = event.GetUnixnano(), true
+			}
+		}
+	}
+	return start, end, ok
 }
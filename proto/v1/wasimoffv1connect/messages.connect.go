@@ -39,6 +39,12 @@ const (
 	TasksRunPyodideProcedure = "/wasimoff.v1.Tasks/RunPyodide"
 	// TasksUploadProcedure is the fully-qualified name of the Tasks's Upload RPC.
 	TasksUploadProcedure = "/wasimoff.v1.Tasks/Upload"
+	// TasksUploadChunkProcedure is the fully-qualified name of the Tasks's UploadChunk RPC.
+	TasksUploadChunkProcedure = "/wasimoff.v1.Tasks/UploadChunk"
+	// TasksDownloadChunkProcedure is the fully-qualified name of the Tasks's DownloadChunk RPC.
+	TasksDownloadChunkProcedure = "/wasimoff.v1.Tasks/DownloadChunk"
+	// TasksListFilesProcedure is the fully-qualified name of the Tasks's ListFiles RPC.
+	TasksListFilesProcedure = "/wasimoff.v1.Tasks/ListFiles"
 )
 
 // TasksClient is a client for the wasimoff.v1.Tasks service.
@@ -46,6 +52,9 @@ type TasksClient interface {
 	RunWasip1(context.Context, *connect.Request[v1.Task_Wasip1_Request]) (*connect.Response[v1.Task_Wasip1_Response], error)
 	RunPyodide(context.Context, *connect.Request[v1.Task_Pyodide_Request]) (*connect.Response[v1.Task_Pyodide_Response], error)
 	Upload(context.Context, *connect.Request[v1.Filesystem_Upload_Request]) (*connect.Response[v1.Filesystem_Upload_Response], error)
+	UploadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Upload_Request]) (*connect.Response[v1.Filesystem_Chunk_Upload_Response], error)
+	DownloadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Download_Request]) (*connect.Response[v1.Filesystem_Chunk_Download_Response], error)
+	ListFiles(context.Context, *connect.Request[v1.Filesystem_List_Request]) (*connect.Response[v1.Filesystem_List_Response], error)
 }
 
 // NewTasksClient constructs a client for the wasimoff.v1.Tasks service. By default, it uses the
@@ -77,14 +86,35 @@ func NewTasksClient(httpClient connect.HTTPClient, baseURL string, opts ...conne
 			connect.WithSchema(tasksMethods.ByName("Upload")),
 			connect.WithClientOptions(opts...),
 		),
+		uploadChunk: connect.NewClient[v1.Filesystem_Chunk_Upload_Request, v1.Filesystem_Chunk_Upload_Response](
+			httpClient,
+			baseURL+TasksUploadChunkProcedure,
+			connect.WithSchema(tasksMethods.ByName("UploadChunk")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadChunk: connect.NewClient[v1.Filesystem_Chunk_Download_Request, v1.Filesystem_Chunk_Download_Response](
+			httpClient,
+			baseURL+TasksDownloadChunkProcedure,
+			connect.WithSchema(tasksMethods.ByName("DownloadChunk")),
+			connect.WithClientOptions(opts...),
+		),
+		listFiles: connect.NewClient[v1.Filesystem_List_Request, v1.Filesystem_List_Response](
+			httpClient,
+			baseURL+TasksListFilesProcedure,
+			connect.WithSchema(tasksMethods.ByName("ListFiles")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // tasksClient implements TasksClient.
 type tasksClient struct {
-	runWasip1  *connect.Client[v1.Task_Wasip1_Request, v1.Task_Wasip1_Response]
-	runPyodide *connect.Client[v1.Task_Pyodide_Request, v1.Task_Pyodide_Response]
-	upload     *connect.Client[v1.Filesystem_Upload_Request, v1.Filesystem_Upload_Response]
+	runWasip1     *connect.Client[v1.Task_Wasip1_Request, v1.Task_Wasip1_Response]
+	runPyodide    *connect.Client[v1.Task_Pyodide_Request, v1.Task_Pyodide_Response]
+	upload        *connect.Client[v1.Filesystem_Upload_Request, v1.Filesystem_Upload_Response]
+	uploadChunk   *connect.Client[v1.Filesystem_Chunk_Upload_Request, v1.Filesystem_Chunk_Upload_Response]
+	downloadChunk *connect.Client[v1.Filesystem_Chunk_Download_Request, v1.Filesystem_Chunk_Download_Response]
+	listFiles     *connect.Client[v1.Filesystem_List_Request, v1.Filesystem_List_Response]
 }
 
 // RunWasip1 calls wasimoff.v1.Tasks.RunWasip1.
@@ -102,11 +132,29 @@ func (c *tasksClient) Upload(ctx context.Context, req *connect.Request[v1.Filesy
 	return c.upload.CallUnary(ctx, req)
 }
 
+// UploadChunk calls wasimoff.v1.Tasks.UploadChunk.
+func (c *tasksClient) UploadChunk(ctx context.Context, req *connect.Request[v1.Filesystem_Chunk_Upload_Request]) (*connect.Response[v1.Filesystem_Chunk_Upload_Response], error) {
+	return c.uploadChunk.CallUnary(ctx, req)
+}
+
+// DownloadChunk calls wasimoff.v1.Tasks.DownloadChunk.
+func (c *tasksClient) DownloadChunk(ctx context.Context, req *connect.Request[v1.Filesystem_Chunk_Download_Request]) (*connect.Response[v1.Filesystem_Chunk_Download_Response], error) {
+	return c.downloadChunk.CallUnary(ctx, req)
+}
+
+// ListFiles calls wasimoff.v1.Tasks.ListFiles.
+func (c *tasksClient) ListFiles(ctx context.Context, req *connect.Request[v1.Filesystem_List_Request]) (*connect.Response[v1.Filesystem_List_Response], error) {
+	return c.listFiles.CallUnary(ctx, req)
+}
+
 // TasksHandler is an implementation of the wasimoff.v1.Tasks service.
 type TasksHandler interface {
 	RunWasip1(context.Context, *connect.Request[v1.Task_Wasip1_Request]) (*connect.Response[v1.Task_Wasip1_Response], error)
 	RunPyodide(context.Context, *connect.Request[v1.Task_Pyodide_Request]) (*connect.Response[v1.Task_Pyodide_Response], error)
 	Upload(context.Context, *connect.Request[v1.Filesystem_Upload_Request]) (*connect.Response[v1.Filesystem_Upload_Response], error)
+	UploadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Upload_Request]) (*connect.Response[v1.Filesystem_Chunk_Upload_Response], error)
+	DownloadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Download_Request]) (*connect.Response[v1.Filesystem_Chunk_Download_Response], error)
+	ListFiles(context.Context, *connect.Request[v1.Filesystem_List_Request]) (*connect.Response[v1.Filesystem_List_Response], error)
 }
 
 // NewTasksHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -134,6 +182,24 @@ func NewTasksHandler(svc TasksHandler, opts ...connect.HandlerOption) (string, h
 		connect.WithSchema(tasksMethods.ByName("Upload")),
 		connect.WithHandlerOptions(opts...),
 	)
+	tasksUploadChunkHandler := connect.NewUnaryHandler(
+		TasksUploadChunkProcedure,
+		svc.UploadChunk,
+		connect.WithSchema(tasksMethods.ByName("UploadChunk")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tasksDownloadChunkHandler := connect.NewUnaryHandler(
+		TasksDownloadChunkProcedure,
+		svc.DownloadChunk,
+		connect.WithSchema(tasksMethods.ByName("DownloadChunk")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tasksListFilesHandler := connect.NewUnaryHandler(
+		TasksListFilesProcedure,
+		svc.ListFiles,
+		connect.WithSchema(tasksMethods.ByName("ListFiles")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/wasimoff.v1.Tasks/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TasksRunWasip1Procedure:
@@ -142,6 +208,12 @@ func NewTasksHandler(svc TasksHandler, opts ...connect.HandlerOption) (string, h
 			tasksRunPyodideHandler.ServeHTTP(w, r)
 		case TasksUploadProcedure:
 			tasksUploadHandler.ServeHTTP(w, r)
+		case TasksUploadChunkProcedure:
+			tasksUploadChunkHandler.ServeHTTP(w, r)
+		case TasksDownloadChunkProcedure:
+			tasksDownloadChunkHandler.ServeHTTP(w, r)
+		case TasksListFilesProcedure:
+			tasksListFilesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -162,3 +234,15 @@ func (UnimplementedTasksHandler) RunPyodide(context.Context, *connect.Request[v1
 func (UnimplementedTasksHandler) Upload(context.Context, *connect.Request[v1.Filesystem_Upload_Request]) (*connect.Response[v1.Filesystem_Upload_Response], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wasimoff.v1.Tasks.Upload is not implemented"))
 }
+
+func (UnimplementedTasksHandler) UploadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Upload_Request]) (*connect.Response[v1.Filesystem_Chunk_Upload_Response], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wasimoff.v1.Tasks.UploadChunk is not implemented"))
+}
+
+func (UnimplementedTasksHandler) DownloadChunk(context.Context, *connect.Request[v1.Filesystem_Chunk_Download_Request]) (*connect.Response[v1.Filesystem_Chunk_Download_Response], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wasimoff.v1.Tasks.DownloadChunk is not implemented"))
+}
+
+func (UnimplementedTasksHandler) ListFiles(context.Context, *connect.Request[v1.Filesystem_List_Request]) (*connect.Response[v1.Filesystem_List_Response], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wasimoff.v1.Tasks.ListFiles is not implemented"))
+}
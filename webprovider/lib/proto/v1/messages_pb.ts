@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file proto/v1/messages.proto.
  */
 export const file_proto_v1_messages: GenFile = /*@__PURE__*/
-  fileDesc("Chdwcm90by92MS9tZXNzYWdlcy5wcm90bxILd2FzaW1vZmYudjEixQEKCEVudmVsb3BlEhAKCHNlcXVlbmNlGAEgASgEEi8KBHR5cGUYAiABKA4yIS53YXNpbW9mZi52MS5FbnZlbG9wZS5NZXNzYWdlVHlwZRINCgVlcnJvchgDIAEoCRIlCgdwYXlsb2FkGAQgASgLMhQuZ29vZ2xlLnByb3RvYnVmLkFueSJACgtNZXNzYWdlVHlwZRILCgdVTktOT1dOEAASCwoHUmVxdWVzdBABEgwKCFJlc3BvbnNlEAISCQoFRXZlbnQQAyKXEgoEVGFzaxp2CghNZXRhZGF0YRIKCgJpZBgBIAEoCRIRCglyZXF1ZXN0ZXIYAiABKAkSEAoIcHJvdmlkZXIYAyABKAkSEQoJcmVmZXJlbmNlGAQgASgJEiYKBXRyYWNlGAUgASgLMhcud2FzaW1vZmYudjEuVGFzay5UcmFjZRpYCgNRb1MSEAoIcHJpb3JpdHkYASABKAgSLAoIZGVhZGxpbmUYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhEKCWltbWVkaWF0ZRgDIAEoCBpYCgVUcmFjZRIPCgdjcmVhdGVkGAEgASgDEhAKCGR1cmF0aW9uGAIgASgEEiwKBmV2ZW50cxgDIAMoCzIcLndhc2ltb2ZmLnYxLlRhc2suVHJhY2VFdmVudBqSBwoKVHJhY2VFdmVudBIQCgh1bml4bmFubxgBIAEoAxI1CgVldmVudBgCIAEoDjImLndhc2ltb2ZmLnYxLlRhc2suVHJhY2VFdmVudC5FdmVudFR5cGUSDwoHZGV0YWlscxgDIAEoCSKpBgoJRXZlbnRUeXBlEgsKB1VOS05PV04QABIPCgtDbGllbnRFcnJvchAKEhkKFUNsaWVudFRyYW5zbWl0UmVxdWVzdBALEhoKFkNsaWVudFJlY2VpdmVkUmVzcG9uc2UQDBIPCgtCcm9rZXJFcnJvchAUEh8KG0Jyb2tlclJlY2VpdmVkQ2xpZW50UmVxdWVzdBAVEhMKD0Jyb2tlclF1ZXVlVGFzaxAWEhYKEkJyb2tlclNjaGVkdWxlVGFzaxAXEh4KGkJyb2tlclRyYW5zbWl0UHJvdmlkZXJUYXNrEBgSIAocQnJva2VyUmVjZWl2ZWRQcm92aWRlclJlc3VsdBAZEiAKHEJyb2tlclRyYW5zbWl0Q2xpZW50UmVzcG9uc2UQGhIRCg1Qcm92aWRlckVycm9yEB4SGAoUUHJvdmlkZXJUYXNrUmVjZWl2ZWQQHxIVChFQcm92aWRlckdldFdvcmtlchAgEhgKFFByb3ZpZGVyUG9zdFRvV29ya2VyECESGQoVUHJvdmlkZXJXb3JrZXJQcmVwYXJlECISGQoVUHJvdmlkZXJXb3JrZXJFeGVjdXRlECMSFgoSUHJvdmlkZXJXb3JrZXJEb25lECQSGgoWUHJvdmlkZXJUcmFuc21pdFJlc3VsdBAlEhkKFUFydERlY29TY2hlZHVsZXJFbnRlchAmEhkKFUFydERlY29TY2hlZHVsZXJMZWF2ZRAnEh0KGUFydERlY29TY2hlZHVsZXJTY2hlZHVsZWQQKBIfChtBcnREZWNvU2NoZWR1bGVyUmVzdWx0RW50ZXIQKRIfChtBcnREZWNvU2NoZWR1bGVyUmVzdWx0TGVhdmUQKhIdChlBcnREZWNvV2FzaW1vZmZTZXJpYWxpemVkECsSHwobQXJ0RGVjb1dhc2ltb2ZmRGVzZXJpYWxpemVkECwSIwofQXJ0RGVjb1NjaGVkdWxlclByb3ZpZGVyQ29ubmVjdBAtEiMKH0FydERlY29TY2hlZHVsZXJQcm92aWRlck9mZmxvYWQQLhIbChdBcnREZWNvU2NoZWR1bGVyUmVxdWV1ZRAvGiQKBkNhbmNlbBIKCgJpZBgBIAEoCRIOCgZyZWFzb24YAiABKAkaggQKBldhc2lwMRqMAQoGUGFyYW1zEiEKBmJpbmFyeRgBIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUSDAoEYXJncxgCIAMoCRIMCgRlbnZzGAMgAygJEg0KBXN0ZGluGAQgASgMEiEKBnJvb3RmcxgFIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUSEQoJYXJ0aWZhY3RzGAYgAygJGl4KBk91dHB1dBIOCgZzdGF0dXMYASABKAUSDgoGc3Rkb3V0GAIgASgMEg4KBnN0ZGVychgDIAEoDBIkCglhcnRpZmFjdHMYBCABKAsyES53YXNpbW9mZi52MS5GaWxlGogBCgdSZXF1ZXN0EigKBGluZm8YASABKAsyGi53YXNpbW9mZi52MS5UYXNrLk1ldGFkYXRhEiIKA3FvcxgCIAEoCzIVLndhc2ltb2ZmLnYxLlRhc2suUW9TEi8KBnBhcmFtcxgDIAEoCzIfLndhc2ltb2ZmLnYxLlRhc2suV2FzaXAxLlBhcmFtcxp+CghSZXNwb25zZRIoCgRpbmZvGAEgASgLMhoud2FzaW1vZmYudjEuVGFzay5NZXRhZGF0YRIPCgVlcnJvchgCIAEoCUgAEi0KAm9rGAMgASgLMh8ud2FzaW1vZmYudjEuVGFzay5XYXNpcDEuT3V0cHV0SABCCAoGcmVzdWx0GqIECgdQeW9kaWRlGpgBCgZQYXJhbXMSEAoIcGFja2FnZXMYASADKAkSEAoGc2NyaXB0GAIgASgJSAASEAoGcGlja2xlGAMgASgMSAASDAoEZW52cxgEIAMoCRINCgVzdGRpbhgFIAEoDBIhCgZyb290ZnMYBiABKAsyES53YXNpbW9mZi52MS5GaWxlEhEKCWFydGlmYWN0cxgHIAMoCUIFCgNydW4abwoGT3V0cHV0Eg4KBnBpY2tsZRgBIAEoDBIOCgZzdGRvdXQYAiABKAwSDgoGc3RkZXJyGAMgASgMEg8KB3ZlcnNpb24YBCABKAkSJAoJYXJ0aWZhY3RzGAUgASgLMhEud2FzaW1vZmYudjEuRmlsZRqJAQoHUmVxdWVzdBIoCgRpbmZvGAEgASgLMhoud2FzaW1vZmYudjEuVGFzay5NZXRhZGF0YRIiCgNxb3MYAiABKAsyFS53YXNpbW9mZi52MS5UYXNrLlFvUxIwCgZwYXJhbXMYAyABKAsyIC53YXNpbW9mZi52MS5UYXNrLlB5b2RpZGUuUGFyYW1zGn8KCFJlc3BvbnNlEigKBGluZm8YASABKAsyGi53YXNpbW9mZi52MS5UYXNrLk1ldGFkYXRhEg8KBWVycm9yGAIgASgJSAASLgoCb2sYAyABKAsyIC53YXNpbW9mZi52MS5UYXNrLlB5b2RpZGUuT3V0cHV0SABCCAoGcmVzdWx0IjAKBEZpbGUSCwoDcmVmGAEgASgJEg0KBW1lZGlhGAIgASgJEgwKBGJsb2IYAyABKAwiqwIKCkZpbGVzeXN0ZW0aLwoHTGlzdGluZxoJCgdSZXF1ZXN0GhkKCFJlc3BvbnNlEg0KBWZpbGVzGAEgAygJGjgKBVByb2JlGhcKB1JlcXVlc3QSDAoEZmlsZRgBIAEoCRoWCghSZXNwb25zZRIKCgJvaxgBIAEoCBpPCgZVcGxvYWQaLAoHUmVxdWVzdBIhCgZ1cGxvYWQYASABKAsyES53YXNpbW9mZi52MS5GaWxlGhcKCFJlc3BvbnNlEgsKA3JlZhgBIAEoCRphCghEb3dubG9hZBoXCgdSZXF1ZXN0EgwKBGZpbGUYASABKAkaPAoIUmVzcG9uc2USIwoIZG93bmxvYWQYASABKAsyES53YXNpbW9mZi52MS5GaWxlEgsKA2VychgCIAEoCSLnAQoFRXZlbnQaIQoOR2VuZXJpY01lc3NhZ2USDwoHbWVzc2FnZRgBIAEoCRo3ChFQcm92aWRlclJlc291cmNlcxITCgtjb25jdXJyZW5jeRgBIAEoDRINCgV0YXNrcxgCIAEoDRogCgtDbHVzdGVySW5mbxIRCglwcm92aWRlcnMYASABKA0aLAoKVGhyb3VnaHB1dBIPCgdvdmVyYWxsGAEgASgCEg0KBXlvdXJzGAIgASgCGjIKEEZpbGVTeXN0ZW1VcGRhdGUSDQoFYWRkZWQYASADKAkSDwoHcmVtb3ZlZBgCIAMoCSIGCgRQaW5nKlwKC1N1YnByb3RvY29sEgsKB1VOS05PV04QABIhCh13YXNpbW9mZl9wcm92aWRlcl92MV9wcm90b2J1ZhABEh0KGXdhc2ltb2ZmX3Byb3ZpZGVyX3YxX2pzb24QAjKPAgoFVGFza3MSUgoJUnVuV2FzaXAxEiAud2FzaW1vZmYudjEuVGFzay5XYXNpcDEuUmVxdWVzdBohLndhc2ltb2ZmLnYxLlRhc2suV2FzaXAxLlJlc3BvbnNlIgASVQoKUnVuUHlvZGlkZRIhLndhc2ltb2ZmLnYxLlRhc2suUHlvZGlkZS5SZXF1ZXN0GiIud2FzaW1vZmYudjEuVGFzay5QeW9kaWRlLlJlc3BvbnNlIgASWwoGVXBsb2FkEiYud2FzaW1vZmYudjEuRmlsZXN5c3RlbS5VcGxvYWQuUmVxdWVzdBonLndhc2ltb2ZmLnYxLkZpbGVzeXN0ZW0uVXBsb2FkLlJlc3BvbnNlIgBCH1odd2FzaS50ZWFtL3Byb3RvL3YxO3dhc2ltb2ZmdjFiCGVkaXRpb25zcOgH", [file_google_protobuf_any, file_google_protobuf_timestamp]);
+  fileDesc("Chdwcm90by92MS9tZXNzYWdlcy5wcm90bxILd2FzaW1vZmYudjEixQEKCEVudmVsb3BlEhAKCHNlcXVlbmNlGAEgASgEEi8KBHR5cGUYAiABKA4yIS53YXNpbW9mZi52MS5FbnZlbG9wZS5NZXNzYWdlVHlwZRINCgVlcnJvchgDIAEoCRIlCgdwYXlsb2FkGAQgASgLMhQuZ29vZ2xlLnByb3RvYnVmLkFueSJACgtNZXNzYWdlVHlwZRILCgdVTktOT1dOEAASCwoHUmVxdWVzdBABEgwKCFJlc3BvbnNlEAISCQoFRXZlbnQQAyLXFQoEVGFzaxqJAQoITWV0YWRhdGESCgoCaWQYASABKAkSEQoJcmVxdWVzdGVyGAIgASgJEhAKCHByb3ZpZGVyGAMgASgJEhEKCXJlZmVyZW5jZRgEIAEoCRImCgV0cmFjZRgFIAEoCzIXLndhc2ltb2ZmLnYxLlRhc2suVHJhY2USEQoJbmFtZXNwYWNlGAYgASgJGlgKA1FvUxIQCghwcmlvcml0eRgBIAEoCBIsCghkZWFkbGluZRgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJaW1tZWRpYXRlGAMgASgIGlgKBVRyYWNlEg8KB2NyZWF0ZWQYASABKAMSEAoIZHVyYXRpb24YAiABKAQSLAoGZXZlbnRzGAMgAygLMhwud2FzaW1vZmYudjEuVGFzay5UcmFjZUV2ZW50GpIHCgpUcmFjZUV2ZW50EhAKCHVuaXhuYW5vGAEgASgDEjUKBWV2ZW50GAIgASgOMiYud2FzaW1vZmYudjEuVGFzay5UcmFjZUV2ZW50LkV2ZW50VHlwZRIPCgdkZXRhaWxzGAMgASgJIqkGCglFdmVudFR5cGUSCwoHVU5LTk9XThAAEg8KC0NsaWVudEVycm9yEAoSGQoVQ2xpZW50VHJhbnNtaXRSZXF1ZXN0EAsSGgoWQ2xpZW50UmVjZWl2ZWRSZXNwb25zZRAMEg8KC0Jyb2tlckVycm9yEBQSHwobQnJva2VyUmVjZWl2ZWRDbGllbnRSZXF1ZXN0EBUSEwoPQnJva2VyUXVldWVUYXNrEBYSFgoSQnJva2VyU2NoZWR1bGVUYXNrEBcSHgoaQnJva2VyVHJhbnNtaXRQcm92aWRlclRhc2sQGBIgChxCcm9rZXJSZWNlaXZlZFByb3ZpZGVyUmVzdWx0EBkSIAocQnJva2VyVHJhbnNtaXRDbGllbnRSZXNwb25zZRAaEhEKDVByb3ZpZGVyRXJyb3IQHhIYChRQcm92aWRlclRhc2tSZWNlaXZlZBAfEhUKEVByb3ZpZGVyR2V0V29ya2VyECASGAoUUHJvdmlkZXJQb3N0VG9Xb3JrZXIQIRIZChVQcm92aWRlcldvcmtlclByZXBhcmUQIhIZChVQcm92aWRlcldvcmtlckV4ZWN1dGUQIxIWChJQcm92aWRlcldvcmtlckRvbmUQJBIaChZQcm92aWRlclRyYW5zbWl0UmVzdWx0ECUSGQoVQXJ0RGVjb1NjaGVkdWxlckVudGVyECYSGQoVQXJ0RGVjb1NjaGVkdWxlckxlYXZlECcSHQoZQXJ0RGVjb1NjaGVkdWxlclNjaGVkdWxlZBAoEh8KG0FydERlY29TY2hlZHVsZXJSZXN1bHRFbnRlchApEh8KG0FydERlY29TY2hlZHVsZXJSZXN1bHRMZWF2ZRAqEh0KGUFydERlY29XYXNpbW9mZlNlcmlhbGl6ZWQQKxIfChtBcnREZWNvV2FzaW1vZmZEZXNlcmlhbGl6ZWQQLBIjCh9BcnREZWNvU2NoZWR1bGVyUHJvdmlkZXJDb25uZWN0EC0SIwofQXJ0RGVjb1NjaGVkdWxlclByb3ZpZGVyT2ZmbG9hZBAuEhsKF0FydERlY29TY2hlZHVsZXJSZXF1ZXVlEC8aJAoGQ2FuY2VsEgoKAmlkGAEgASgJEg4KBnJlYXNvbhgCIAEoCRpBCgZSZXN1bWUaGgoHUmVxdWVzdBIPCgdwZW5kaW5nGAEgAygJGhsKCFJlc3BvbnNlEg8KB3Vua25vd24YASADKAkalgEKB0RlbGl2ZXIafwoHUmVxdWVzdBIzCgZ3YXNpcDEYASABKAsyIS53YXNpbW9mZi52MS5UYXNrLldhc2lwMS5SZXNwb25zZUgAEjUKB3B5b2RpZGUYAiABKAsyIi53YXNpbW9mZi52MS5UYXNrLlB5b2RpZGUuUmVzcG9uc2VIAEIICgZyZXN1bHQaCgoIUmVzcG9uc2UaTAoFTGF5ZXISIgoHYXJjaGl2ZRgBIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUSDQoFbW91bnQYAiABKAkSEAoIcmVhZG9ubHkYAyABKAga6wQKBldhc2lwMRr1AQoGUGFyYW1zEiEKBmJpbmFyeRgBIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUSDAoEYXJncxgCIAMoCRIMCgRlbnZzGAMgAygJEg0KBXN0ZGluGAQgASgMEiEKBnJvb3RmcxgFIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUSEQoJYXJ0aWZhY3RzGAYgAygJEhcKD3N0b3JlX2FydGlmYWN0cxgHIAEoCBInCgZsYXllcnMYCCADKAsyFy53YXNpbW9mZi52MS5UYXNrLkxheWVyEiUKCnN0ZGluX2ZpbGUYCSABKAsyES53YXNpbW9mZi52MS5GaWxlGl4KBk91dHB1dBIOCgZzdGF0dXMYASABKAUSDgoGc3Rkb3V0GAIgASgMEg4KBnN0ZGVychgDIAEoDBIkCglhcnRpZmFjdHMYBCABKAsyES53YXNpbW9mZi52MS5GaWxlGogBCgdSZXF1ZXN0EigKBGluZm8YASABKAsyGi53YXNpbW9mZi52MS5UYXNrLk1ldGFkYXRhEiIKA3FvcxgCIAEoCzIVLndhc2ltb2ZmLnYxLlRhc2suUW9TEi8KBnBhcmFtcxgDIAEoCzIfLndhc2ltb2ZmLnYxLlRhc2suV2FzaXAxLlBhcmFtcxp+CghSZXNwb25zZRIoCgRpbmZvGAEgASgLMhoud2FzaW1vZmYudjEuVGFzay5NZXRhZGF0YRIPCgVlcnJvchgCIAEoCUgAEi0KAm9rGAMgASgLMh8ud2FzaW1vZmYudjEuVGFzay5XYXNpcDEuT3V0cHV0SABCCAoGcmVzdWx0GrsECgdQeW9kaWRlGrEBCgZQYXJhbXMSEAoIcGFja2FnZXMYASADKAkSEAoGc2NyaXB0GAIgASgJSAASEAoGcGlja2xlGAMgASgMSAASDAoEZW52cxgEIAMoCRINCgVzdGRpbhgFIAEoDBIhCgZyb290ZnMYBiABKAsyES53YXNpbW9mZi52MS5GaWxlEhEKCWFydGlmYWN0cxgHIAMoCRIXCg9zdG9yZV9hcnRpZmFjdHMYCCABKAhCBQoDcnVuGm8KBk91dHB1dBIOCgZwaWNrbGUYASABKAwSDgoGc3Rkb3V0GAIgASgMEg4KBnN0ZGVychgDIAEoDBIPCgd2ZXJzaW9uGAQgASgJEiQKCWFydGlmYWN0cxgFIAEoCzIRLndhc2ltb2ZmLnYxLkZpbGUaiQEKB1JlcXVlc3QSKAoEaW5mbxgBIAEoCzIaLndhc2ltb2ZmLnYxLlRhc2suTWV0YWRhdGESIgoDcW9zGAIgASgLMhUud2FzaW1vZmYudjEuVGFzay5Rb1MSMAoGcGFyYW1zGAMgASgLMiAud2FzaW1vZmYudjEuVGFzay5QeW9kaWRlLlBhcmFtcxp/CghSZXNwb25zZRIoCgRpbmZvGAEgASgLMhoud2FzaW1vZmYudjEuVGFzay5NZXRhZGF0YRIPCgVlcnJvchgCIAEoCUgAEi4KAm9rGAMgASgLMiAud2FzaW1vZmYudjEuVGFzay5QeW9kaWRlLk91dHB1dEgAQggKBnJlc3VsdCIwCgRGaWxlEgsKA3JlZhgBIAEoCRINCgVtZWRpYRgCIAEoCRIMCgRibG9iGAMgASgMIpEKCgpGaWxlc3lzdGVtGi8KB0xpc3RpbmcaCQoHUmVxdWVzdBoZCghSZXNwb25zZRINCgVmaWxlcxgBIAMoCRrRAwoETGlzdBo4CgdSZXF1ZXN0Eg4KBnByZWZpeBgBIAEoCRINCgVsaW1pdBgCIAEoDRIOCgZjdXJzb3IYAyABKAkaSwoIUmVzcG9uc2USMQoFZmlsZXMYASADKAsyIi53YXNpbW9mZi52MS5GaWxlc3lzdGVtLkxpc3QuRW50cnkSDAoEbmV4dBgCIAEoCRrBAgoFRW50cnkSDAoEbmFtZRgBIAEoCRILCgNyZWYYAiABKAkSDQoFbWVkaWEYAyABKAkSDAoEc2l6ZRgEIAEoBBIsCgh1cGxvYWRlZBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLAoIYWNjZXNzZWQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhAKCHVwbG9hZGVyGAcgASgJEi4KBHdhc20YCCABKAsyIC53YXNpbW9mZi52MS5GaWxlc3lzdGVtLldhc21JbmZvEjAKA3ppcBgJIAEoCzIjLndhc2ltb2ZmLnYxLkZpbGVzeXN0ZW0uQXJjaGl2ZUluZm8SMAoDdGFyGAogASgLMiMud2FzaW1vZmYudjEuRmlsZXN5c3RlbS5BcmNoaXZlSW5mbxqGAQoIV2FzbUluZm8SEQoJY29tcG9uZW50GAEgASgIEg8KB3ZlcnNpb24YAiABKA0SDAoEd2FzaRgDIAEoCRIPCgdpbXBvcnRzGAQgAygJEg8KB2V4cG9ydHMYBSADKAkSEgoKbWVtb3J5X21pbhgGIAEoBBISCgptZW1vcnlfbWF4GAcgASgEGi4KC0FyY2hpdmVJbmZvEg0KBWZpbGVzGAEgASgNEhAKCHVucGFja2VkGAIgASgEGjgKBVByb2JlGhcKB1JlcXVlc3QSDAoEZmlsZRgBIAEoCRoWCghSZXNwb25zZRIKCgJvaxgBIAEoCBpPCgZVcGxvYWQaLAoHUmVxdWVzdBIhCgZ1cGxvYWQYASABKAsyES53YXNpbW9mZi52MS5GaWxlGhcKCFJlc3BvbnNlEgsKA3JlZhgBIAEoCRphCghEb3dubG9hZBoXCgdSZXF1ZXN0EgwKBGZpbGUYASABKAkaPAoIUmVzcG9uc2USIwoIZG93bmxvYWQYASABKAsyES53YXNpbW9mZi52MS5GaWxlEgsKA2VychgCIAEoCRrWAgoFQ2h1bmsaogEKBlVwbG9hZBpvCgdSZXF1ZXN0EgsKA3JlZhgBIAEoCRIMCgRuYW1lGAIgASgJEg0KBW1lZGlhGAMgASgJEgwKBHNpemUYBCABKAQSDgoGb2Zmc2V0GAUgASgEEgwKBGRhdGEYBiABKAwSDgoGZGlnZXN0GAcgASgJGicKCFJlc3BvbnNlEg4KBm9mZnNldBgBIAEoBBILCgNyZWYYAiABKAkapwEKCERvd25sb2FkGjcKB1JlcXVlc3QSDAoEZmlsZRgBIAEoCRIOCgZvZmZzZXQYAiABKAQSDgoGbGVuZ3RoGAMgASgNGmIKCFJlc3BvbnNlEgsKA3JlZhgBIAEoCRINCgVtZWRpYRgCIAEoCRIMCgRzaXplGAMgASgEEg4KBm9mZnNldBgEIAEoBBIMCgRkYXRhGAUgASgMEg4KBmRpZ2VzdBgGIAEoCSKsAwoFRXZlbnQaIQoOR2VuZXJpY01lc3NhZ2USDwoHbWVzc2FnZRgBIAEoCRo3ChFQcm92aWRlclJlc291cmNlcxITCgtjb25jdXJyZW5jeRgBIAEoDRINCgV0YXNrcxgCIAEoDRpPCgtDbHVzdGVySW5mbxIRCglwcm92aWRlcnMYASABKA0SDwoHd29ya2VycxgCIAEoDRIMCgRidXN5GAMgASgNEg4KBnF1ZXVlZBgEIAEoDRosCgpUaHJvdWdocHV0Eg8KB292ZXJhbGwYASABKAISDQoFeW91cnMYAiABKAIaMgoQRmlsZVN5c3RlbVVwZGF0ZRINCgVhZGRlZBgBIAMoCRIPCgdyZW1vdmVkGAIgAygJGhcKBURyYWluEg4KBnJlYXNvbhgBIAEoCRpGCglTdWJzY3JpYmUaGwoHUmVxdWVzdBIQCghpbnRlcnZhbBgBIAEoDRocCghSZXNwb25zZRIQCghpbnRlcnZhbBgBIAEoDRozCgdTZXNzaW9uEgoKAmlkGAEgASgJEg0KBXRva2VuGAIgASgJEg0KBWdyYWNlGAMgASgNIgYKBFBpbmcqXAoLU3VicHJvdG9jb2wSCwoHVU5LTk9XThAAEiEKHXdhc2ltb2ZmX3Byb3ZpZGVyX3YxX3Byb3RvYnVmEAESHQoZd2FzaW1vZmZfcHJvdmlkZXJfdjFfanNvbhACMs0ECgVUYXNrcxJSCglSdW5XYXNpcDESIC53YXNpbW9mZi52MS5UYXNrLldhc2lwMS5SZXF1ZXN0GiEud2FzaW1vZmYudjEuVGFzay5XYXNpcDEuUmVzcG9uc2UiABJVCgpSdW5QeW9kaWRlEiEud2FzaW1vZmYudjEuVGFzay5QeW9kaWRlLlJlcXVlc3QaIi53YXNpbW9mZi52MS5UYXNrLlB5b2RpZGUuUmVzcG9uc2UiABJbCgZVcGxvYWQSJi53YXNpbW9mZi52MS5GaWxlc3lzdGVtLlVwbG9hZC5SZXF1ZXN0Gicud2FzaW1vZmYudjEuRmlsZXN5c3RlbS5VcGxvYWQuUmVzcG9uc2UiABJsCgtVcGxvYWRDaHVuaxIsLndhc2ltb2ZmLnYxLkZpbGVzeXN0ZW0uQ2h1bmsuVXBsb2FkLlJlcXVlc3QaLS53YXNpbW9mZi52MS5GaWxlc3lzdGVtLkNodW5rLlVwbG9hZC5SZXNwb25zZSIAEnIKDURvd25sb2FkQ2h1bmsSLi53YXNpbW9mZi52MS5GaWxlc3lzdGVtLkNodW5rLkRvd25sb2FkLlJlcXVlc3QaLy53YXNpbW9mZi52MS5GaWxlc3lzdGVtLkNodW5rLkRvd25sb2FkLlJlc3BvbnNlIgASWgoJTGlzdEZpbGVzEiQud2FzaW1vZmYudjEuRmlsZXN5c3RlbS5MaXN0LlJlcXVlc3QaJS53YXNpbW9mZi52MS5GaWxlc3lzdGVtLkxpc3QuUmVzcG9uc2UiAEIfWh13YXNpLnRlYW0vcHJvdG8vdjE7d2FzaW1vZmZ2MWIIZWRpdGlvbnNw6Ac", [file_google_protobuf_any, file_google_protobuf_timestamp]);
 
 /**
  * Envelope is a generic message wrapper with a sequence counter and message type.
@@ -212,6 +212,13 @@ export type Task_Metadata = Message<"wasimoff.v1.Task.Metadata"> & {
    * @generated from field: wasimoff.v1.Task.Trace trace = 5;
    */
   trace?: Task_Trace;
+
+  /**
+   * tenant namespace of the requester
+   *
+   * @generated from field: string namespace = 6;
+   */
+  namespace: string;
 };
 
 /**
@@ -254,6 +261,13 @@ export type Task_MetadataJson = {
    * @generated from field: wasimoff.v1.Task.Trace trace = 5;
    */
   trace?: Task_TraceJson;
+
+  /**
+   * tenant namespace of the requester
+   *
+   * @generated from field: string namespace = 6;
+   */
+  namespace?: string;
 };
 
 /**
@@ -700,6 +714,252 @@ export type Task_CancelJson = {
 export const Task_CancelSchema: GenMessage<Task_Cancel, {jsonType: Task_CancelJson}> = /*@__PURE__*/
   messageDesc(file_proto_v1_messages, 1, 4);
 
+/**
+ * Resume is sent by the Broker after a Provider reconnected with a session token.
+ * It lists all tasks that were running when the previous connection was lost and
+ * whose results are still expected. The Provider answers with those task IDs that
+ * it does not know about anymore, so they can be retried elsewhere.
+ *
+ * @generated from message wasimoff.v1.Task.Resume
+ */
+export type Task_Resume = Message<"wasimoff.v1.Task.Resume"> & {
+};
+
+/**
+ * Resume is sent by the Broker after a Provider reconnected with a session token.
+ * It lists all tasks that were running when the previous connection was lost and
+ * whose results are still expected. The Provider answers with those task IDs that
+ * it does not know about anymore, so they can be retried elsewhere.
+ *
+ * @generated from message wasimoff.v1.Task.Resume
+ */
+export type Task_ResumeJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Resume.
+ * Use `create(Task_ResumeSchema)` to create a new message.
+ */
+export const Task_ResumeSchema: GenMessage<Task_Resume, {jsonType: Task_ResumeJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 5);
+
+/**
+ * @generated from message wasimoff.v1.Task.Resume.Request
+ */
+export type Task_Resume_Request = Message<"wasimoff.v1.Task.Resume.Request"> & {
+  /**
+   * task IDs still awaiting a result
+   *
+   * @generated from field: repeated string pending = 1;
+   */
+  pending: string[];
+};
+
+/**
+ * @generated from message wasimoff.v1.Task.Resume.Request
+ */
+export type Task_Resume_RequestJson = {
+  /**
+   * task IDs still awaiting a result
+   *
+   * @generated from field: repeated string pending = 1;
+   */
+  pending?: string[];
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Resume.Request.
+ * Use `create(Task_Resume_RequestSchema)` to create a new message.
+ */
+export const Task_Resume_RequestSchema: GenMessage<Task_Resume_Request, {jsonType: Task_Resume_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 5, 0);
+
+/**
+ * @generated from message wasimoff.v1.Task.Resume.Response
+ */
+export type Task_Resume_Response = Message<"wasimoff.v1.Task.Resume.Response"> & {
+  /**
+   * task IDs which will never be delivered
+   *
+   * @generated from field: repeated string unknown = 1;
+   */
+  unknown: string[];
+};
+
+/**
+ * @generated from message wasimoff.v1.Task.Resume.Response
+ */
+export type Task_Resume_ResponseJson = {
+  /**
+   * task IDs which will never be delivered
+   *
+   * @generated from field: repeated string unknown = 1;
+   */
+  unknown?: string[];
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Resume.Response.
+ * Use `create(Task_Resume_ResponseSchema)` to create a new message.
+ */
+export const Task_Resume_ResponseSchema: GenMessage<Task_Resume_Response, {jsonType: Task_Resume_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 5, 1);
+
+/**
+ * Deliver is sent by a Provider on a resumed session to return the result of a
+ * task, whose original Request was received on a previous connection.
+ *
+ * @generated from message wasimoff.v1.Task.Deliver
+ */
+export type Task_Deliver = Message<"wasimoff.v1.Task.Deliver"> & {
+};
+
+/**
+ * Deliver is sent by a Provider on a resumed session to return the result of a
+ * task, whose original Request was received on a previous connection.
+ *
+ * @generated from message wasimoff.v1.Task.Deliver
+ */
+export type Task_DeliverJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Deliver.
+ * Use `create(Task_DeliverSchema)` to create a new message.
+ */
+export const Task_DeliverSchema: GenMessage<Task_Deliver, {jsonType: Task_DeliverJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 6);
+
+/**
+ * @generated from message wasimoff.v1.Task.Deliver.Request
+ */
+export type Task_Deliver_Request = Message<"wasimoff.v1.Task.Deliver.Request"> & {
+  /**
+   * @generated from oneof wasimoff.v1.Task.Deliver.Request.result
+   */
+  result: {
+    /**
+     * @generated from field: wasimoff.v1.Task.Wasip1.Response wasip1 = 1;
+     */
+    value: Task_Wasip1_Response;
+    case: "wasip1";
+  } | {
+    /**
+     * @generated from field: wasimoff.v1.Task.Pyodide.Response pyodide = 2;
+     */
+    value: Task_Pyodide_Response;
+    case: "pyodide";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * @generated from message wasimoff.v1.Task.Deliver.Request
+ */
+export type Task_Deliver_RequestJson = {
+  /**
+   * @generated from field: wasimoff.v1.Task.Wasip1.Response wasip1 = 1;
+   */
+  wasip1?: Task_Wasip1_ResponseJson;
+
+  /**
+   * @generated from field: wasimoff.v1.Task.Pyodide.Response pyodide = 2;
+   */
+  pyodide?: Task_Pyodide_ResponseJson;
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Deliver.Request.
+ * Use `create(Task_Deliver_RequestSchema)` to create a new message.
+ */
+export const Task_Deliver_RequestSchema: GenMessage<Task_Deliver_Request, {jsonType: Task_Deliver_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 6, 0);
+
+/**
+ * @generated from message wasimoff.v1.Task.Deliver.Response
+ */
+export type Task_Deliver_Response = Message<"wasimoff.v1.Task.Deliver.Response"> & {
+};
+
+/**
+ * @generated from message wasimoff.v1.Task.Deliver.Response
+ */
+export type Task_Deliver_ResponseJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Deliver.Response.
+ * Use `create(Task_Deliver_ResponseSchema)` to create a new message.
+ */
+export const Task_Deliver_ResponseSchema: GenMessage<Task_Deliver_Response, {jsonType: Task_Deliver_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 6, 1);
+
+/**
+ * Layer is an archive, which is unpacked at a mount path in the filesystem of a
+ * task. The rootfs is the base layer at "/" and further layers are merged on top
+ * in order, so later layers overwrite the files of earlier ones.
+ *
+ * @generated from message wasimoff.v1.Task.Layer
+ */
+export type Task_Layer = Message<"wasimoff.v1.Task.Layer"> & {
+  /**
+   * zip or tar archive
+   *
+   * @generated from field: wasimoff.v1.File archive = 1;
+   */
+  archive?: File;
+
+  /**
+   * absolute path to unpack at, "/" if empty
+   *
+   * @generated from field: string mount = 2;
+   */
+  mount: string;
+
+  /**
+   * files of this layer can't be modified by the task
+   *
+   * @generated from field: bool readonly = 3;
+   */
+  readonly: boolean;
+};
+
+/**
+ * Layer is an archive, which is unpacked at a mount path in the filesystem of a
+ * task. The rootfs is the base layer at "/" and further layers are merged on top
+ * in order, so later layers overwrite the files of earlier ones.
+ *
+ * @generated from message wasimoff.v1.Task.Layer
+ */
+export type Task_LayerJson = {
+  /**
+   * zip or tar archive
+   *
+   * @generated from field: wasimoff.v1.File archive = 1;
+   */
+  archive?: FileJson;
+
+  /**
+   * absolute path to unpack at, "/" if empty
+   *
+   * @generated from field: string mount = 2;
+   */
+  mount?: string;
+
+  /**
+   * files of this layer can't be modified by the task
+   *
+   * @generated from field: bool readonly = 3;
+   */
+  readonly?: boolean;
+};
+
+/**
+ * Describes the message wasimoff.v1.Task.Layer.
+ * Use `create(Task_LayerSchema)` to create a new message.
+ */
+export const Task_LayerSchema: GenMessage<Task_Layer, {jsonType: Task_LayerJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 1, 7);
+
 /**
  *  WebAssembly System Interface (WASI), preview1
  * ===============================================
@@ -723,7 +983,7 @@ export type Task_Wasip1Json = {
  * Use `create(Task_Wasip1Schema)` to create a new message.
  */
 export const Task_Wasip1Schema: GenMessage<Task_Wasip1, {jsonType: Task_Wasip1Json}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 5);
+  messageDesc(file_proto_v1_messages, 1, 8);
 
 /**
  * Parameters to instantiate a WebAssembly WASI preview 1 task.
@@ -760,6 +1020,27 @@ export type Task_Wasip1_Params = Message<"wasimoff.v1.Task.Wasip1.Params"> & {
    * @generated from field: repeated string artifacts = 6;
    */
   artifacts: string[];
+
+  /**
+   * insert artifacts in Broker storage and return their ref
+   *
+   * @generated from field: bool store_artifacts = 7;
+   */
+  storeArtifacts: boolean;
+
+  /**
+   * additional filesystem layers on top of the rootfs
+   *
+   * @generated from field: repeated wasimoff.v1.Task.Layer layers = 8;
+   */
+  layers: Task_Layer[];
+
+  /**
+   * large stdin in Broker storage, instead of the inline stdin
+   *
+   * @generated from field: wasimoff.v1.File stdin_file = 9;
+   */
+  stdinFile?: File;
 };
 
 /**
@@ -797,6 +1078,27 @@ export type Task_Wasip1_ParamsJson = {
    * @generated from field: repeated string artifacts = 6;
    */
   artifacts?: string[];
+
+  /**
+   * insert artifacts in Broker storage and return their ref
+   *
+   * @generated from field: bool store_artifacts = 7;
+   */
+  storeArtifacts?: boolean;
+
+  /**
+   * additional filesystem layers on top of the rootfs
+   *
+   * @generated from field: repeated wasimoff.v1.Task.Layer layers = 8;
+   */
+  layers?: Task_LayerJson[];
+
+  /**
+   * large stdin in Broker storage, instead of the inline stdin
+   *
+   * @generated from field: wasimoff.v1.File stdin_file = 9;
+   */
+  stdinFile?: FileJson;
 };
 
 /**
@@ -804,7 +1106,7 @@ export type Task_Wasip1_ParamsJson = {
  * Use `create(Task_Wasip1_ParamsSchema)` to create a new message.
  */
 export const Task_Wasip1_ParamsSchema: GenMessage<Task_Wasip1_Params, {jsonType: Task_Wasip1_ParamsJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 5, 0);
+  messageDesc(file_proto_v1_messages, 1, 8, 0);
 
 /**
  * The result of an execution from a Wasip1.Params message. It should only be
@@ -867,7 +1169,7 @@ export type Task_Wasip1_OutputJson = {
  * Use `create(Task_Wasip1_OutputSchema)` to create a new message.
  */
 export const Task_Wasip1_OutputSchema: GenMessage<Task_Wasip1_Output, {jsonType: Task_Wasip1_OutputJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 5, 1);
+  messageDesc(file_proto_v1_messages, 1, 8, 1);
 
 /**
  * Offload a Wasip1 task.
@@ -918,7 +1220,7 @@ export type Task_Wasip1_RequestJson = {
  * Use `create(Task_Wasip1_RequestSchema)` to create a new message.
  */
 export const Task_Wasip1_RequestSchema: GenMessage<Task_Wasip1_Request, {jsonType: Task_Wasip1_RequestJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 5, 2);
+  messageDesc(file_proto_v1_messages, 1, 8, 2);
 
 /**
  * Response for a single Wasip1 task, which can be an Error or OK.
@@ -976,7 +1278,7 @@ export type Task_Wasip1_ResponseJson = {
  * Use `create(Task_Wasip1_ResponseSchema)` to create a new message.
  */
 export const Task_Wasip1_ResponseSchema: GenMessage<Task_Wasip1_Response, {jsonType: Task_Wasip1_ResponseJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 5, 3);
+  messageDesc(file_proto_v1_messages, 1, 8, 3);
 
 /**
  *  Pyodide Python scripts
@@ -1001,7 +1303,7 @@ export type Task_PyodideJson = {
  * Use `create(Task_PyodideSchema)` to create a new message.
  */
 export const Task_PyodideSchema: GenMessage<Task_Pyodide, {jsonType: Task_PyodideJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 6);
+  messageDesc(file_proto_v1_messages, 1, 9);
 
 /**
  * Parameters to instantiate a Pyodide task.
@@ -1052,7 +1354,7 @@ export type Task_Pyodide_Params = Message<"wasimoff.v1.Task.Pyodide.Params"> & {
   stdin: Uint8Array;
 
   /**
-   * zip file to unpack
+   * zip or tar archive to unpack
    *
    * @generated from field: wasimoff.v1.File rootfs = 6;
    */
@@ -1064,6 +1366,13 @@ export type Task_Pyodide_Params = Message<"wasimoff.v1.Task.Pyodide.Params"> & {
    * @generated from field: repeated string artifacts = 7;
    */
   artifacts: string[];
+
+  /**
+   * insert artifacts in Broker storage and return their ref
+   *
+   * @generated from field: bool store_artifacts = 8;
+   */
+  storeArtifacts: boolean;
 };
 
 /**
@@ -1108,7 +1417,7 @@ export type Task_Pyodide_ParamsJson = {
   stdin?: string;
 
   /**
-   * zip file to unpack
+   * zip or tar archive to unpack
    *
    * @generated from field: wasimoff.v1.File rootfs = 6;
    */
@@ -1120,6 +1429,13 @@ export type Task_Pyodide_ParamsJson = {
    * @generated from field: repeated string artifacts = 7;
    */
   artifacts?: string[];
+
+  /**
+   * insert artifacts in Broker storage and return their ref
+   *
+   * @generated from field: bool store_artifacts = 8;
+   */
+  storeArtifacts?: boolean;
 };
 
 /**
@@ -1127,7 +1443,7 @@ export type Task_Pyodide_ParamsJson = {
  * Use `create(Task_Pyodide_ParamsSchema)` to create a new message.
  */
 export const Task_Pyodide_ParamsSchema: GenMessage<Task_Pyodide_Params, {jsonType: Task_Pyodide_ParamsJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 6, 0);
+  messageDesc(file_proto_v1_messages, 1, 9, 0);
 
 /**
  * The result of an execution from a Pyodide.Params message. It should only be
@@ -1200,7 +1516,7 @@ export type Task_Pyodide_OutputJson = {
  * Use `create(Task_Pyodide_OutputSchema)` to create a new message.
  */
 export const Task_Pyodide_OutputSchema: GenMessage<Task_Pyodide_Output, {jsonType: Task_Pyodide_OutputJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 6, 1);
+  messageDesc(file_proto_v1_messages, 1, 9, 1);
 
 /**
  * Offload a Pyodide task.
@@ -1251,7 +1567,7 @@ export type Task_Pyodide_RequestJson = {
  * Use `create(Task_Pyodide_RequestSchema)` to create a new message.
  */
 export const Task_Pyodide_RequestSchema: GenMessage<Task_Pyodide_Request, {jsonType: Task_Pyodide_RequestJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 6, 2);
+  messageDesc(file_proto_v1_messages, 1, 9, 2);
 
 /**
  * Response for a single Pyodide task, which can be an Error or OK.
@@ -1309,7 +1625,7 @@ export type Task_Pyodide_ResponseJson = {
  * Use `create(Task_Pyodide_ResponseSchema)` to create a new message.
  */
 export const Task_Pyodide_ResponseSchema: GenMessage<Task_Pyodide_Response, {jsonType: Task_Pyodide_ResponseJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 1, 6, 3);
+  messageDesc(file_proto_v1_messages, 1, 9, 3);
 
 /**
  * File is a file reference with optional mime-type. The ref could be a plain
@@ -1471,105 +1787,555 @@ export const Filesystem_Listing_ResponseSchema: GenMessage<Filesystem_Listing_Re
   messageDesc(file_proto_v1_messages, 3, 0, 1);
 
 /**
- * Probe checks if a certain file exists on Provider
+ * List returns the named files in storage, which are readable by the Client,
+ * ordered by their qualified names and split into pages.
  *
- * @generated from message wasimoff.v1.Filesystem.Probe
+ * @generated from message wasimoff.v1.Filesystem.List
  */
-export type Filesystem_Probe = Message<"wasimoff.v1.Filesystem.Probe"> & {
+export type Filesystem_List = Message<"wasimoff.v1.Filesystem.List"> & {
 };
 
 /**
- * Probe checks if a certain file exists on Provider
+ * List returns the named files in storage, which are readable by the Client,
+ * ordered by their qualified names and split into pages.
  *
- * @generated from message wasimoff.v1.Filesystem.Probe
+ * @generated from message wasimoff.v1.Filesystem.List
  */
-export type Filesystem_ProbeJson = {
+export type Filesystem_ListJson = {
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Probe.
- * Use `create(Filesystem_ProbeSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.List.
+ * Use `create(Filesystem_ListSchema)` to create a new message.
  */
-export const Filesystem_ProbeSchema: GenMessage<Filesystem_Probe, {jsonType: Filesystem_ProbeJson}> = /*@__PURE__*/
+export const Filesystem_ListSchema: GenMessage<Filesystem_List, {jsonType: Filesystem_ListJson}> = /*@__PURE__*/
   messageDesc(file_proto_v1_messages, 3, 1);
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Probe.Request
+ * @generated from message wasimoff.v1.Filesystem.List.Request
  */
-export type Filesystem_Probe_Request = Message<"wasimoff.v1.Filesystem.Probe.Request"> & {
+export type Filesystem_List_Request = Message<"wasimoff.v1.Filesystem.List.Request"> & {
   /**
-   * @generated from field: string file = 1;
+   * only names starting with this prefix, may be qualified
+   *
+   * @generated from field: string prefix = 1;
    */
-  file: string;
+  prefix: string;
+
+  /**
+   * maximum number of entries per page, uses a default if unset
+   *
+   * @generated from field: uint32 limit = 2;
+   */
+  limit: number;
+
+  /**
+   * continue after this name, from a previous response
+   *
+   * @generated from field: string cursor = 3;
+   */
+  cursor: string;
 };
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Probe.Request
+ * @generated from message wasimoff.v1.Filesystem.List.Request
  */
-export type Filesystem_Probe_RequestJson = {
+export type Filesystem_List_RequestJson = {
   /**
-   * @generated from field: string file = 1;
+   * only names starting with this prefix, may be qualified
+   *
+   * @generated from field: string prefix = 1;
    */
-  file?: string;
+  prefix?: string;
+
+  /**
+   * maximum number of entries per page, uses a default if unset
+   *
+   * @generated from field: uint32 limit = 2;
+   */
+  limit?: number;
+
+  /**
+   * continue after this name, from a previous response
+   *
+   * @generated from field: string cursor = 3;
+   */
+  cursor?: string;
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Probe.Request.
- * Use `create(Filesystem_Probe_RequestSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.List.Request.
+ * Use `create(Filesystem_List_RequestSchema)` to create a new message.
  */
-export const Filesystem_Probe_RequestSchema: GenMessage<Filesystem_Probe_Request, {jsonType: Filesystem_Probe_RequestJson}> = /*@__PURE__*/
+export const Filesystem_List_RequestSchema: GenMessage<Filesystem_List_Request, {jsonType: Filesystem_List_RequestJson}> = /*@__PURE__*/
   messageDesc(file_proto_v1_messages, 3, 1, 0);
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Probe.Response
+ * @generated from message wasimoff.v1.Filesystem.List.Response
  */
-export type Filesystem_Probe_Response = Message<"wasimoff.v1.Filesystem.Probe.Response"> & {
+export type Filesystem_List_Response = Message<"wasimoff.v1.Filesystem.List.Response"> & {
   /**
-   * @generated from field: bool ok = 1;
+   * @generated from field: repeated wasimoff.v1.Filesystem.List.Entry files = 1;
    */
-  ok: boolean;
-};
+  files: Filesystem_List_Entry[];
 
-/**
- * @generated from message wasimoff.v1.Filesystem.Probe.Response
- */
-export type Filesystem_Probe_ResponseJson = {
   /**
-   * @generated from field: bool ok = 1;
+   * cursor of the next page, empty on the last page
+   *
+   * @generated from field: string next = 2;
    */
-  ok?: boolean;
+  next: string;
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Probe.Response.
- * Use `create(Filesystem_Probe_ResponseSchema)` to create a new message.
+ * @generated from message wasimoff.v1.Filesystem.List.Response
  */
-export const Filesystem_Probe_ResponseSchema: GenMessage<Filesystem_Probe_Response, {jsonType: Filesystem_Probe_ResponseJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 1, 1);
+export type Filesystem_List_ResponseJson = {
+  /**
+   * @generated from field: repeated wasimoff.v1.Filesystem.List.Entry files = 1;
+   */
+  files?: Filesystem_List_EntryJson[];
 
-/**
- * Upload pushes a file to the other peer.
- *
- * @generated from message wasimoff.v1.Filesystem.Upload
- */
-export type Filesystem_Upload = Message<"wasimoff.v1.Filesystem.Upload"> & {
+  /**
+   * cursor of the next page, empty on the last page
+   *
+   * @generated from field: string next = 2;
+   */
+  next?: string;
 };
 
 /**
- * Upload pushes a file to the other peer.
- *
- * @generated from message wasimoff.v1.Filesystem.Upload
+ * Describes the message wasimoff.v1.Filesystem.List.Response.
+ * Use `create(Filesystem_List_ResponseSchema)` to create a new message.
  */
-export type Filesystem_UploadJson = {
-};
+export const Filesystem_List_ResponseSchema: GenMessage<Filesystem_List_Response, {jsonType: Filesystem_List_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 1, 1);
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Upload.
- * Use `create(Filesystem_UploadSchema)` to create a new message.
+ * @generated from message wasimoff.v1.Filesystem.List.Entry
  */
-export const Filesystem_UploadSchema: GenMessage<Filesystem_Upload, {jsonType: Filesystem_UploadJson}> = /*@__PURE__*/
+export type Filesystem_List_Entry = Message<"wasimoff.v1.Filesystem.List.Entry"> & {
+  /**
+   * name, qualified as `namespace/name` outside the default namespace
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * content address
+   *
+   * @generated from field: string ref = 2;
+   */
+  ref: string;
+
+  /**
+   * media type in MIME notation
+   *
+   * @generated from field: string media = 3;
+   */
+  media: string;
+
+  /**
+   * size in bytes
+   *
+   * @generated from field: uint64 size = 4;
+   */
+  size: bigint;
+
+  /**
+   * time of the first upload
+   *
+   * @generated from field: google.protobuf.Timestamp uploaded = 5;
+   */
+  uploaded?: Timestamp;
+
+  /**
+   * last access since the Broker started
+   *
+   * @generated from field: google.protobuf.Timestamp accessed = 6;
+   */
+  accessed?: Timestamp;
+
+  /**
+   * identity of the first uploader, if authenticated
+   *
+   * @generated from field: string uploader = 7;
+   */
+  uploader: string;
+
+  /**
+   * inspected WebAssembly binary
+   *
+   * @generated from field: wasimoff.v1.Filesystem.WasmInfo wasm = 8;
+   */
+  wasm?: Filesystem_WasmInfo;
+
+  /**
+   * inspected zip archive or wheel
+   *
+   * @generated from field: wasimoff.v1.Filesystem.ArchiveInfo zip = 9;
+   */
+  zip?: Filesystem_ArchiveInfo;
+
+  /**
+   * inspected tar archive, maybe compressed
+   *
+   * @generated from field: wasimoff.v1.Filesystem.ArchiveInfo tar = 10;
+   */
+  tar?: Filesystem_ArchiveInfo;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.List.Entry
+ */
+export type Filesystem_List_EntryJson = {
+  /**
+   * name, qualified as `namespace/name` outside the default namespace
+   *
+   * @generated from field: string name = 1;
+   */
+  name?: string;
+
+  /**
+   * content address
+   *
+   * @generated from field: string ref = 2;
+   */
+  ref?: string;
+
+  /**
+   * media type in MIME notation
+   *
+   * @generated from field: string media = 3;
+   */
+  media?: string;
+
+  /**
+   * size in bytes
+   *
+   * @generated from field: uint64 size = 4;
+   */
+  size?: string;
+
+  /**
+   * time of the first upload
+   *
+   * @generated from field: google.protobuf.Timestamp uploaded = 5;
+   */
+  uploaded?: TimestampJson;
+
+  /**
+   * last access since the Broker started
+   *
+   * @generated from field: google.protobuf.Timestamp accessed = 6;
+   */
+  accessed?: TimestampJson;
+
+  /**
+   * identity of the first uploader, if authenticated
+   *
+   * @generated from field: string uploader = 7;
+   */
+  uploader?: string;
+
+  /**
+   * inspected WebAssembly binary
+   *
+   * @generated from field: wasimoff.v1.Filesystem.WasmInfo wasm = 8;
+   */
+  wasm?: Filesystem_WasmInfoJson;
+
+  /**
+   * inspected zip archive or wheel
+   *
+   * @generated from field: wasimoff.v1.Filesystem.ArchiveInfo zip = 9;
+   */
+  zip?: Filesystem_ArchiveInfoJson;
+
+  /**
+   * inspected tar archive, maybe compressed
+   *
+   * @generated from field: wasimoff.v1.Filesystem.ArchiveInfo tar = 10;
+   */
+  tar?: Filesystem_ArchiveInfoJson;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.List.Entry.
+ * Use `create(Filesystem_List_EntrySchema)` to create a new message.
+ */
+export const Filesystem_List_EntrySchema: GenMessage<Filesystem_List_Entry, {jsonType: Filesystem_List_EntryJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 1, 2);
+
+/**
+ * WasmInfo is the metadata extracted from an uploaded WebAssembly binary.
+ *
+ * @generated from message wasimoff.v1.Filesystem.WasmInfo
+ */
+export type Filesystem_WasmInfo = Message<"wasimoff.v1.Filesystem.WasmInfo"> & {
+  /**
+   * a component instead of a core module
+   *
+   * @generated from field: bool component = 1;
+   */
+  component: boolean;
+
+  /**
+   * binary format version
+   *
+   * @generated from field: uint32 version = 2;
+   */
+  version: number;
+
+  /**
+   * "preview1" if it imports WASI functions
+   *
+   * @generated from field: string wasi = 3;
+   */
+  wasi: string;
+
+  /**
+   * imported functions as `module.name`
+   *
+   * @generated from field: repeated string imports = 4;
+   */
+  imports: string[];
+
+  /**
+   * exported functions
+   *
+   * @generated from field: repeated string exports = 5;
+   */
+  exports: string[];
+
+  /**
+   * limits of the first memory in 64 KiB pages
+   *
+   * @generated from field: uint64 memory_min = 6;
+   */
+  memoryMin: bigint;
+
+  /**
+   * unset if unbounded
+   *
+   * @generated from field: uint64 memory_max = 7;
+   */
+  memoryMax: bigint;
+};
+
+/**
+ * WasmInfo is the metadata extracted from an uploaded WebAssembly binary.
+ *
+ * @generated from message wasimoff.v1.Filesystem.WasmInfo
+ */
+export type Filesystem_WasmInfoJson = {
+  /**
+   * a component instead of a core module
+   *
+   * @generated from field: bool component = 1;
+   */
+  component?: boolean;
+
+  /**
+   * binary format version
+   *
+   * @generated from field: uint32 version = 2;
+   */
+  version?: number;
+
+  /**
+   * "preview1" if it imports WASI functions
+   *
+   * @generated from field: string wasi = 3;
+   */
+  wasi?: string;
+
+  /**
+   * imported functions as `module.name`
+   *
+   * @generated from field: repeated string imports = 4;
+   */
+  imports?: string[];
+
+  /**
+   * exported functions
+   *
+   * @generated from field: repeated string exports = 5;
+   */
+  exports?: string[];
+
+  /**
+   * limits of the first memory in 64 KiB pages
+   *
+   * @generated from field: uint64 memory_min = 6;
+   */
+  memoryMin?: string;
+
+  /**
+   * unset if unbounded
+   *
+   * @generated from field: uint64 memory_max = 7;
+   */
+  memoryMax?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.WasmInfo.
+ * Use `create(Filesystem_WasmInfoSchema)` to create a new message.
+ */
+export const Filesystem_WasmInfoSchema: GenMessage<Filesystem_WasmInfo, {jsonType: Filesystem_WasmInfoJson}> = /*@__PURE__*/
   messageDesc(file_proto_v1_messages, 3, 2);
 
+/**
+ * ArchiveInfo is the metadata extracted from an uploaded zip or tar archive.
+ *
+ * @generated from message wasimoff.v1.Filesystem.ArchiveInfo
+ */
+export type Filesystem_ArchiveInfo = Message<"wasimoff.v1.Filesystem.ArchiveInfo"> & {
+  /**
+   * number of entries
+   *
+   * @generated from field: uint32 files = 1;
+   */
+  files: number;
+
+  /**
+   * total uncompressed size in bytes
+   *
+   * @generated from field: uint64 unpacked = 2;
+   */
+  unpacked: bigint;
+};
+
+/**
+ * ArchiveInfo is the metadata extracted from an uploaded zip or tar archive.
+ *
+ * @generated from message wasimoff.v1.Filesystem.ArchiveInfo
+ */
+export type Filesystem_ArchiveInfoJson = {
+  /**
+   * number of entries
+   *
+   * @generated from field: uint32 files = 1;
+   */
+  files?: number;
+
+  /**
+   * total uncompressed size in bytes
+   *
+   * @generated from field: uint64 unpacked = 2;
+   */
+  unpacked?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.ArchiveInfo.
+ * Use `create(Filesystem_ArchiveInfoSchema)` to create a new message.
+ */
+export const Filesystem_ArchiveInfoSchema: GenMessage<Filesystem_ArchiveInfo, {jsonType: Filesystem_ArchiveInfoJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 3);
+
+/**
+ * Probe checks if a certain file exists on Provider
+ *
+ * @generated from message wasimoff.v1.Filesystem.Probe
+ */
+export type Filesystem_Probe = Message<"wasimoff.v1.Filesystem.Probe"> & {
+};
+
+/**
+ * Probe checks if a certain file exists on Provider
+ *
+ * @generated from message wasimoff.v1.Filesystem.Probe
+ */
+export type Filesystem_ProbeJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Probe.
+ * Use `create(Filesystem_ProbeSchema)` to create a new message.
+ */
+export const Filesystem_ProbeSchema: GenMessage<Filesystem_Probe, {jsonType: Filesystem_ProbeJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 4);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Probe.Request
+ */
+export type Filesystem_Probe_Request = Message<"wasimoff.v1.Filesystem.Probe.Request"> & {
+  /**
+   * @generated from field: string file = 1;
+   */
+  file: string;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Probe.Request
+ */
+export type Filesystem_Probe_RequestJson = {
+  /**
+   * @generated from field: string file = 1;
+   */
+  file?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Probe.Request.
+ * Use `create(Filesystem_Probe_RequestSchema)` to create a new message.
+ */
+export const Filesystem_Probe_RequestSchema: GenMessage<Filesystem_Probe_Request, {jsonType: Filesystem_Probe_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 4, 0);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Probe.Response
+ */
+export type Filesystem_Probe_Response = Message<"wasimoff.v1.Filesystem.Probe.Response"> & {
+  /**
+   * @generated from field: bool ok = 1;
+   */
+  ok: boolean;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Probe.Response
+ */
+export type Filesystem_Probe_ResponseJson = {
+  /**
+   * @generated from field: bool ok = 1;
+   */
+  ok?: boolean;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Probe.Response.
+ * Use `create(Filesystem_Probe_ResponseSchema)` to create a new message.
+ */
+export const Filesystem_Probe_ResponseSchema: GenMessage<Filesystem_Probe_Response, {jsonType: Filesystem_Probe_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 4, 1);
+
+/**
+ * Upload pushes a file to the other peer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Upload
+ */
+export type Filesystem_Upload = Message<"wasimoff.v1.Filesystem.Upload"> & {
+};
+
+/**
+ * Upload pushes a file to the other peer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Upload
+ */
+export type Filesystem_UploadJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Upload.
+ * Use `create(Filesystem_UploadSchema)` to create a new message.
+ */
+export const Filesystem_UploadSchema: GenMessage<Filesystem_Upload, {jsonType: Filesystem_UploadJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 5);
+
 /**
  * @generated from message wasimoff.v1.Filesystem.Upload.Request
  */
@@ -1595,121 +2361,516 @@ export type Filesystem_Upload_RequestJson = {
  * Use `create(Filesystem_Upload_RequestSchema)` to create a new message.
  */
 export const Filesystem_Upload_RequestSchema: GenMessage<Filesystem_Upload_Request, {jsonType: Filesystem_Upload_RequestJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 2, 0);
+  messageDesc(file_proto_v1_messages, 3, 5, 0);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Upload.Response
+ */
+export type Filesystem_Upload_Response = Message<"wasimoff.v1.Filesystem.Upload.Response"> & {
+  /**
+   * @generated from field: string ref = 1;
+   */
+  ref: string;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Upload.Response
+ */
+export type Filesystem_Upload_ResponseJson = {
+  /**
+   * @generated from field: string ref = 1;
+   */
+  ref?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Upload.Response.
+ * Use `create(Filesystem_Upload_ResponseSchema)` to create a new message.
+ */
+export const Filesystem_Upload_ResponseSchema: GenMessage<Filesystem_Upload_Response, {jsonType: Filesystem_Upload_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 5, 1);
+
+/**
+ * Download can request a file download from the other peer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Download
+ */
+export type Filesystem_Download = Message<"wasimoff.v1.Filesystem.Download"> & {
+};
+
+/**
+ * Download can request a file download from the other peer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Download
+ */
+export type Filesystem_DownloadJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Download.
+ * Use `create(Filesystem_DownloadSchema)` to create a new message.
+ */
+export const Filesystem_DownloadSchema: GenMessage<Filesystem_Download, {jsonType: Filesystem_DownloadJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 6);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Download.Request
+ */
+export type Filesystem_Download_Request = Message<"wasimoff.v1.Filesystem.Download.Request"> & {
+  /**
+   * @generated from field: string file = 1;
+   */
+  file: string;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Download.Request
+ */
+export type Filesystem_Download_RequestJson = {
+  /**
+   * @generated from field: string file = 1;
+   */
+  file?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Download.Request.
+ * Use `create(Filesystem_Download_RequestSchema)` to create a new message.
+ */
+export const Filesystem_Download_RequestSchema: GenMessage<Filesystem_Download_Request, {jsonType: Filesystem_Download_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 6, 0);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Download.Response
+ */
+export type Filesystem_Download_Response = Message<"wasimoff.v1.Filesystem.Download.Response"> & {
+  /**
+   * @generated from field: wasimoff.v1.File download = 1;
+   */
+  download?: File;
+
+  /**
+   * @generated from field: string err = 2;
+   */
+  err: string;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Download.Response
+ */
+export type Filesystem_Download_ResponseJson = {
+  /**
+   * @generated from field: wasimoff.v1.File download = 1;
+   */
+  download?: FileJson;
+
+  /**
+   * @generated from field: string err = 2;
+   */
+  err?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Download.Response.
+ * Use `create(Filesystem_Download_ResponseSchema)` to create a new message.
+ */
+export const Filesystem_Download_ResponseSchema: GenMessage<Filesystem_Download_Response, {jsonType: Filesystem_Download_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 6, 1);
+
+/**
+ * Chunk transfers large files in sequential pieces, so that no single message
+ * needs to hold an entire file. Every chunk carries its offset and a digest of
+ * its data, so transfers can be verified and resumed after an interruption.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Chunk
+ */
+export type Filesystem_Chunk = Message<"wasimoff.v1.Filesystem.Chunk"> & {
+};
+
+/**
+ * Chunk transfers large files in sequential pieces, so that no single message
+ * needs to hold an entire file. Every chunk carries its offset and a digest of
+ * its data, so transfers can be verified and resumed after an interruption.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Chunk
+ */
+export type Filesystem_ChunkJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Chunk.
+ * Use `create(Filesystem_ChunkSchema)` to create a new message.
+ */
+export const Filesystem_ChunkSchema: GenMessage<Filesystem_Chunk, {jsonType: Filesystem_ChunkJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7);
+
+/**
+ * Upload pushes the next chunk of a file to the other peer. Send a request
+ * without data to query the current offset when resuming a transfer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload
+ */
+export type Filesystem_Chunk_Upload = Message<"wasimoff.v1.Filesystem.Chunk.Upload"> & {
+};
+
+/**
+ * Upload pushes the next chunk of a file to the other peer. Send a request
+ * without data to query the current offset when resuming a transfer.
+ *
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload
+ */
+export type Filesystem_Chunk_UploadJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Upload.
+ * Use `create(Filesystem_Chunk_UploadSchema)` to create a new message.
+ */
+export const Filesystem_Chunk_UploadSchema: GenMessage<Filesystem_Chunk_Upload, {jsonType: Filesystem_Chunk_UploadJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 0);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload.Request
+ */
+export type Filesystem_Chunk_Upload_Request = Message<"wasimoff.v1.Filesystem.Chunk.Upload.Request"> & {
+  /**
+   * content address of the complete file, identifies the transfer
+   *
+   * @generated from field: string ref = 1;
+   */
+  ref: string;
+
+  /**
+   * optional friendly name for lookup
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * media type of the complete file
+   *
+   * @generated from field: string media = 3;
+   */
+  media: string;
+
+  /**
+   * total size of the complete file
+   *
+   * @generated from field: uint64 size = 4;
+   */
+  size: bigint;
+
+  /**
+   * offset of this chunk within the file
+   *
+   * @generated from field: uint64 offset = 5;
+   */
+  offset: bigint;
+
+  /**
+   * chunk contents
+   *
+   * @generated from field: bytes data = 6;
+   */
+  data: Uint8Array;
+
+  /**
+   * sha256 digest of data, encoded like a ref
+   *
+   * @generated from field: string digest = 7;
+   */
+  digest: string;
+};
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload.Request
+ */
+export type Filesystem_Chunk_Upload_RequestJson = {
+  /**
+   * content address of the complete file, identifies the transfer
+   *
+   * @generated from field: string ref = 1;
+   */
+  ref?: string;
+
+  /**
+   * optional friendly name for lookup
+   *
+   * @generated from field: string name = 2;
+   */
+  name?: string;
+
+  /**
+   * media type of the complete file
+   *
+   * @generated from field: string media = 3;
+   */
+  media?: string;
+
+  /**
+   * total size of the complete file
+   *
+   * @generated from field: uint64 size = 4;
+   */
+  size?: string;
+
+  /**
+   * offset of this chunk within the file
+   *
+   * @generated from field: uint64 offset = 5;
+   */
+  offset?: string;
+
+  /**
+   * chunk contents
+   *
+   * @generated from field: bytes data = 6;
+   */
+  data?: string;
+
+  /**
+   * sha256 digest of data, encoded like a ref
+   *
+   * @generated from field: string digest = 7;
+   */
+  digest?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Upload.Request.
+ * Use `create(Filesystem_Chunk_Upload_RequestSchema)` to create a new message.
+ */
+export const Filesystem_Chunk_Upload_RequestSchema: GenMessage<Filesystem_Chunk_Upload_Request, {jsonType: Filesystem_Chunk_Upload_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 0, 0);
+
+/**
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload.Response
+ */
+export type Filesystem_Chunk_Upload_Response = Message<"wasimoff.v1.Filesystem.Chunk.Upload.Response"> & {
+  /**
+   * contiguous bytes received so far, i.e. the next expected offset
+   *
+   * @generated from field: uint64 offset = 1;
+   */
+  offset: bigint;
 
-/**
- * @generated from message wasimoff.v1.Filesystem.Upload.Response
- */
-export type Filesystem_Upload_Response = Message<"wasimoff.v1.Filesystem.Upload.Response"> & {
   /**
-   * @generated from field: string ref = 1;
+   * content address, only set once the file is complete
+   *
+   * @generated from field: string ref = 2;
    */
   ref: string;
 };
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Upload.Response
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Upload.Response
  */
-export type Filesystem_Upload_ResponseJson = {
+export type Filesystem_Chunk_Upload_ResponseJson = {
   /**
-   * @generated from field: string ref = 1;
+   * contiguous bytes received so far, i.e. the next expected offset
+   *
+   * @generated from field: uint64 offset = 1;
+   */
+  offset?: string;
+
+  /**
+   * content address, only set once the file is complete
+   *
+   * @generated from field: string ref = 2;
    */
   ref?: string;
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Upload.Response.
- * Use `create(Filesystem_Upload_ResponseSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Upload.Response.
+ * Use `create(Filesystem_Chunk_Upload_ResponseSchema)` to create a new message.
  */
-export const Filesystem_Upload_ResponseSchema: GenMessage<Filesystem_Upload_Response, {jsonType: Filesystem_Upload_ResponseJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 2, 1);
+export const Filesystem_Chunk_Upload_ResponseSchema: GenMessage<Filesystem_Chunk_Upload_Response, {jsonType: Filesystem_Chunk_Upload_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 0, 1);
 
 /**
- * Download can request a file download from the other peer.
+ * Download requests a single chunk of a file from the other peer.
  *
- * @generated from message wasimoff.v1.Filesystem.Download
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download
  */
-export type Filesystem_Download = Message<"wasimoff.v1.Filesystem.Download"> & {
+export type Filesystem_Chunk_Download = Message<"wasimoff.v1.Filesystem.Chunk.Download"> & {
 };
 
 /**
- * Download can request a file download from the other peer.
+ * Download requests a single chunk of a file from the other peer.
  *
- * @generated from message wasimoff.v1.Filesystem.Download
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download
  */
-export type Filesystem_DownloadJson = {
+export type Filesystem_Chunk_DownloadJson = {
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Download.
- * Use `create(Filesystem_DownloadSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Download.
+ * Use `create(Filesystem_Chunk_DownloadSchema)` to create a new message.
  */
-export const Filesystem_DownloadSchema: GenMessage<Filesystem_Download, {jsonType: Filesystem_DownloadJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 3);
+export const Filesystem_Chunk_DownloadSchema: GenMessage<Filesystem_Chunk_Download, {jsonType: Filesystem_Chunk_DownloadJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 1);
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Download.Request
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download.Request
  */
-export type Filesystem_Download_Request = Message<"wasimoff.v1.Filesystem.Download.Request"> & {
+export type Filesystem_Chunk_Download_Request = Message<"wasimoff.v1.Filesystem.Chunk.Download.Request"> & {
   /**
+   * filename or content address
+   *
    * @generated from field: string file = 1;
    */
   file: string;
+
+  /**
+   * offset of the requested chunk
+   *
+   * @generated from field: uint64 offset = 2;
+   */
+  offset: bigint;
+
+  /**
+   * maximum length of the chunk, uses a default if unset
+   *
+   * @generated from field: uint32 length = 3;
+   */
+  length: number;
 };
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Download.Request
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download.Request
  */
-export type Filesystem_Download_RequestJson = {
+export type Filesystem_Chunk_Download_RequestJson = {
   /**
+   * filename or content address
+   *
    * @generated from field: string file = 1;
    */
   file?: string;
+
+  /**
+   * offset of the requested chunk
+   *
+   * @generated from field: uint64 offset = 2;
+   */
+  offset?: string;
+
+  /**
+   * maximum length of the chunk, uses a default if unset
+   *
+   * @generated from field: uint32 length = 3;
+   */
+  length?: number;
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Download.Request.
- * Use `create(Filesystem_Download_RequestSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Download.Request.
+ * Use `create(Filesystem_Chunk_Download_RequestSchema)` to create a new message.
  */
-export const Filesystem_Download_RequestSchema: GenMessage<Filesystem_Download_Request, {jsonType: Filesystem_Download_RequestJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 3, 0);
+export const Filesystem_Chunk_Download_RequestSchema: GenMessage<Filesystem_Chunk_Download_Request, {jsonType: Filesystem_Chunk_Download_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 1, 0);
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Download.Response
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download.Response
  */
-export type Filesystem_Download_Response = Message<"wasimoff.v1.Filesystem.Download.Response"> & {
+export type Filesystem_Chunk_Download_Response = Message<"wasimoff.v1.Filesystem.Chunk.Download.Response"> & {
   /**
-   * @generated from field: wasimoff.v1.File download = 1;
+   * content address of the complete file
+   *
+   * @generated from field: string ref = 1;
    */
-  download?: File;
+  ref: string;
 
   /**
-   * @generated from field: string err = 2;
+   * media type of the complete file
+   *
+   * @generated from field: string media = 2;
    */
-  err: string;
+  media: string;
+
+  /**
+   * total size of the complete file
+   *
+   * @generated from field: uint64 size = 3;
+   */
+  size: bigint;
+
+  /**
+   * offset of this chunk within the file
+   *
+   * @generated from field: uint64 offset = 4;
+   */
+  offset: bigint;
+
+  /**
+   * chunk contents
+   *
+   * @generated from field: bytes data = 5;
+   */
+  data: Uint8Array;
+
+  /**
+   * sha256 digest of data, encoded like a ref
+   *
+   * @generated from field: string digest = 6;
+   */
+  digest: string;
 };
 
 /**
- * @generated from message wasimoff.v1.Filesystem.Download.Response
+ * @generated from message wasimoff.v1.Filesystem.Chunk.Download.Response
  */
-export type Filesystem_Download_ResponseJson = {
+export type Filesystem_Chunk_Download_ResponseJson = {
   /**
-   * @generated from field: wasimoff.v1.File download = 1;
+   * content address of the complete file
+   *
+   * @generated from field: string ref = 1;
    */
-  download?: FileJson;
+  ref?: string;
 
   /**
-   * @generated from field: string err = 2;
+   * media type of the complete file
+   *
+   * @generated from field: string media = 2;
    */
-  err?: string;
+  media?: string;
+
+  /**
+   * total size of the complete file
+   *
+   * @generated from field: uint64 size = 3;
+   */
+  size?: string;
+
+  /**
+   * offset of this chunk within the file
+   *
+   * @generated from field: uint64 offset = 4;
+   */
+  offset?: string;
+
+  /**
+   * chunk contents
+   *
+   * @generated from field: bytes data = 5;
+   */
+  data?: string;
+
+  /**
+   * sha256 digest of data, encoded like a ref
+   *
+   * @generated from field: string digest = 6;
+   */
+  digest?: string;
 };
 
 /**
- * Describes the message wasimoff.v1.Filesystem.Download.Response.
- * Use `create(Filesystem_Download_ResponseSchema)` to create a new message.
+ * Describes the message wasimoff.v1.Filesystem.Chunk.Download.Response.
+ * Use `create(Filesystem_Chunk_Download_ResponseSchema)` to create a new message.
  */
-export const Filesystem_Download_ResponseSchema: GenMessage<Filesystem_Download_Response, {jsonType: Filesystem_Download_ResponseJson}> = /*@__PURE__*/
-  messageDesc(file_proto_v1_messages, 3, 3, 1);
+export const Filesystem_Chunk_Download_ResponseSchema: GenMessage<Filesystem_Chunk_Download_Response, {jsonType: Filesystem_Chunk_Download_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 3, 7, 1, 1);
 
 /**
  * @generated from message wasimoff.v1.Event
@@ -1822,6 +2983,27 @@ export type Event_ClusterInfo = Message<"wasimoff.v1.Event.ClusterInfo"> & {
    * @generated from field: uint32 providers = 1;
    */
   providers: number;
+
+  /**
+   * total concurrency across all providers
+   *
+   * @generated from field: uint32 workers = 2;
+   */
+  workers: number;
+
+  /**
+   * currently running tasks across all providers
+   *
+   * @generated from field: uint32 busy = 3;
+   */
+  busy: number;
+
+  /**
+   * tasks waiting in the broker's queue
+   *
+   * @generated from field: uint32 queued = 4;
+   */
+  queued: number;
 };
 
 /**
@@ -1836,6 +3018,27 @@ export type Event_ClusterInfoJson = {
    * @generated from field: uint32 providers = 1;
    */
   providers?: number;
+
+  /**
+   * total concurrency across all providers
+   *
+   * @generated from field: uint32 workers = 2;
+   */
+  workers?: number;
+
+  /**
+   * currently running tasks across all providers
+   *
+   * @generated from field: uint32 busy = 3;
+   */
+  busy?: number;
+
+  /**
+   * tasks waiting in the broker's queue
+   *
+   * @generated from field: uint32 queued = 4;
+   */
+  queued?: number;
 };
 
 /**
@@ -1895,7 +3098,8 @@ export const Event_ThroughputSchema: GenMessage<Event_Throughput, {jsonType: Eve
   messageDesc(file_proto_v1_messages, 4, 3);
 
 /**
- * FileSystemUpdate notifies the Broker about changed files on the Provider.
+ * FileSystemUpdate notifies the Broker about changed files on the Provider. The
+ * Broker sends removed files to the Providers, so they can evict cached copies.
  *
  * @generated from message wasimoff.v1.Event.FileSystemUpdate
  */
@@ -1912,7 +3116,8 @@ export type Event_FileSystemUpdate = Message<"wasimoff.v1.Event.FileSystemUpdate
 };
 
 /**
- * FileSystemUpdate notifies the Broker about changed files on the Provider.
+ * FileSystemUpdate notifies the Broker about changed files on the Provider. The
+ * Broker sends removed files to the Providers, so they can evict cached copies.
  *
  * @generated from message wasimoff.v1.Event.FileSystemUpdate
  */
@@ -1935,6 +3140,195 @@ export type Event_FileSystemUpdateJson = {
 export const Event_FileSystemUpdateSchema: GenMessage<Event_FileSystemUpdate, {jsonType: Event_FileSystemUpdateJson}> = /*@__PURE__*/
   messageDesc(file_proto_v1_messages, 4, 4);
 
+/**
+ * Drain announces that the Provider wants to leave gracefully. The Broker stops
+ * scheduling new tasks to it but lets running tasks finish before closing.
+ *
+ * @generated from message wasimoff.v1.Event.Drain
+ */
+export type Event_Drain = Message<"wasimoff.v1.Event.Drain"> & {
+  /**
+   * freeform reason for logging
+   *
+   * @generated from field: string reason = 1;
+   */
+  reason: string;
+};
+
+/**
+ * Drain announces that the Provider wants to leave gracefully. The Broker stops
+ * scheduling new tasks to it but lets running tasks finish before closing.
+ *
+ * @generated from message wasimoff.v1.Event.Drain
+ */
+export type Event_DrainJson = {
+  /**
+   * freeform reason for logging
+   *
+   * @generated from field: string reason = 1;
+   */
+  reason?: string;
+};
+
+/**
+ * Describes the message wasimoff.v1.Event.Drain.
+ * Use `create(Event_DrainSchema)` to create a new message.
+ */
+export const Event_DrainSchema: GenMessage<Event_Drain, {jsonType: Event_DrainJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 4, 5);
+
+/**
+ * Subscribe is sent by Clients to receive ClusterInfo and Throughput events at
+ * a regular interval. The Broker may enforce a minimum interval.
+ *
+ * @generated from message wasimoff.v1.Event.Subscribe
+ */
+export type Event_Subscribe = Message<"wasimoff.v1.Event.Subscribe"> & {
+};
+
+/**
+ * Subscribe is sent by Clients to receive ClusterInfo and Throughput events at
+ * a regular interval. The Broker may enforce a minimum interval.
+ *
+ * @generated from message wasimoff.v1.Event.Subscribe
+ */
+export type Event_SubscribeJson = {
+};
+
+/**
+ * Describes the message wasimoff.v1.Event.Subscribe.
+ * Use `create(Event_SubscribeSchema)` to create a new message.
+ */
+export const Event_SubscribeSchema: GenMessage<Event_Subscribe, {jsonType: Event_SubscribeJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 4, 6);
+
+/**
+ * @generated from message wasimoff.v1.Event.Subscribe.Request
+ */
+export type Event_Subscribe_Request = Message<"wasimoff.v1.Event.Subscribe.Request"> & {
+  /**
+   * interval in milliseconds, zero unsubscribes
+   *
+   * @generated from field: uint32 interval = 1;
+   */
+  interval: number;
+};
+
+/**
+ * @generated from message wasimoff.v1.Event.Subscribe.Request
+ */
+export type Event_Subscribe_RequestJson = {
+  /**
+   * interval in milliseconds, zero unsubscribes
+   *
+   * @generated from field: uint32 interval = 1;
+   */
+  interval?: number;
+};
+
+/**
+ * Describes the message wasimoff.v1.Event.Subscribe.Request.
+ * Use `create(Event_Subscribe_RequestSchema)` to create a new message.
+ */
+export const Event_Subscribe_RequestSchema: GenMessage<Event_Subscribe_Request, {jsonType: Event_Subscribe_RequestJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 4, 6, 0);
+
+/**
+ * @generated from message wasimoff.v1.Event.Subscribe.Response
+ */
+export type Event_Subscribe_Response = Message<"wasimoff.v1.Event.Subscribe.Response"> & {
+  /**
+   * effective interval in milliseconds
+   *
+   * @generated from field: uint32 interval = 1;
+   */
+  interval: number;
+};
+
+/**
+ * @generated from message wasimoff.v1.Event.Subscribe.Response
+ */
+export type Event_Subscribe_ResponseJson = {
+  /**
+   * effective interval in milliseconds
+   *
+   * @generated from field: uint32 interval = 1;
+   */
+  interval?: number;
+};
+
+/**
+ * Describes the message wasimoff.v1.Event.Subscribe.Response.
+ * Use `create(Event_Subscribe_ResponseSchema)` to create a new message.
+ */
+export const Event_Subscribe_ResponseSchema: GenMessage<Event_Subscribe_Response, {jsonType: Event_Subscribe_ResponseJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 4, 6, 1);
+
+/**
+ * Session is sent by the Broker upon connection. A Provider can reconnect with the
+ * token within the grace period to resume its session after a connection loss.
+ *
+ * @generated from message wasimoff.v1.Event.Session
+ */
+export type Event_Session = Message<"wasimoff.v1.Event.Session"> & {
+  /**
+   * stable identifier of this Provider on the Broker
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * secret to present as `?session=` query parameter
+   *
+   * @generated from field: string token = 2;
+   */
+  token: string;
+
+  /**
+   * grace period in seconds, zero if resumption is disabled
+   *
+   * @generated from field: uint32 grace = 3;
+   */
+  grace: number;
+};
+
+/**
+ * Session is sent by the Broker upon connection. A Provider can reconnect with the
+ * token within the grace period to resume its session after a connection loss.
+ *
+ * @generated from message wasimoff.v1.Event.Session
+ */
+export type Event_SessionJson = {
+  /**
+   * stable identifier of this Provider on the Broker
+   *
+   * @generated from field: string id = 1;
+   */
+  id?: string;
+
+  /**
+   * secret to present as `?session=` query parameter
+   *
+   * @generated from field: string token = 2;
+   */
+  token?: string;
+
+  /**
+   * grace period in seconds, zero if resumption is disabled
+   *
+   * @generated from field: uint32 grace = 3;
+   */
+  grace?: number;
+};
+
+/**
+ * Describes the message wasimoff.v1.Event.Session.
+ * Use `create(Event_SessionSchema)` to create a new message.
+ */
+export const Event_SessionSchema: GenMessage<Event_Session, {jsonType: Event_SessionJson}> = /*@__PURE__*/
+  messageDesc(file_proto_v1_messages, 4, 7);
+
 /**
  * Ping is sent in Request and Response pairs to make use of existing sequence
  * number handlers and is used to measure latency of Providers. It necessarily
@@ -2035,6 +3429,30 @@ export const Tasks: GenService<{
     input: typeof Filesystem_Upload_RequestSchema;
     output: typeof Filesystem_Upload_ResponseSchema;
   },
+  /**
+   * @generated from rpc wasimoff.v1.Tasks.UploadChunk
+   */
+  uploadChunk: {
+    methodKind: "unary";
+    input: typeof Filesystem_Chunk_Upload_RequestSchema;
+    output: typeof Filesystem_Chunk_Upload_ResponseSchema;
+  },
+  /**
+   * @generated from rpc wasimoff.v1.Tasks.DownloadChunk
+   */
+  downloadChunk: {
+    methodKind: "unary";
+    input: typeof Filesystem_Chunk_Download_RequestSchema;
+    output: typeof Filesystem_Chunk_Download_ResponseSchema;
+  },
+  /**
+   * @generated from rpc wasimoff.v1.Tasks.ListFiles
+   */
+  listFiles: {
+    methodKind: "unary";
+    input: typeof Filesystem_List_RequestSchema;
+    output: typeof Filesystem_List_ResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_proto_v1_messages, 0);
 
@@ -26,6 +26,9 @@ export class ProviderStorage {
   // cache zip archives for rootfs
   private zipCache: LRUCache<string, ArrayBuffer>;
 
+  // download files over the broker connection in chunks instead of fetching from origin
+  public download?: ChunkDownload;
+
   constructor(filesystem?: ProviderStorageFileSystem, fetchOrigin?: string) {
     // if no filesystem was given, instantiate a MemoryFilesystem
     if (filesystem === undefined) this.filesystem = new MemoryFileSystem();
@@ -70,6 +73,9 @@ export class ProviderStorage {
 
   // fetch a file from the backend
   private async fetchFile(filename: string): Promise<File | undefined> {
+    // prefer chunked downloads over the connection, so no single message holds the file
+    if (this.download !== undefined) return this.downloadFile(filename);
+
     // request the file from broker
     console.warn(...logprefix, `file ${filename} not found locally, fetch from broker`);
     let response = await fetch(`${this.origin}/api/storage/${filename}`);
@@ -87,12 +93,52 @@ export class ProviderStorage {
     return file;
   }
 
+  // download a file from the broker in sequential chunks and verify it against its ref
+  private async downloadFile(filename: string): Promise<File> {
+    console.warn(...logprefix, `file ${filename} not found locally, download from broker`);
+    let chunks: Uint8Array<ArrayBuffer>[] = [];
+    let first = await this.download!(filename, 0);
+    let { ref, media, size } = first;
+    let chunk = first;
+    let offset = 0;
+    while (true) {
+      if ((await getRef(chunk.data as Uint8Array<ArrayBuffer>)) !== chunk.digest) {
+        throw `chunk at offset ${offset}: digest mismatch`;
+      }
+      if (chunk.ref !== ref) throw `chunk at offset ${offset}: file changed during download`;
+      chunks.push(chunk.data as Uint8Array<ArrayBuffer>);
+      offset += chunk.data.length;
+      if (offset >= size) break;
+      if (chunk.data.length === 0) throw `chunk at offset ${offset}: no data before end of file`;
+      chunk = await this.download!(filename, offset);
+    }
+
+    // verify the complete file before storing
+    let file = new File(chunks, ref, { type: media });
+    let computed = await getRef(new Uint8Array(await file.arrayBuffer()));
+    if (computed !== ref) throw `download complete but digest ${computed} does not match ref`;
+    await this.filesystem.put(ref, file);
+
+    // emit event for broker
+    this.updates.emit({ added: [ref] });
+    return file;
+  }
+
   // TODO: emitting events for removed files requires shimming the FileSystem functions
 
+  /** Evict files, which were deleted on the broker, from the filesystem and the caches. */
+  async evict(filenames: string[]) {
+    for (const filename of filenames) {
+      this.wasmCache.delete(filename);
+      this.zipCache.delete(filename);
+      await this.filesystem.delete(filename);
+    }
+  }
+
   // either return a file from filesystem or attempt to fetch it remotely
   private async getFile(filename: string): Promise<File | undefined> {
     let file = await this.filesystem.get(filename);
-    if (!file && this.origin) file = await this.fetchFile(filename);
+    if (!file && (this.origin || this.download)) file = await this.fetchFile(filename);
     return file;
   }
 
@@ -105,8 +151,21 @@ export class ProviderStorage {
   async getZipArchive(filename: string): Promise<ArrayBuffer | undefined> {
     return this.zipCache.fetch(filename);
   }
+
+  /** Get the plain contents of a file, e.g. a large stdin. These are not cached. */
+  async getBytes(filename: string): Promise<Uint8Array | undefined> {
+    let file = await this.getFile(filename);
+    if (file === undefined) return undefined;
+    return new Uint8Array(await file.arrayBuffer());
+  }
 }
 
+/** ChunkDownload requests a single chunk of a file from the broker, starting at offset. */
+export type ChunkDownload = (
+  file: string,
+  offset: number,
+) => Promise<{ ref: string; media: string; size: number; data: Uint8Array; digest: string }>;
+
 /** ProviderStorageFileSystem is an underlying structure, which actually holds the
  * files. It minimally needs to support list, has, get, put and delete operations,
  * like a Map<string, File>. */
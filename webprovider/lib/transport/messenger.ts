@@ -94,8 +94,9 @@ export class Messenger implements MessengerInterface {
           break;
 
         case Envelope_MessageType.Event:
-          // push the event to the iterable
+          // push the event to the iterable, unless it was handled already
           let e = anyUnpack(m.envelope.payload!, this.registry);
+          if (e !== undefined && this.eventhandler?.(e)) break;
           this.events.push(e!);
           break;
 
@@ -139,6 +140,10 @@ export class Messenger implements MessengerInterface {
 
   events = new PushableAsyncIterable<ProtoMessage>();
 
+  /** Optional handler for events, which are consumed by the application itself.
+   * Events are only pushed to the iterable if the handler does not return true. */
+  eventhandler?: (event: ProtoMessage) => boolean;
+
   private eventSequence = 0n;
   async sendEvent(event: ProtoMessage): Promise<void> {
     // envelope the event and send it off
@@ -5,11 +5,20 @@ export {};
 import { ProviderStorage, ProviderStorageFileSystem } from "@wasimoff/storage/index";
 import { Messenger, WebRTCTransport, WebSocketTransport } from "@wasimoff/transport/index";
 import { WasiWorkerPool } from "./workerpool";
-import { create, Message } from "@bufbuild/protobuf";
+import { create, isMessage, Message } from "@bufbuild/protobuf";
 import {
+  Event_DrainSchema,
   Event_FileSystemUpdateSchema,
   Event_ProviderResourcesSchema,
+  Event_SessionSchema,
+  Filesystem_Chunk_Download_RequestSchema,
+  Filesystem_Chunk_Download_ResponseSchema,
+  Task_Deliver_RequestSchema,
   Task_Metadata,
+  Task_Pyodide_Response,
+  Task_Pyodide_ResponseSchema,
+  Task_Wasip1_Response,
+  Task_Wasip1_ResponseSchema,
 } from "@wasimoff/proto/v1/messages_pb";
 import { rpchandler } from "@wasimoff/worker/rpchandler";
 import { expose, proxy, transfer, workerReady } from "./comlink";
@@ -73,7 +82,13 @@ export class WasimoffProvider {
     });
   }
 
-  static async init(nmax: number, origin: string, fs: ProviderStorageFileSystem, id?: string) {
+  static async init(
+    nmax: number,
+    origin: string,
+    fs: ProviderStorageFileSystem,
+    id?: string,
+    token?: string,
+  ) {
     const p = new WasimoffProvider(nmax);
 
     // recheck the origin
@@ -91,7 +106,7 @@ export class WasimoffProvider {
       await p.open(undefined, fs);
     }
 
-    await p.connect(url.href, id);
+    await p.connect(url.href, id, token);
 
     return p;
   }
@@ -222,6 +237,23 @@ export class WasimoffProvider {
     this.storage.updates.on((update) => {
       if (this.messenger) this.messenger.sendEvent(create(Event_FileSystemUpdateSchema, update));
     });
+
+    // download missing files in chunks over the current broker connection
+    this.storage.download = async (file, offset) => {
+      const messenger = this.messenger;
+      if (messenger === undefined || messenger.closed.aborted) throw "not connected to a broker";
+      const request = create(Filesystem_Chunk_Download_RequestSchema, {
+        file,
+        offset: BigInt(offset),
+      });
+      const response = await messenger.sendRequest(request);
+      if (response instanceof Error) throw response;
+      if (!isMessage(response, Filesystem_Chunk_Download_ResponseSchema)) {
+        throw "unexpected response to chunk download";
+      }
+      const { ref, media, size, data, digest } = response;
+      return { ref, media, size: Number(size), data, digest };
+    };
   }
 
   // --------->  messenger connections
@@ -232,8 +264,8 @@ export class WasimoffProvider {
     return proxy(this.messenger);
   }
 
-  // (re)connect to a broker by url
-  async connect(origin: string, id?: string) {
+  // (re)connect to a broker by url, optionally with an enrollment token or JWT
+  async connect(origin: string, id?: string, token?: string) {
     // close previous connections
     if (this.messenger !== undefined && !this.messenger.closed.aborted) {
       this.messenger.close("reconnecting");
@@ -243,6 +275,8 @@ export class WasimoffProvider {
     if (!url) throw "cannot parse url";
     let isArtDeco = /^ads?:$/.test(url.protocol);
     if (id !== undefined) url.searchParams.set("id", id);
+    if (token !== undefined) url.searchParams.set("token", token);
+    if (this.session !== undefined) url.searchParams.set("session", this.session.token);
 
     if (isArtDeco) {
       // construct new url because switching from custom to non-custom scheme is not supported
@@ -251,6 +285,7 @@ export class WasimoffProvider {
       console.debug(...WasimoffProvider.logprefix, "connecting to ad", url.href);
       const rtcTransport = await WebRTCTransport.connect(url);
       this.messenger = new Messenger(rtcTransport);
+      this.messenger.eventhandler = (event) => this.handleEvent(event);
       await rtcTransport.ready;
     } else {
       url.protocol = url.protocol.replace("http", "ws");
@@ -258,6 +293,7 @@ export class WasimoffProvider {
       console.debug(...WasimoffProvider.logprefix, "connecting to wasimoff", url.href);
       const wst = WebSocketTransport.connect(url);
       this.messenger = new Messenger(wst);
+      this.messenger.eventhandler = (event) => this.handleEvent(event);
       await wst.ready;
     }
 
@@ -270,6 +306,14 @@ export class WasimoffProvider {
     }
   }
 
+  /** Announce a graceful exit, so the broker stops scheduling new tasks here
+   * but lets the running tasks finish before the connection is closed. */
+  async drain(reason = "shutting down") {
+    if (this.messenger !== undefined && !this.messenger.closed.aborted) {
+      await this.messenger.sendEvent(create(Event_DrainSchema, { reason }));
+    }
+  }
+
   // --------->  handle rpc requests on messenger
 
   // bind the rpchandler function into this class
@@ -290,7 +334,84 @@ export class WasimoffProvider {
 
     // this will loop until the messenger is closed
     for await (const request of this.messenger.requests) {
-      request((request) => this.rpchandler(request));
+      let response: Message | undefined;
+      request(async (request) => (response = await this.rpchandler(request))).catch(() => {
+        // the connection was lost before the response could be sent
+        if (response !== undefined) this.undeliverable(response);
+      });
+    }
+  }
+
+  // --------->  session resumption
+
+  /** Session parameters announced by the broker, to resume after a connection loss. */
+  public session?: { id: string; token: string; grace: number };
+
+  /** IDs of the tasks, which are currently handled in the rpchandler. */
+  public readonly tasks = new Set<string>();
+
+  /** Results of tasks, whose connection was lost before they could be sent. */
+  public readonly undelivered = new Map<string, Task_Wasip1_Response | Task_Pyodide_Response>();
+
+  /** Handle events, which concern the provider itself. Returns true if the event
+   * was consumed and should not be passed on to the event iterable. */
+  private handleEvent(event: Message): boolean {
+    switch (true) {
+      case isMessage(event, Event_SessionSchema):
+        if (this.session !== undefined && this.session.id !== event.id) {
+          // the previous session was not resumed, its results are not expected anymore
+          this.undelivered.clear();
+        }
+        this.session = { id: event.id, token: event.token, grace: event.grace };
+        this.deliver();
+        return true;
+
+      case isMessage(event, Event_FileSystemUpdateSchema):
+        // files deleted on the broker must not be used by later tasks anymore
+        if (event.removed.length > 0 && this.storage !== undefined) {
+          this.storage.evict(event.removed).catch((err) => {
+            console.warn(...WasimoffProvider.logprefix, "evicting files failed:", err);
+          });
+        }
+        return false;
+
+      default:
+        return false;
+    }
+  }
+
+  /** Keep the result of a task, whose response could not be sent anymore, to
+   * deliver it on a resumed session. */
+  private undeliverable(response: Message) {
+    if (this.session === undefined || this.session.grace === 0) return;
+    if (
+      isMessage(response, Task_Wasip1_ResponseSchema) ||
+      isMessage(response, Task_Pyodide_ResponseSchema)
+    ) {
+      const id = response.info?.id;
+      if (id === undefined || id === "") return;
+      this.undelivered.set(id, response);
+      this.deliver();
+    }
+  }
+
+  /** Deliver the kept results on the current connection. */
+  async deliver() {
+    const messenger = this.messenger;
+    if (messenger === undefined || messenger.closed.aborted) return;
+    for (const [id, response] of this.undelivered) {
+      const result = isMessage(response, Task_Wasip1_ResponseSchema)
+        ? { case: "wasip1" as const, value: response }
+        : { case: "pyodide" as const, value: response };
+      try {
+        const ack = await messenger.sendRequest(create(Task_Deliver_RequestSchema, { result }));
+        if (ack instanceof Error) {
+          console.warn(...WasimoffProvider.logprefix, `delivering ${id} failed:`, ack.message);
+        }
+        this.undelivered.delete(id);
+      } catch {
+        return; // connection lost again, retry on the next session
+      }
     }
   }
 
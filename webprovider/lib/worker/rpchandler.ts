@@ -2,7 +2,7 @@ import { create, isMessage, Message as ProtoMessage } from "@bufbuild/protobuf";
 import * as wasimoff from "@wasimoff/proto/v1/messages_pb";
 import { getRef, isRef, ProviderStorage } from "@wasimoff/storage/index";
 import { type WasimoffProvider } from "./provider";
-import { PyodideTaskParams, Wasip1TaskParams } from "./wasiworker";
+import { PyodideTaskParams, Wasip1Layer, Wasip1TaskParams } from "./wasiworker";
 
 // Handle incoming RemoteProcedureCalls on the Messenger iterable. Moved into a
 // separate file for better readability and separation of concerns in a way.
@@ -81,14 +81,16 @@ export async function rpchandler(
         }
 
         console.debug(...rpcHandlerPrefix, info.id, task);
+        this.tasks.add(info.id);
         try {
           // execute the module in a worker
           let run = await this.pool.runWasip1(info, {
             wasm: wasm,
             argv: task.args,
             envs: task.envs,
-            stdin: task.stdin,
+            stdin: await getStdin(this.storage, task.stdin, task.stdinFile),
             rootfs: await getRootfsZip(this.storage, task.rootfs),
+            layers: await getLayers(this.storage, task.layers),
             artifacts: task.artifacts,
           } as Wasip1TaskParams);
           // send back the result
@@ -112,6 +114,8 @@ export async function rpchandler(
             info: info,
             result: { case: "error", value: String(err) },
           });
+        } finally {
+          this.tasks.delete(info.id);
         }
       })();
 
@@ -137,10 +141,12 @@ export async function rpchandler(
         };
 
         console.debug(...rpcHandlerPrefix, info.id, task);
+        this.tasks.add(info.id);
         try {
           let run = await this.pool.runPyodide(info, task);
           traceEvent(info, wasimoff.Task_TraceEvent_EventType.ProviderTransmitResult);
           return create(wasimoff.Task_Pyodide_ResponseSchema, {
+            info: info,
             result: {
               case: "ok",
               value: {
@@ -159,9 +165,20 @@ export async function rpchandler(
             info: info,
             result: { case: "error", value: String(err) },
           });
+        } finally {
+          this.tasks.delete(info.id);
         }
       })();
 
+    // tell the broker which tasks of a resumed session are unknown here
+    case isMessage(request, wasimoff.Task_Resume_RequestSchema):
+      return <Promise<wasimoff.Task_Resume_Response>>(async () => {
+        const unknown = request.pending.filter(
+          (id) => !this.tasks.has(id) && !this.undelivered.has(id),
+        );
+        return create(wasimoff.Task_Resume_ResponseSchema, { unknown });
+      })();
+
     // cancel a running task
     case isMessage(request, wasimoff.Task_CancelSchema):
       return <Promise<wasimoff.Task_Cancel>>(async () => {
@@ -201,7 +218,7 @@ export async function rpchandler(
         // overwrite name with computed digest
         if (!isRef(ref)) ref = await getRef(blob);
         await this.storage.filesystem.put(ref, new File([blob], ref, { type: media }));
-        return create(wasimoff.Filesystem_Upload_ResponseSchema, {});
+        return create(wasimoff.Filesystem_Upload_ResponseSchema, { ref });
       })();
 
     // ping should have already been handled, but let's add it here as fallback
@@ -235,3 +252,30 @@ export async function getRootfsZip(
     }
   }
 }
+
+// get the stdin of a task, which may be stored separately when it is large
+export async function getStdin(
+  storage: ProviderStorage | undefined,
+  stdin?: Uint8Array,
+  file?: wasimoff.File,
+): Promise<Uint8Array | undefined> {
+  if (file === undefined || file.ref === "") return stdin;
+  if (storage === undefined) throw "cannot access storage yet";
+  const bytes = await storage.getBytes(file.ref);
+  if (bytes === undefined) throw "stdin not found in storage";
+  return bytes;
+}
+
+// get the archives of all filesystem layers in a task
+export async function getLayers(
+  storage: ProviderStorage | undefined,
+  layers: wasimoff.Task_Layer[],
+): Promise<Wasip1Layer[]> {
+  return Promise.all(
+    layers.map(async (layer) => {
+      const archive = await getRootfsZip(storage, layer.archive);
+      if (archive === undefined) throw "layer.archive cannot be undefined";
+      return { archive, mount: layer.mount || "/", readonly: layer.readonly };
+    }),
+  );
+}
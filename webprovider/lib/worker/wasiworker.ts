@@ -302,12 +302,24 @@ export type Wasip1TaskParams = {
   stdin?: Uint8Array;
   /** Load files for preloaded filesystem from a zip archive. */
   rootfs?: Uint8Array;
+  /** Further zip archives, which are unpacked on top of the rootfs in order. */
+  layers?: Wasip1Layer[];
   /** Send back a zip archive with artifacts after successful execution. */
   artifacts?: string[];
   /** Wrap the WASI imports in `strace` for improved debug visibility. */
   strace?: boolean;
 };
 
+/** Filesystem layer of a wasip1 task. */
+export type Wasip1Layer = {
+  /** Zip archive with the files of this layer. */
+  archive: Uint8Array;
+  /** Absolute path to unpack the archive at. */
+  mount: string;
+  /** Files of this layer can't be modified by the task. */
+  readonly: boolean;
+};
+
 /** Result of a wasip1 task. */
 export type Wasip1TaskResult = {
   /** The returned exit code, where `0` usually indicates success. */
@@ -357,8 +369,16 @@ export type PyodideTaskResult = {
 async function preopenFilesystem(task: Wasip1TaskParams): Promise<Fd[]> {
   // prepare a rootfs and optionally extract zip file
   let rootfs = new PreopenDirectory(".", new Map());
-  if (task.rootfs !== undefined) {
-    rootfs = await extractRootfs(task.rootfs);
+  const layers = task.layers ?? [];
+  if (task.rootfs !== undefined || layers.length > 0) {
+    let root = new Map<string, Inode>();
+    if (task.rootfs !== undefined) await extractZip(task.rootfs, root);
+    // merge the layers on top, later ones overwrite the files of earlier ones
+    for (const layer of layers) {
+      const path = layer.mount.split("/").filter((name) => name !== "");
+      await extractZip(layer.archive, mkdirs(root, path), layer.readonly);
+    }
+    rootfs = new PreopenDirectory("/", root);
   }
   // return file descriptors
   return [
@@ -369,44 +389,44 @@ async function preopenFilesystem(task: Wasip1TaskParams): Promise<Fd[]> {
   ];
 }
 
-/** Extract a ZipReader to a preopened directory for the browser_wasi_shim */
-async function extractRootfs(archive: Uint8Array): Promise<PreopenDirectory> {
+/** Extract a ZipReader into the nested directory map of the browser_wasi_shim. Existing
+ * directories are merged and existing files are overwritten. */
+async function extractZip(archive: Uint8Array, root: Map<string, Inode>, readonly = false) {
   const zip = new ZipReader(new Uint8ArrayReader(archive));
 
   // TODO: can we use create_entry_for_path directly?
-  let root = new Map<string, Inode>();
   for await (const entry of zip.getEntriesGenerator()) {
-    let pwd = root; // current working dir
-
     // descend to the corrent node, creating directories along the way
     if (entry.filename.endsWith("/")) entry.filename = entry.filename.slice(0, -1);
     const path = entry.filename.split("/");
-    for (const [i, name] of path.entries()) {
-      // last path component => set the contents
-      if (i === path.length - 1) {
-        if (entry.directory) {
-          // set an empty directory
-          pwd.set(name, new Directory(new Map()));
-        } else {
-          // get contents and insert a File
-          let bufwriter = new Uint8ArrayWriter();
-          await entry.getData!(bufwriter);
-          pwd.set(name, new File(await bufwriter.getData()));
-        }
-        continue;
-      } else {
-        // create if directory does not exist
-        if (!(pwd.get(name) instanceof Directory)) {
-          pwd.set(name, new Directory(new Map()));
-        }
-        // descend into directory
-        pwd = (pwd.get(name) as Directory).contents;
-      }
-    } // path.entries
+    const name = path.pop()!;
+    const pwd = mkdirs(root, path); // current working dir
+
+    // last path component => set the contents
+    if (entry.directory) {
+      // set an empty directory, unless it exists already
+      if (!(pwd.get(name) instanceof Directory)) pwd.set(name, new Directory(new Map()));
+    } else {
+      // get contents and insert a File
+      let bufwriter = new Uint8ArrayWriter();
+      await entry.getData!(bufwriter);
+      pwd.set(name, new File(await bufwriter.getData(), { readonly }));
+    }
   } // zip.getEntriesGenerator
+}
 
-  // return nested map as preopened directory
-  return new PreopenDirectory("/", root);
+/** Descend into a path of the nested directory map, creating directories along the way. */
+function mkdirs(root: Map<string, Inode>, path: string[]): Map<string, Inode> {
+  let pwd = root;
+  for (const name of path) {
+    // create if directory does not exist
+    if (!(pwd.get(name) instanceof Directory)) {
+      pwd.set(name, new Directory(new Map()));
+    }
+    // descend into directory
+    pwd = (pwd.get(name) as Directory).contents;
+  }
+  return pwd;
 }
 
 /** Pack requested artifacts with a ZipWriter to send back. */
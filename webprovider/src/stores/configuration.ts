@@ -8,6 +8,8 @@ type Configuration = {
   workers: number | null;
   // broker transport URL
   transport: string | null;
+  // enrollment token or JWT to authenticate at the broker
+  token: string | null;
   // be more verbose
   verbose: boolean | null;
 };
@@ -20,6 +22,7 @@ export const useConfiguration = defineStore("Configuration", () => {
     autoconnect: true,
     workers: navigator.hardwareConcurrency, // i.e. number of logical cores
     transport: window.location.origin,
+    token: null,
     verbose: false,
   };
 
@@ -46,6 +49,8 @@ export const useConfiguration = defineStore("Configuration", () => {
 
     transport: fragments.get("transport"),
 
+    token: fragments.get("token"),
+
     verbose: asBoolean(fragments.get("verbose")),
   };
 
@@ -53,9 +58,10 @@ export const useConfiguration = defineStore("Configuration", () => {
   const autoconnect = computed(() => firstOf(fragmentconf.autoconnect, defaultconf.autoconnect));
   const workers = computed(() => firstOf(fragmentconf.workers, defaultconf.workers));
   const transport = computed(() => firstOf(fragmentconf.transport, defaultconf.transport));
+  const token = computed(() => firstOf(fragmentconf.token, defaultconf.token));
   const verbose = computed(() => firstOf(fragmentconf.verbose, defaultconf.verbose));
 
-  return { fragmentconf, defaultconf, autoconnect, workers, transport, verbose };
+  return { fragmentconf, defaultconf, autoconnect, workers, transport, token, verbose };
 });
 
 // ---------- helpers for the fragment parsing --------- //